@@ -2,13 +2,18 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/tblang/core/internal/compiler"
 	"github.com/tblang/core/internal/engine"
+	"github.com/tblang/core/internal/formatter"
+	"github.com/tblang/core/internal/lsp"
 )
 
 var (
@@ -27,6 +32,13 @@ var rootCmd = &cobra.Command{
 It provides a simple, readable syntax for managing cloud infrastructure
 with a plugin-based architecture supporting multiple cloud providers.`,
 	Version: "1.1.1",
+
+	SilenceErrors: true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Arguments have been validated by now, so any later error is a
+		// diagnostic rather than a usage mistake.
+		cmd.SilenceUsage = true
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
 			cmd.Help()
@@ -41,20 +53,48 @@ var planCmd = &cobra.Command{
 	Long:  `Analyze the TBLang configuration file and show what resources will be created, updated, or destroyed.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		vars, err := inputVariablesFromFlags(cmd)
+		if err != nil {
+			return err
+		}
 		return runWithEngine(func(ctx context.Context, engine *engine.Engine) error {
+			engine.SetInputVariables(vars)
+			out, _ := cmd.Flags().GetString("out")
 			infoColor.Println("Planning infrastructure changes...")
-			return engine.Plan(ctx, args[0])
+			return engine.Plan(ctx, args[0], out)
 		})
 	},
 }
 
 var applyCmd = &cobra.Command{
-	Use:   "apply [file.tbl]",
+	Use:   "apply [file.tbl | plan.tbplan]",
 	Short: "Apply infrastructure changes",
-	Long:  `Create, update, or delete infrastructure resources as defined in the TBLang configuration file.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Create, update, or delete infrastructure resources as defined in the TBLang configuration file.
+Given a plan file saved by plan -out, apply performs exactly the changes in that plan.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		parallelism, err := parallelismFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		if engine.IsPlanFile(args[0]) {
+			if cmd.Flags().Changed("var") || cmd.Flags().Changed("var-file") {
+				return fmt.Errorf("variables cannot be set when applying a saved plan")
+			}
+			return runWithEngine(func(ctx context.Context, engine *engine.Engine) error {
+				engine.SetParallelism(parallelism)
+				return engine.ApplyPlan(ctx, args[0])
+			})
+		}
+
+		vars, err := inputVariablesFromFlags(cmd)
+		if err != nil {
+			return err
+		}
 		return runWithEngine(func(ctx context.Context, engine *engine.Engine) error {
+			engine.SetInputVariables(vars)
+			engine.SetParallelism(parallelism)
 			infoColor.Println("Applying infrastructure changes...")
 			return engine.Apply(ctx, args[0])
 		})
@@ -67,7 +107,17 @@ var destroyCmd = &cobra.Command{
 	Long:  `Destroy all infrastructure resources defined in the TBLang configuration file.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		parallelism, err := parallelismFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		vars, err := inputVariablesFromFlags(cmd)
+		if err != nil {
+			return err
+		}
 		return runWithEngine(func(ctx context.Context, engine *engine.Engine) error {
+			engine.SetInputVariables(vars)
+			engine.SetParallelism(parallelism)
 			warningColor.Println("Destroying infrastructure...")
 			return engine.Destroy(ctx, args[0])
 		})
@@ -125,6 +175,82 @@ var pluginsListCmd = &cobra.Command{
 	},
 }
 
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [path]",
+	Short: "Format .tbl files",
+	Long: `Rewrite .tbl files in the canonical TBLang style. The path may be a file or
+a directory, which is searched recursively; it defaults to the current
+directory. With -check or -diff no files are written.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+		check, _ := cmd.Flags().GetBool("check")
+		diff, _ := cmd.Flags().GetBool("diff")
+		return formatPath(path, check, diff)
+	},
+}
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Run the language server",
+	Long:  `Run a Language Server Protocol server on stdin and stdout for editor integration.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// stdout carries the protocol, so everything the compiler and plugin
+		// manager print goes to stderr, which editors keep as the server log.
+		stdout := os.Stdout
+		os.Stdout = os.Stderr
+		color.Output = os.Stderr
+
+		return runWithEngine(func(ctx context.Context, engine *engine.Engine) error {
+			return lsp.NewServer(os.Stdin, stdout, engine.Schemas(ctx)).Run()
+		})
+	},
+}
+
+func formatPath(path string, check, diff bool) error {
+	files, err := formatter.Files(path)
+	if err != nil {
+		return err
+	}
+
+	unformatted := 0
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		formatted, err := formatter.Source(file, src)
+		if err != nil {
+			return err
+		}
+		if string(formatted) == string(src) {
+			continue
+		}
+
+		unformatted++
+		switch {
+		case diff:
+			fmt.Print(formatter.Diff(file, src, formatted))
+		case check:
+			fmt.Println(file)
+		default:
+			if err := os.WriteFile(file, formatted, 0644); err != nil {
+				return err
+			}
+			fmt.Println(file)
+		}
+	}
+
+	if check && unformatted > 0 {
+		return fmt.Errorf("%d file(s) need formatting", unformatted)
+	}
+	return nil
+}
+
 func printCredits() {
 	cyan := color.New(color.FgCyan, color.Bold)
 	magenta := color.New(color.FgMagenta, color.Bold)
@@ -153,9 +279,23 @@ func init() {
 	rootCmd.AddCommand(showCmd)
 	rootCmd.AddCommand(graphCmd)
 	rootCmd.AddCommand(pluginsCmd)
+	rootCmd.AddCommand(fmtCmd)
+	rootCmd.AddCommand(lspCmd)
 
 	pluginsCmd.AddCommand(pluginsListCmd)
 
+	for _, cmd := range []*cobra.Command{planCmd, applyCmd, destroyCmd} {
+		cmd.Flags().StringArray("var", nil, "Set a variable value (key=value)")
+		cmd.Flags().StringArray("var-file", nil, "Load variable values from a .tbvars file")
+	}
+
+	planCmd.Flags().String("out", "", "Save the plan to a file that apply can execute")
+	applyCmd.Flags().Int("parallelism", engine.DefaultParallelism, "Limit the number of resources applied at once")
+	destroyCmd.Flags().Int("parallelism", engine.DefaultParallelism, "Limit the number of resources destroyed at once")
+
+	fmtCmd.Flags().Bool("check", false, "Report unformatted files and exit non-zero instead of writing them")
+	fmtCmd.Flags().Bool("diff", false, "Print the changes formatting would make instead of writing them")
+
 	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output")
 }
 
@@ -173,6 +313,47 @@ func getCreditsString() string {
 		cyan.Sprint("╚════════════════════════════════════════════════════════╝")
 }
 
+func parallelismFromFlags(cmd *cobra.Command) (int, error) {
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+	if parallelism < 1 {
+		return 0, fmt.Errorf("-parallelism must be at least 1, got %d", parallelism)
+	}
+	return parallelism, nil
+}
+
+func inputVariablesFromFlags(cmd *cobra.Command) (*compiler.InputVariables, error) {
+	flags, _ := cmd.Flags().GetStringArray("var")
+	files, _ := cmd.Flags().GetStringArray("var-file")
+
+	vars := &compiler.InputVariables{
+		Flags: make(map[string]string),
+		Files: files,
+	}
+	for _, flag := range flags {
+		key, value, found := strings.Cut(flag, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid -var %q: expected key=value", flag)
+		}
+		vars.Flags[key] = value
+	}
+
+	return vars, nil
+}
+
+// normalizeArgs rewrites single-dash long flags such as -var or -var-file=x
+// into the double-dash form cobra expects.
+func normalizeArgs(args []string) []string {
+	normalized := make([]string, len(args))
+	for i, arg := range args {
+		name, _, _ := strings.Cut(arg, "=")
+		if len(name) > 2 && name[0] == '-' && name[1] != '-' {
+			arg = "-" + arg
+		}
+		normalized[i] = arg
+	}
+	return normalized
+}
+
 func runWithEngine(fn func(context.Context, *engine.Engine) error) error {
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -202,6 +383,7 @@ func runWithEngine(fn func(context.Context, *engine.Engine) error) error {
 }
 
 func main() {
+	rootCmd.SetArgs(normalizeArgs(os.Args[1:]))
 	if err := rootCmd.Execute(); err != nil {
 		errorColor.Printf("Error: %v\n", err)
 		os.Exit(1)
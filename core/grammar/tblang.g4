@@ -7,11 +7,20 @@ program
     : statement* EOF
     ;
 
+// Entry point for -var-file files: key = value lines
+varFile
+    : property* EOF
+    ;
+
 // Top-level statements
 statement
     : blockDeclaration       // cloud_vendor "aws" { ... }
     | variableDeclaration    // declare vpc_configuration = { ... }
     | forLoop                // for item in list { ... }
+    | ifStatement            // if cond { ... } else { ... }
+    | functionDeclaration    // func name(a, b) { ... }
+    | returnStatement        // return expression
+    | importStatement        // import "./network.tbl" as net
     | functionCall           // print(vpc_out) or ec2(...)
     | SEMICOLON              // Empty statement
     ;
@@ -26,14 +35,42 @@ variableDeclaration
     : DECLARE IDENTIFIER ASSIGN expression SEMICOLON?
     ;
 
-// For loop: for item in collection { statements }
+// For loop: for item in collection { ... } or for key, value in collection { ... }
 forLoop
-    : FOR IDENTIFIER IN expression LBRACE statement* RBRACE
+    : FOR IDENTIFIER (COMMA IDENTIFIER)? IN expression LBRACE statement* RBRACE
+    ;
+
+// Conditional: if condition { statements } else { statements }
+ifStatement
+    : IF expression LBRACE statement* RBRACE elseClause?
+    ;
+
+elseClause
+    : ELSE ifStatement
+    | ELSE LBRACE statement* RBRACE
+    ;
+
+// Function declaration: func name(params) { statements }
+functionDeclaration
+    : FUNC IDENTIFIER LPAREN parameterList? RPAREN LBRACE statement* RBRACE
+    ;
+
+parameterList
+    : IDENTIFIER (COMMA IDENTIFIER)*
+    ;
+
+returnStatement
+    : RETURN expression SEMICOLON?
+    ;
+
+// Module import: import "path.tbl" as alias { inputs }
+importStatement
+    : IMPORT STRING_LITERAL AS IDENTIFIER objectLiteral? SEMICOLON?
     ;
 
 // Properties inside blocks: key = value
 property
-    : IDENTIFIER ASSIGN expression SEMICOLON?
+    : IDENTIFIER ASSIGN expression (SEMICOLON | COMMA)?
     ;
 
 // Function calls: functionName(arg1, arg2, ...)
@@ -54,9 +91,20 @@ expression
     | IDENTIFIER                              // vpc_configuration
     | objectLiteral                           // { key: value }
     | arrayLiteral                            // [1, 2, 3]
+    | listComprehension                       // [for s in subnets: s.cidr]
+    | mapComprehension                        // {for s in subnets: s.name => s.az}
     | functionCall                            // vpc("name", config)
     | expression DOT IDENTIFIER               // object.property
+    | expression LBRACKET expression RBRACKET // list[0], map["key"]
     | LPAREN expression RPAREN                // (expression)
+    | (MINUS | NOT) expression                // -x, !flag
+    | expression (STAR | SLASH | PERCENT) expression
+    | expression (PLUS | MINUS) expression
+    | expression (LT | LE | GT | GE) expression
+    | expression (EQ | NEQ) expression
+    | expression AND expression
+    | expression OR expression
+    | <assoc=right> expression QUESTION expression COLON expression   // cond ? a : b
     ;
 
 // Object literal: { key: value, key2: value2 }
@@ -73,12 +121,28 @@ arrayLiteral
     : LBRACKET (expression (COMMA expression)*)? RBRACKET
     ;
 
+// List comprehension: [for item in collection: value if condition]
+listComprehension
+    : LBRACKET FOR IDENTIFIER (COMMA IDENTIFIER)? IN expression COLON expression (IF expression)? RBRACKET
+    ;
+
+// Map comprehension: {for item in collection: key => value if condition}
+mapComprehension
+    : LBRACE FOR IDENTIFIER (COMMA IDENTIFIER)? IN expression COLON expression ARROW expression (IF expression)? RBRACE
+    ;
+
 // ============= LEXER RULES =============
 
 // Keywords
 DECLARE : 'declare' ;
 FOR     : 'for' ;
 IN      : 'in' ;
+IF      : 'if' ;
+ELSE    : 'else' ;
+FUNC    : 'func' ;
+RETURN  : 'return' ;
+IMPORT  : 'import' ;
+AS      : 'as' ;
 
 // Literals
 STRING_LITERAL
@@ -87,7 +151,7 @@ STRING_LITERAL
     ;
 
 NUMBER
-    : '-'? [0-9]+ ('.' [0-9]+)?
+    : [0-9]+ ('.' [0-9]+)?
     ;
 
 BOOLEAN
@@ -107,6 +171,23 @@ SEMICOLON : ';' ;
 COMMA     : ',' ;
 DOT       : '.' ;
 
+EQ        : '==' ;
+NEQ       : '!=' ;
+LE        : '<=' ;
+GE        : '>=' ;
+LT        : '<' ;
+GT        : '>' ;
+AND       : '&&' ;
+OR        : '||' ;
+NOT       : '!' ;
+QUESTION  : '?' ;
+PLUS      : '+' ;
+MINUS     : '-' ;
+STAR      : '*' ;
+SLASH     : '/' ;
+PERCENT   : '%' ;
+ARROW     : '=>' ;
+
 LPAREN    : '(' ;
 RPAREN    : ')' ;
 LBRACE    : '{' ;
@@ -114,13 +195,13 @@ RBRACE    : '}' ;
 LBRACKET  : '[' ;
 RBRACKET  : ']' ;
 
-// Comments - each alternative with -> skip must be separate
+// Comments - kept on the hidden channel so that tblang fmt can preserve them
 LINE_COMMENT
-    : '//' ~[\r\n]* -> skip
+    : '//' ~[\r\n]* -> channel(HIDDEN)
     ;
 
 BLOCK_COMMENT
-    : '/*' .*? '*/' -> skip
+    : '/*' .*? '*/' -> channel(HIDDEN)
     ;
 
 // Whitespace
@@ -1,10 +1,108 @@
 package ast
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 type Resource struct {
-	Name       string
-	Type       string
-	Properties map[string]interface{}
-	DependsOn  []string
+	Name       string                 `json:"name"`
+	Type       string                 `json:"type"`
+	Properties map[string]interface{} `json:"properties"`
+	DependsOn  []string               `json:"depends_on,omitempty"`
+	Lifecycle  Lifecycle              `json:"lifecycle"`
+
+	// Pos is where the resource is declared and AttributePos where each of
+	// its attributes is set, when they are written out in an object literal.
+	Pos          Position            `json:"-"`
+	AttributePos map[string]Position `json:"-"`
+}
+
+// Position is a location in a source file.
+type Position struct {
+	Filename string
+	Line     int
+	Column   int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%s:%d:%d", p.Filename, p.Line, p.Column)
+}
+
+// Lifecycle holds the lifecycle meta-argument of a resource.
+type Lifecycle struct {
+	PreventDestroy      bool     `json:"prevent_destroy,omitempty"`
+	CreateBeforeDestroy bool     `json:"create_before_destroy,omitempty"`
+	IgnoreChanges       []string `json:"ignore_changes,omitempty"`
+}
+
+// Reference is a resource attribute whose value is only known once the
+// resource has been applied, e.g. main_vpc.vpc_id.
+type Reference struct {
+	Resource  string
+	Attribute string
+}
+
+func (r *Reference) String() string {
+	return r.Resource + "." + r.Attribute
+}
+
+const referenceKey = "$reference"
+
+// encodedReference is the JSON form of a Reference. Resource and attribute
+// are kept apart because resource addresses may themselves contain dots, as
+// in net.vpc or web["10.0.1.0/24"].
+type encodedReference struct {
+	Resource  string `json:"resource"`
+	Attribute string `json:"attribute"`
+}
+
+// MarshalJSON encodes a reference as
+// {"$reference": {"resource": "main_vpc", "attribute": "vpc_id"}}, so that
+// DecodeReferences can tell it apart from other values.
+func (r *Reference) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]encodedReference{
+		referenceKey: {Resource: r.Resource, Attribute: r.Attribute},
+	})
+}
+
+// DecodeReferences returns value, decoded from JSON, with the references
+// encoded in it turned back into a *Reference.
+func DecodeReferences(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := decodeReference(v); ok {
+			return ref
+		}
+		decoded := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			decoded[key] = DecodeReferences(item)
+		}
+		return decoded
+	case []interface{}:
+		decoded := make([]interface{}, len(v))
+		for i, item := range v {
+			decoded[i] = DecodeReferences(item)
+		}
+		return decoded
+	}
+	return value
+}
+
+func decodeReference(v map[string]interface{}) (*Reference, bool) {
+	encoded, ok := v[referenceKey].(map[string]interface{})
+	if !ok || len(v) != 1 || len(encoded) != 2 {
+		return nil, false
+	}
+	resource, ok := encoded["resource"].(string)
+	if !ok {
+		return nil, false
+	}
+	attribute, ok := encoded["attribute"].(string)
+	if !ok {
+		return nil, false
+	}
+	return &Reference{Resource: resource, Attribute: attribute}, true
 }
 
 type Program struct {
@@ -14,8 +112,8 @@ type Program struct {
 }
 
 type CloudVendor struct {
-	Name       string
-	Properties map[string]interface{}
+	Name       string                 `json:"name"`
+	Properties map[string]interface{} `json:"properties"`
 }
 
 type Variable struct {
@@ -23,72 +121,6 @@ type Variable struct {
 	Value interface{}
 }
 
-type Expression interface {
-	Evaluate() interface{}
-}
-
-type StringLiteral struct {
-	Value string
-}
-
-func (s *StringLiteral) Evaluate() interface{} {
-	return s.Value
-}
-
-type NumberLiteral struct {
-	Value float64
-}
-
-func (n *NumberLiteral) Evaluate() interface{} {
-	return n.Value
-}
-
-type BooleanLiteral struct {
-	Value bool
-}
-
-func (b *BooleanLiteral) Evaluate() interface{} {
-	return b.Value
-}
-
-type ObjectLiteral struct {
-	Properties map[string]Expression
-}
-
-func (o *ObjectLiteral) Evaluate() interface{} {
-	result := make(map[string]interface{})
-	for key, expr := range o.Properties {
-		result[key] = expr.Evaluate()
-	}
-	return result
-}
-
-type ArrayLiteral struct {
-	Elements []Expression
-}
-
-func (a *ArrayLiteral) Evaluate() interface{} {
-	var result []interface{}
-	for _, expr := range a.Elements {
-		result = append(result, expr.Evaluate())
-	}
-	return result
-}
-
-type ForLoop struct {
-	Iterator   string
-	Collection Expression
-	Body       []interface{}
-}
-
-type IdentifierExpression struct {
-	Name string
-}
-
-func (i *IdentifierExpression) Evaluate() interface{} {
-	return i.Name
-}
-
 type DataSource struct {
 	Name       string
 	Type       string
@@ -0,0 +1,51 @@
+package ast
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestReferenceRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"resource", &Reference{Resource: "main_vpc", Attribute: "vpc_id"}},
+		{"module", &Reference{Resource: "net.vpc", Attribute: "vpc_id"}},
+		{"nested module", &Reference{Resource: "app.net.vpc", Attribute: "cidr_block"}},
+		{"count", &Reference{Resource: "web[0]", Attribute: "subnet_id"}},
+		{"for_each", &Reference{Resource: `fe["10.0.1.0/24"]`, Attribute: "subnet_id"}},
+		{"module for_each", &Reference{Resource: `net.fe["a.b"]`, Attribute: "subnet_id"}},
+		{
+			"nested",
+			map[string]interface{}{
+				"subnet_ids": []interface{}{
+					&Reference{Resource: `fe["10.0.1.0/24"]`, Attribute: "subnet_id"},
+					"subnet-123",
+				},
+				"tags": map[string]interface{}{"Name": "net.vpc.vpc_id"},
+			},
+		},
+		{
+			"lookalike",
+			map[string]interface{}{referenceKey: "main_vpc.vpc_id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var decoded interface{}
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if got := DecodeReferences(decoded); !reflect.DeepEqual(got, tt.value) {
+				t.Errorf("%s decoded to %#v, want %#v", data, got, tt.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package ast
+
+type StringLiteral struct {
+	Pos   Position
+	Value string
+}
+
+// Template is a string literal with ${...} placeholders.
+type Template struct {
+	Pos   Position
+	Parts []TemplatePart
+}
+
+// TemplatePart is either literal text or, when Expr is set, a placeholder
+// whose source between ${ and } is kept in Source for error messages.
+type TemplatePart struct {
+	Text   string
+	Source string
+	Expr   Expression
+}
+
+type NumberLiteral struct {
+	Pos   Position
+	Value float64
+}
+
+type BooleanLiteral struct {
+	Pos   Position
+	Value bool
+}
+
+type Identifier struct {
+	Pos  Position
+	Name string
+}
+
+type ObjectLiteral struct {
+	Pos        Position
+	Properties []*Property
+}
+
+// Property returns the value given for key, or nil.
+func (o *ObjectLiteral) Property(key string) Expression {
+	for _, prop := range o.Properties {
+		if prop.Key == key {
+			return prop.Value
+		}
+	}
+	return nil
+}
+
+type ArrayLiteral struct {
+	Pos      Position
+	Elements []Expression
+}
+
+// ListComprehension is [for v in collection: value if condition]. Vars holds
+// one loop variable, or two for key and value.
+type ListComprehension struct {
+	Pos        Position
+	Vars       []string
+	Collection Expression
+	Value      Expression
+	Condition  Expression
+}
+
+// MapComprehension is {for v in collection: key => value if condition}.
+type MapComprehension struct {
+	Pos        Position
+	Vars       []string
+	Collection Expression
+	Key        Expression
+	Value      Expression
+	Condition  Expression
+}
+
+// Selector is object.name.
+type Selector struct {
+	Pos    Position
+	Object Expression
+	Name   string
+}
+
+// Index is collection[key].
+type Index struct {
+	Pos        Position
+	Collection Expression
+	Key        Expression
+}
+
+type Unary struct {
+	Pos     Position
+	Op      string
+	Operand Expression
+}
+
+type Binary struct {
+	Pos   Position
+	Op    string
+	Left  Expression
+	Right Expression
+}
+
+// Conditional is condition ? then : else.
+type Conditional struct {
+	Pos       Position
+	Condition Expression
+	Then      Expression
+	Else      Expression
+}
+
+// Call is a call of a built-in, a user function or a resource type.
+type Call struct {
+	Pos  Position
+	Name string
+	Args []Expression
+}
+
+func (e *StringLiteral) Position() Position     { return e.Pos }
+func (e *Template) Position() Position          { return e.Pos }
+func (e *NumberLiteral) Position() Position     { return e.Pos }
+func (e *BooleanLiteral) Position() Position    { return e.Pos }
+func (e *Identifier) Position() Position        { return e.Pos }
+func (e *ObjectLiteral) Position() Position     { return e.Pos }
+func (e *ArrayLiteral) Position() Position      { return e.Pos }
+func (e *ListComprehension) Position() Position { return e.Pos }
+func (e *MapComprehension) Position() Position  { return e.Pos }
+func (e *Selector) Position() Position          { return e.Pos }
+func (e *Index) Position() Position             { return e.Pos }
+func (e *Unary) Position() Position             { return e.Pos }
+func (e *Binary) Position() Position            { return e.Pos }
+func (e *Conditional) Position() Position       { return e.Pos }
+func (e *Call) Position() Position              { return e.Pos }
+
+func (*StringLiteral) expressionNode()     {}
+func (*Template) expressionNode()          {}
+func (*NumberLiteral) expressionNode()     {}
+func (*BooleanLiteral) expressionNode()    {}
+func (*Identifier) expressionNode()        {}
+func (*ObjectLiteral) expressionNode()     {}
+func (*ArrayLiteral) expressionNode()      {}
+func (*ListComprehension) expressionNode() {}
+func (*MapComprehension) expressionNode()  {}
+func (*Selector) expressionNode()          {}
+func (*Index) expressionNode()             {}
+func (*Unary) expressionNode()             {}
+func (*Binary) expressionNode()            {}
+func (*Conditional) expressionNode()       {}
+func (*Call) expressionNode()              {}
@@ -0,0 +1,33 @@
+package ast
+
+// Node is an element of the syntax tree of a .tbl file.
+type Node interface {
+	Position() Position
+}
+
+// Expression is a node that produces a value.
+type Expression interface {
+	Node
+	expressionNode()
+}
+
+// Statement is a node that is executed for its effect.
+type Statement interface {
+	Node
+	statementNode()
+}
+
+// File is the syntax tree of a source file.
+type File struct {
+	Filename   string
+	Statements []Statement
+}
+
+// Property is a key and value in an object literal or block.
+type Property struct {
+	Pos   Position
+	Key   string
+	Value Expression
+}
+
+func (p *Property) Position() Position { return p.Pos }
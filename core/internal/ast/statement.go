@@ -0,0 +1,94 @@
+package ast
+
+// VariableDeclaration is declare name = value.
+type VariableDeclaration struct {
+	Pos   Position
+	Name  string
+	Value Expression
+}
+
+// BlockDeclaration is a block such as cloud_vendor "aws" { ... } or
+// output "vpc_id" { ... }.
+type BlockDeclaration struct {
+	Pos        Position
+	Type       string
+	Name       string
+	Properties []*Property
+}
+
+// VariableBlock is an input variable declared with variable "name" { ... }.
+// Type is "any" when the block does not give one.
+type VariableBlock struct {
+	Pos          Position
+	Name         string
+	Type         string
+	Default      Expression
+	Validate     Expression
+	ErrorMessage Expression
+}
+
+// ForLoop is for v in collection { ... }. Vars holds one loop variable, or two
+// for key and value.
+type ForLoop struct {
+	Pos        Position
+	Vars       []string
+	Collection Expression
+	Body       []Statement
+}
+
+// IfStatement is if condition { ... }, followed by either an else block or,
+// for else if, another IfStatement in ElseIf.
+type IfStatement struct {
+	Pos       Position
+	Condition Expression
+	Then      []Statement
+	Else      []Statement
+	ElseIf    *IfStatement
+}
+
+type FunctionDeclaration struct {
+	Pos    Position
+	Name   string
+	Params []string
+	Body   []Statement
+}
+
+type ReturnStatement struct {
+	Pos   Position
+	Value Expression
+}
+
+// ImportStatement is import "path" as alias { inputs }. Inputs is nil when no
+// inputs are given.
+type ImportStatement struct {
+	Pos    Position
+	Path   string
+	Alias  string
+	Inputs *ObjectLiteral
+}
+
+// CallStatement is a call made for its effect, such as print(...) or a
+// resource declared without a variable.
+type CallStatement struct {
+	Call *Call
+}
+
+func (s *VariableDeclaration) Position() Position { return s.Pos }
+func (s *BlockDeclaration) Position() Position    { return s.Pos }
+func (s *VariableBlock) Position() Position       { return s.Pos }
+func (s *ForLoop) Position() Position             { return s.Pos }
+func (s *IfStatement) Position() Position         { return s.Pos }
+func (s *FunctionDeclaration) Position() Position { return s.Pos }
+func (s *ReturnStatement) Position() Position     { return s.Pos }
+func (s *ImportStatement) Position() Position     { return s.Pos }
+func (s *CallStatement) Position() Position       { return s.Call.Pos }
+
+func (*VariableDeclaration) statementNode() {}
+func (*BlockDeclaration) statementNode()    {}
+func (*VariableBlock) statementNode()       {}
+func (*ForLoop) statementNode()             {}
+func (*IfStatement) statementNode()         {}
+func (*FunctionDeclaration) statementNode() {}
+func (*ReturnStatement) statementNode()     {}
+func (*ImportStatement) statementNode()     {}
+func (*CallStatement) statementNode()       {}
@@ -0,0 +1,95 @@
+package ast
+
+// Inspect calls f for node and, as long as f returns true, for each of its
+// children in source order.
+func Inspect(node Node, f func(Node) bool) {
+	if node == nil || !f(node) {
+		return
+	}
+
+	expr := func(e Expression) {
+		if e != nil {
+			Inspect(e, f)
+		}
+	}
+	stmts := func(list []Statement) {
+		for _, s := range list {
+			Inspect(s, f)
+		}
+	}
+	props := func(list []*Property) {
+		for _, p := range list {
+			Inspect(p, f)
+		}
+	}
+
+	switch n := node.(type) {
+	case *Property:
+		expr(n.Value)
+	case *Template:
+		for _, part := range n.Parts {
+			expr(part.Expr)
+		}
+	case *ObjectLiteral:
+		props(n.Properties)
+	case *ArrayLiteral:
+		for _, e := range n.Elements {
+			expr(e)
+		}
+	case *ListComprehension:
+		expr(n.Collection)
+		expr(n.Value)
+		expr(n.Condition)
+	case *MapComprehension:
+		expr(n.Collection)
+		expr(n.Key)
+		expr(n.Value)
+		expr(n.Condition)
+	case *Selector:
+		expr(n.Object)
+	case *Index:
+		expr(n.Collection)
+		expr(n.Key)
+	case *Unary:
+		expr(n.Operand)
+	case *Binary:
+		expr(n.Left)
+		expr(n.Right)
+	case *Conditional:
+		expr(n.Condition)
+		expr(n.Then)
+		expr(n.Else)
+	case *Call:
+		for _, e := range n.Args {
+			expr(e)
+		}
+	case *VariableDeclaration:
+		expr(n.Value)
+	case *BlockDeclaration:
+		props(n.Properties)
+	case *VariableBlock:
+		expr(n.Default)
+		expr(n.Validate)
+		expr(n.ErrorMessage)
+	case *ForLoop:
+		expr(n.Collection)
+		stmts(n.Body)
+	case *IfStatement:
+		expr(n.Condition)
+		stmts(n.Then)
+		if n.ElseIf != nil {
+			Inspect(n.ElseIf, f)
+		}
+		stmts(n.Else)
+	case *FunctionDeclaration:
+		stmts(n.Body)
+	case *ReturnStatement:
+		expr(n.Value)
+	case *ImportStatement:
+		if n.Inputs != nil {
+			Inspect(n.Inputs, f)
+		}
+	case *CallStatement:
+		Inspect(n.Call, f)
+	}
+}
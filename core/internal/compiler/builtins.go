@@ -0,0 +1,496 @@
+package compiler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tblang/core/internal/ast"
+)
+
+const maxRangeLength = 1024
+
+// builtin describes a pure function callable from any expression. maxArgs of
+// -1 means variadic.
+type builtin struct {
+	minArgs int
+	maxArgs int
+	call    func(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error)
+}
+
+var builtins map[string]builtin
+
+// referenceBuiltins are the built-in functions that only move values around,
+// so that the references in their arguments are kept in their result to be
+// resolved on apply. The others need every value to be known.
+var referenceBuiltins = map[string]bool{
+	"length": true,
+	"lookup": true,
+	"merge":  true,
+	"concat": true,
+	"keys":   true,
+	"values": true,
+}
+
+func init() {
+	builtins = map[string]builtin{
+		"length":       {1, 1, builtinLength},
+		"join":         {2, 2, builtinJoin},
+		"split":        {2, 2, builtinSplit},
+		"format":       {1, -1, builtinFormat},
+		"lookup":       {2, 3, builtinLookup},
+		"merge":        {0, -1, builtinMerge},
+		"concat":       {0, -1, builtinConcat},
+		"keys":         {1, 1, builtinKeys},
+		"values":       {1, 1, builtinValues},
+		"upper":        {1, 1, builtinUpper},
+		"lower":        {1, 1, builtinLower},
+		"replace":      {3, 3, builtinReplace},
+		"range":        {1, 3, builtinRange},
+		"base64encode": {1, 1, builtinBase64Encode},
+		"jsonencode":   {1, 1, builtinJSONEncode},
+		"file":         {1, 1, builtinFile},
+		"templatefile": {2, 2, builtinTemplateFile},
+	}
+}
+
+// Builtins returns the names of the built-in functions, including print and
+// output, in sorted order.
+func Builtins() []string {
+	names := []string{"print", "output"}
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isBuiltin(name string) bool {
+	_, exists := builtins[name]
+	return exists
+}
+
+func (w *ASTWalker) callBuiltin(call *ast.Call, name string, args []interface{}) interface{} {
+	fn := builtins[name]
+
+	if err := fn.checkArity(name, len(args)); err != nil {
+		w.addError(call, "%v", err)
+		return invalid
+	}
+
+	for _, arg := range args {
+		if _, ok := arg.(*ast.Reference); !ok && referenceBuiltins[name] {
+			continue
+		}
+		if !w.checkKnown(call, "argument of "+name, arg) {
+			return invalid
+		}
+	}
+
+	result, err := fn.call(w, call, args)
+	if err != nil {
+		w.addError(call, "%s: %v", name, err)
+		return invalid
+	}
+	return result
+}
+
+func (fn builtin) checkArity(name string, count int) error {
+	if count >= fn.minArgs && (fn.maxArgs < 0 || count <= fn.maxArgs) {
+		return nil
+	}
+	switch {
+	case fn.maxArgs < 0:
+		return fmt.Errorf("%s expects at least %d argument(s), got %d", name, fn.minArgs, count)
+	case fn.minArgs == fn.maxArgs:
+		return fmt.Errorf("%s expects %d argument(s), got %d", name, fn.minArgs, count)
+	default:
+		return fmt.Errorf("%s expects %d to %d arguments, got %d", name, fn.minArgs, fn.maxArgs, count)
+	}
+}
+
+func argError(index int, want string, got interface{}) error {
+	return fmt.Errorf("argument %d must be %s, got %s", index+1, want, typeName(got))
+}
+
+func stringArg(args []interface{}, index int) (string, error) {
+	s, ok := args[index].(string)
+	if !ok {
+		return "", argError(index, "a string", args[index])
+	}
+	return s, nil
+}
+
+func numberArg(args []interface{}, index int) (float64, error) {
+	n, ok := args[index].(float64)
+	if !ok {
+		return 0, argError(index, "a number", args[index])
+	}
+	return n, nil
+}
+
+func listArg(args []interface{}, index int) ([]interface{}, error) {
+	l, ok := args[index].([]interface{})
+	if !ok {
+		return nil, argError(index, "a list", args[index])
+	}
+	return l, nil
+}
+
+func mapArg(args []interface{}, index int) (map[string]interface{}, error) {
+	m, ok := args[index].(map[string]interface{})
+	if !ok {
+		return nil, argError(index, "a map", args[index])
+	}
+	return m, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func builtinLength(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	switch v := args[0].(type) {
+	case string:
+		return float64(len([]rune(v))), nil
+	case []interface{}:
+		return float64(len(v)), nil
+	case map[string]interface{}:
+		return float64(len(v)), nil
+	}
+	return nil, argError(0, "a string, list or map", args[0])
+}
+
+func builtinJoin(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	sep, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	list, err := listArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]string, len(list))
+	for i, item := range list {
+		switch item.(type) {
+		case string, float64, bool:
+			parts[i] = w.extractStringValue(item)
+		default:
+			return nil, fmt.Errorf("element %d must be a string, number or bool, got %s", i, typeName(item))
+		}
+	}
+	return strings.Join(parts, sep), nil
+}
+
+func builtinSplit(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	sep, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	s, err := stringArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []interface{}
+	for _, part := range strings.Split(s, sep) {
+		result = append(result, part)
+	}
+	return result, nil
+}
+
+// builtinFormat implements printf-style formatting over TBLang values. %d
+// accepts whole numbers, %s and %v accept any primitive.
+func builtinFormat(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	spec, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	next := 1
+	for i := 0; i < len(spec); i++ {
+		if spec[i] != '%' {
+			sb.WriteByte(spec[i])
+			continue
+		}
+
+		j := i + 1
+		for j < len(spec) && strings.IndexByte("+-# 0123456789.", spec[j]) >= 0 {
+			j++
+		}
+		if j >= len(spec) {
+			return nil, fmt.Errorf("incomplete verb at end of format string")
+		}
+
+		verb := spec[j]
+		directive := spec[i : j+1]
+		i = j
+
+		if verb == '%' {
+			sb.WriteByte('%')
+			continue
+		}
+		if next >= len(args) {
+			return nil, fmt.Errorf("not enough arguments for format string %q", spec)
+		}
+		arg := args[next]
+		next++
+
+		switch verb {
+		case 'd':
+			n, ok := arg.(float64)
+			if !ok || n != math.Trunc(n) {
+				return nil, fmt.Errorf("%%d requires a whole number, got %s", typeName(arg))
+			}
+			sb.WriteString(fmt.Sprintf(directive, int64(n)))
+		case 'f', 'e', 'g':
+			n, ok := arg.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%%%c requires a number, got %s", verb, typeName(arg))
+			}
+			sb.WriteString(fmt.Sprintf(directive, n))
+		case 's', 'q', 'v':
+			switch arg.(type) {
+			case []interface{}, map[string]interface{}:
+				if verb != 'v' {
+					return nil, fmt.Errorf("%%%c requires a primitive value, got %s", verb, typeName(arg))
+				}
+			}
+			if verb == 'v' {
+				directive = directive[:len(directive)-1] + "s"
+			}
+			sb.WriteString(fmt.Sprintf(directive, w.extractStringValue(arg)))
+		case 't':
+			b, ok := arg.(bool)
+			if !ok {
+				return nil, fmt.Errorf("%%t requires a bool, got %s", typeName(arg))
+			}
+			sb.WriteString(fmt.Sprintf(directive, b))
+		default:
+			return nil, fmt.Errorf("unsupported verb %%%c", verb)
+		}
+	}
+
+	if next < len(args) {
+		return nil, fmt.Errorf("too many arguments for format string %q", spec)
+	}
+	return sb.String(), nil
+}
+
+func builtinLookup(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	m, err := mapArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	key, err := stringArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if val, exists := m[key]; exists {
+		return val, nil
+	}
+	if len(args) == 3 {
+		return args[2], nil
+	}
+	return nil, fmt.Errorf("key %q not found and no default given", key)
+}
+
+func builtinMerge(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	result := make(map[string]interface{})
+	for i := range args {
+		m, err := mapArg(args, i)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range m {
+			result[k] = v
+		}
+	}
+	return result, nil
+}
+
+func builtinConcat(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	result := []interface{}{}
+	for i := range args {
+		l, err := listArg(args, i)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, l...)
+	}
+	return result, nil
+}
+
+func builtinKeys(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	m, err := mapArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []interface{}{}
+	for _, k := range sortedKeys(m) {
+		result = append(result, k)
+	}
+	return result, nil
+}
+
+func builtinValues(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	m, err := mapArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []interface{}{}
+	for _, k := range sortedKeys(m) {
+		result = append(result, m[k])
+	}
+	return result, nil
+}
+
+func builtinUpper(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	s, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	return strings.ToUpper(s), nil
+}
+
+func builtinLower(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	s, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	return strings.ToLower(s), nil
+}
+
+func builtinReplace(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	var strs [3]string
+	for i := range strs {
+		s, err := stringArg(args, i)
+		if err != nil {
+			return nil, err
+		}
+		strs[i] = s
+	}
+	return strings.ReplaceAll(strs[0], strs[1], strs[2]), nil
+}
+
+func builtinRange(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	var nums []float64
+	for i := range args {
+		n, err := numberArg(args, i)
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, n)
+	}
+
+	start, end, step := 0.0, 0.0, 1.0
+	switch len(nums) {
+	case 1:
+		end = nums[0]
+	case 2:
+		start, end = nums[0], nums[1]
+	case 3:
+		start, end, step = nums[0], nums[1], nums[2]
+	}
+
+	if step == 0 {
+		return nil, fmt.Errorf("step must not be zero")
+	}
+	if (step > 0 && start > end) || (step < 0 && start < end) {
+		return nil, fmt.Errorf("step %v never reaches %v from %v", step, end, start)
+	}
+
+	result := []interface{}{}
+	for v := start; (step > 0 && v < end) || (step < 0 && v > end); v += step {
+		if len(result) >= maxRangeLength {
+			return nil, fmt.Errorf("result would have more than %d elements", maxRangeLength)
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+func builtinBase64Encode(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	s, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.EncodeToString([]byte(s)), nil
+}
+
+func builtinJSONEncode(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	data, err := json.Marshal(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (w *ASTWalker) resolvePath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(w.filename), path)
+}
+
+func builtinFile(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	path, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(w.resolvePath(path))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func builtinTemplateFile(w *ASTWalker, call *ast.Call, args []interface{}) (interface{}, error) {
+	path, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	vars, err := mapArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(w.resolvePath(path))
+	if err != nil {
+		return nil, err
+	}
+
+	l := &lowerer{compiler: w.compiler, filename: w.filename}
+	tmpl := l.template(call.Pos, string(data))
+	if len(l.errors) > 0 {
+		w.errors = append(w.errors, l.errors...)
+		return nil, fmt.Errorf("failed to render template %s", path)
+	}
+
+	// The template sees only the variables passed to it.
+	savedScope, savedPending := w.scope, w.pending
+	w.scope, w.pending = &scope{variables: vars}, nil
+	result := w.evaluateExpression(tmpl)
+	w.scope, w.pending = savedScope, savedPending
+
+	if result == nil {
+		return nil, fmt.Errorf("failed to render template %s", path)
+	}
+	return result, nil
+}
@@ -1,10 +1,11 @@
 package compiler
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"path/filepath"
+	"sort"
 
-	"github.com/antlr4-go/antlr/v4"
 	"github.com/tblang/core/internal/ast"
 	"github.com/tblang/core/internal/graph"
 	"github.com/tblang/core/parser"
@@ -16,6 +17,11 @@ type Compiler struct {
 	orderedResources []*ast.Resource
 	cloudVendors     map[string]*ast.CloudVendor
 	variables        map[string]*ast.Variable
+	inputVariables   *InputVariables
+	allowUnset       bool
+	unknownNames     int
+	sources          map[string][]string
+	contents         map[string][]byte
 }
 
 type Program struct {
@@ -30,25 +36,47 @@ func New() *Compiler {
 		depGraph:     graph.NewDependencyGraph(),
 		cloudVendors: make(map[string]*ast.CloudVendor),
 		variables:    make(map[string]*ast.Variable),
+		sources:      make(map[string][]string),
+		contents:     make(map[string][]byte),
 	}
 }
 
 func (c *Compiler) CompileFile(filename string) (*Program, error) {
 
-	input, err := os.ReadFile(filename)
+	file, err := c.loadFile(filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, err
 	}
 
-	inputStream := antlr.NewInputStream(string(input))
-	lexer := parser.NewtblangLexer(inputStream)
-	stream := antlr.NewCommonTokenStream(lexer, 0)
-	p := parser.NewtblangParser(stream)
+	return c.Compile(file)
+}
 
-	tree := p.Program()
+// Compile checks and evaluates a file that has already been parsed into an
+// ast.File. Names are resolved and types checked for the whole file before
+// any of it is evaluated.
+func (c *Compiler) Compile(file *ast.File) (*Program, error) {
+	if errs := c.check(file); len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
 
-	walker := &ASTWalker{compiler: c}
-	antlr.ParseTreeWalkerDefault.Walk(walker, tree)
+	inputs, sources, err := c.resolveInputVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	walker := c.newWalker(file.Filename, "", inputs, nil)
+	walker.run(file)
+	walker.checkDependsOn()
+
+	for name, source := range sources {
+		if !walker.declaredInputs[name] {
+			walker.errors = append(walker.errors, fmt.Errorf("value given for undeclared variable %q (%s)", name, source))
+		}
+	}
+
+	if len(walker.errors) > 0 {
+		return nil, errors.Join(walker.errors...)
+	}
 
 	if err := c.buildDependencyGraph(); err != nil {
 		return nil, fmt.Errorf("failed to build dependency graph: %w", err)
@@ -63,6 +91,49 @@ func (c *Compiler) CompileFile(filename string) (*Program, error) {
 	return program, nil
 }
 
+func (c *Compiler) parseFile(filename string) (parser.IProgramContext, error) {
+	p, listener, err := c.newParser(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	tree := p.Program()
+	if err := listener.err(); err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+func (c *Compiler) newWalker(filename, modulePrefix string, inputs map[string]interface{}, importStack []string) *ASTWalker {
+	absPath, err := filepath.Abs(filename)
+	if err != nil {
+		absPath = filename
+	}
+
+	globals := newScope(nil)
+	return &ASTWalker{
+		compiler:     c,
+		scope:        globals,
+		globals:      globals,
+		filename:     filename,
+		modulePrefix: modulePrefix,
+		inputs:       inputs,
+		importStack:  append(importStack, absPath),
+	}
+}
+
+// check runs the passes that need no evaluation over file: name resolution
+// and type checking.
+func (c *Compiler) check(file *ast.File) []error {
+	errs := append(c.resolve(file), c.typeCheck(file)...)
+	sort.SliceStable(errs, func(i, j int) bool {
+		a, b := errs[i].(*Diagnostic).Pos, errs[j].(*Diagnostic).Pos
+		return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+	})
+	return errs
+}
+
 func (c *Compiler) buildDependencyGraph() error {
 	fmt.Println("Building dependency graph...")
 
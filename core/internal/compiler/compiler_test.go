@@ -0,0 +1,84 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// compileSource compiles src as main.tbl in a directory of its own, with
+// files holding any other files it imports, keyed by path relative to it.
+func compileSource(t *testing.T, src string, files map[string]string) (*Program, error) {
+	t.Helper()
+	return compileWith(t, New(), src, files)
+}
+
+func compileWith(t *testing.T, c *Compiler, src string, files map[string]string) (*Program, error) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filename := filepath.Join(dir, "main.tbl")
+	if err := os.WriteFile(filename, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return c.CompileFile(filename)
+}
+
+// variable returns the value of the top-level variable name in program.
+func variable(t *testing.T, program *Program, name string) interface{} {
+	t.Helper()
+	v, exists := program.Variables[name]
+	if !exists {
+		t.Fatalf("no variable %s", name)
+	}
+	return v.Value
+}
+
+// diagnostics returns the errors in err as "line:col: message", with notes
+// as "note line:col: message".
+func diagnostics(err error) []string {
+	var lines []string
+	for _, e := range unjoin(err) {
+		var d *Diagnostic
+		if !errors.As(e, &d) {
+			lines = append(lines, e.Error())
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%d:%d: %s", d.Pos.Line, d.Pos.Column, d.Message))
+		for _, note := range d.Notes {
+			lines = append(lines, fmt.Sprintf("note %d:%d: %s", note.Pos.Line, note.Pos.Column, strings.TrimPrefix(note.Message, "note: ")))
+		}
+	}
+	return lines
+}
+
+func unjoin(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, e := range joined.Unwrap() {
+			errs = append(errs, unjoin(e)...)
+		}
+		return errs
+	}
+	return []error{err}
+}
+
+// checkDiagnostics fails t unless err holds exactly the diagnostics in want.
+func checkDiagnostics(t *testing.T, err error, want []string) {
+	t.Helper()
+	got := diagnostics(err)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diagnostics:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,131 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/tblang/core/internal/ast"
+	"github.com/tblang/core/parser"
+)
+
+// Diagnostic is an error at a position in a source file. It is printed as
+// file:line:col followed by the offending source line and a caret under the
+// column.
+type Diagnostic struct {
+	Pos     ast.Position
+	Message string
+	Source  string
+
+	// Notes point at other places involved in the error, such as an earlier
+	// declaration of the same name.
+	Notes []*Diagnostic
+}
+
+func (d *Diagnostic) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %s", d.Pos, d.Message)
+
+	if d.Source != "" {
+		sb.WriteString("\n    ")
+		sb.WriteString(d.Source)
+		sb.WriteString("\n    ")
+		for i := 0; i < d.Pos.Column-1 && i < len(d.Source); i++ {
+			if d.Source[i] == '\t' {
+				sb.WriteByte('\t')
+			} else {
+				sb.WriteByte(' ')
+			}
+		}
+		sb.WriteByte('^')
+	}
+
+	for _, note := range d.Notes {
+		sb.WriteString("\n")
+		sb.WriteString(note.Error())
+	}
+
+	return sb.String()
+}
+
+// diagnostic builds a Diagnostic for pos, with the source line taken from
+// the files read by this compiler.
+func (c *Compiler) diagnostic(pos ast.Position, format string, args ...interface{}) *Diagnostic {
+	d := &Diagnostic{Pos: pos, Message: fmt.Sprintf(format, args...)}
+	if c != nil {
+		if lines, exists := c.sources[pos.Filename]; exists && pos.Line >= 1 && pos.Line <= len(lines) {
+			d.Source = strings.TrimRight(lines[pos.Line-1], "\r")
+		}
+	}
+	return d
+}
+
+type diagnosticListener struct {
+	*antlr.DefaultErrorListener
+	compiler *Compiler
+	filename string
+	errors   []error
+}
+
+func (l *diagnosticListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
+	pos := ast.Position{Filename: l.filename, Line: line, Column: column + 1}
+	l.errors = append(l.errors, l.compiler.diagnostic(pos, "%s", msg))
+}
+
+func (l *diagnosticListener) err() error {
+	return errors.Join(l.errors...)
+}
+
+// sourceParser is the part of the generated parser used for whole files.
+type sourceParser interface {
+	Program() parser.IProgramContext
+	VarFile() parser.IVarFileContext
+}
+
+// newParser reads filename and returns a parser whose syntax errors are
+// collected by the returned listener instead of printed to the console.
+func (c *Compiler) newParser(filename string) (sourceParser, *diagnosticListener, error) {
+	input, err := c.readFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.sources[filename] = strings.Split(string(input), "\n")
+
+	listener := &diagnosticListener{
+		DefaultErrorListener: antlr.NewDefaultErrorListener(),
+		compiler:             c,
+		filename:             filename,
+	}
+
+	lexer := parser.NewtblangLexer(antlr.NewInputStream(string(input)))
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(listener)
+	p := parser.NewtblangParser(antlr.NewCommonTokenStream(lexer, 0))
+	p.RemoveErrorListeners()
+	p.AddErrorListener(listener)
+
+	return p, listener, nil
+}
+
+// SetFileContents makes the compiler use src as the contents of filename
+// instead of reading it from disk, e.g. for a file that is being edited.
+func (c *Compiler) SetFileContents(filename string, src []byte) {
+	c.contents[absolutePath(filename)] = src
+}
+
+func (c *Compiler) readFile(filename string) ([]byte, error) {
+	if src, exists := c.contents[absolutePath(filename)]; exists {
+		return src, nil
+	}
+	return os.ReadFile(filename)
+}
+
+func absolutePath(filename string) string {
+	if abs, err := filepath.Abs(filename); err == nil {
+		return abs
+	}
+	return filename
+}
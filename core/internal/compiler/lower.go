@@ -0,0 +1,316 @@
+package compiler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/tblang/core/internal/ast"
+	"github.com/tblang/core/parser"
+)
+
+// lowerer converts the parse tree of a file into ast nodes. Problems found
+// on the way, such as a malformed placeholder, are collected in errors.
+type lowerer struct {
+	compiler *Compiler
+	filename string
+	errors   []error
+
+	// at, when set, is used as the position of every node. Expressions
+	// parsed out of a placeholder have no position of their own in the file,
+	// so they get the position of the string they appear in.
+	at *ast.Position
+}
+
+// propertyContext is implemented by both block and object properties.
+type propertyContext interface {
+	antlr.ParserRuleContext
+	IDENTIFIER() antlr.TerminalNode
+	Expression() parser.IExpressionContext
+}
+
+// loadFile parses filename and lowers it to an ast.File.
+func (c *Compiler) loadFile(filename string) (*ast.File, error) {
+	tree, err := c.parseFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &lowerer{compiler: c, filename: filename}
+	file := &ast.File{Filename: filename, Statements: l.statements(tree.AllStatement())}
+	if len(l.errors) > 0 {
+		return nil, errors.Join(l.errors...)
+	}
+	return file, nil
+}
+
+func (l *lowerer) position(ctx antlr.ParserRuleContext) ast.Position {
+	if l.at != nil {
+		return *l.at
+	}
+	tok := ctx.GetStart()
+	return ast.Position{Filename: l.filename, Line: tok.GetLine(), Column: tok.GetColumn() + 1}
+}
+
+func (l *lowerer) addError(pos ast.Position, format string, args ...interface{}) {
+	l.errors = append(l.errors, l.compiler.diagnostic(pos, format, args...))
+}
+
+func (l *lowerer) statements(list []parser.IStatementContext) []ast.Statement {
+	var stmts []ast.Statement
+	for _, stmt := range list {
+		if lowered := l.statement(stmt.(*parser.StatementContext)); lowered != nil {
+			stmts = append(stmts, lowered)
+		}
+	}
+	return stmts
+}
+
+func (l *lowerer) statement(ctx *parser.StatementContext) ast.Statement {
+	switch {
+	case ctx.BlockDeclaration() != nil:
+		return l.blockDeclaration(ctx.BlockDeclaration().(*parser.BlockDeclarationContext))
+
+	case ctx.VariableDeclaration() != nil:
+		decl := ctx.VariableDeclaration().(*parser.VariableDeclarationContext)
+		return &ast.VariableDeclaration{
+			Pos:   l.position(decl),
+			Name:  decl.IDENTIFIER().GetText(),
+			Value: l.expression(decl.Expression()),
+		}
+
+	case ctx.ForLoop() != nil:
+		loop := ctx.ForLoop().(*parser.ForLoopContext)
+		return &ast.ForLoop{
+			Pos:        l.position(loop),
+			Vars:       identifiers(loop.AllIDENTIFIER()),
+			Collection: l.expression(loop.Expression()),
+			Body:       l.statements(loop.AllStatement()),
+		}
+
+	case ctx.IfStatement() != nil:
+		return l.ifStatement(ctx.IfStatement().(*parser.IfStatementContext))
+
+	case ctx.FunctionDeclaration() != nil:
+		fn := ctx.FunctionDeclaration().(*parser.FunctionDeclarationContext)
+		decl := &ast.FunctionDeclaration{
+			Pos:  l.position(fn),
+			Name: fn.IDENTIFIER().GetText(),
+			Body: l.statements(fn.AllStatement()),
+		}
+		if fn.ParameterList() != nil {
+			decl.Params = identifiers(fn.ParameterList().AllIDENTIFIER())
+		}
+		return decl
+
+	case ctx.ReturnStatement() != nil:
+		ret := ctx.ReturnStatement().(*parser.ReturnStatementContext)
+		return &ast.ReturnStatement{Pos: l.position(ret), Value: l.expression(ret.Expression())}
+
+	case ctx.ImportStatement() != nil:
+		imp := ctx.ImportStatement().(*parser.ImportStatementContext)
+		stmt := &ast.ImportStatement{
+			Pos:   l.position(imp),
+			Path:  unquote(imp.STRING_LITERAL().GetText()),
+			Alias: imp.IDENTIFIER().GetText(),
+		}
+		if imp.ObjectLiteral() != nil {
+			stmt.Inputs = l.objectLiteral(imp.ObjectLiteral())
+		}
+		return stmt
+
+	case ctx.FunctionCall() != nil:
+		return &ast.CallStatement{Call: l.call(ctx.FunctionCall())}
+	}
+
+	return nil
+}
+
+func (l *lowerer) blockDeclaration(ctx *parser.BlockDeclarationContext) ast.Statement {
+	blockType := ctx.IDENTIFIER().GetText()
+	name := unquote(ctx.STRING_LITERAL().GetText())
+
+	if blockType == "variable" {
+		return l.variableBlock(ctx, name)
+	}
+
+	block := &ast.BlockDeclaration{Pos: l.position(ctx), Type: blockType, Name: name}
+	for _, prop := range ctx.AllProperty() {
+		block.Properties = append(block.Properties, l.property(prop))
+	}
+	return block
+}
+
+// variableBlock lowers an input variable. Its type is written as a bare or
+// quoted type name rather than an expression.
+func (l *lowerer) variableBlock(ctx *parser.BlockDeclarationContext, name string) ast.Statement {
+	block := &ast.VariableBlock{Pos: l.position(ctx), Name: name, Type: "any"}
+
+	for _, prop := range ctx.AllProperty() {
+		switch key := prop.IDENTIFIER().GetText(); key {
+		case "type":
+			block.Type = unquote(prop.Expression().GetText())
+			if !variableTypes[block.Type] {
+				l.addError(l.position(prop.Expression()), "unknown type %s for variable %s", block.Type, name)
+			}
+		case "default":
+			block.Default = l.expression(prop.Expression())
+		case "validate":
+			block.Validate = l.expression(prop.Expression())
+		case "error_message":
+			block.ErrorMessage = l.expression(prop.Expression())
+		case "description":
+		default:
+			l.addError(l.position(prop), "unsupported attribute %s in variable %s", key, name)
+		}
+	}
+
+	return block
+}
+
+func (l *lowerer) ifStatement(ctx *parser.IfStatementContext) *ast.IfStatement {
+	stmt := &ast.IfStatement{
+		Pos:       l.position(ctx),
+		Condition: l.expression(ctx.Expression()),
+		Then:      l.statements(ctx.AllStatement()),
+	}
+
+	if ctx.ElseClause() != nil {
+		elseCtx := ctx.ElseClause().(*parser.ElseClauseContext)
+		if elseCtx.IfStatement() != nil {
+			stmt.ElseIf = l.ifStatement(elseCtx.IfStatement().(*parser.IfStatementContext))
+		} else {
+			stmt.Else = l.statements(elseCtx.AllStatement())
+		}
+	}
+
+	return stmt
+}
+
+func (l *lowerer) property(ctx propertyContext) *ast.Property {
+	return &ast.Property{
+		Pos:   l.position(ctx),
+		Key:   ctx.IDENTIFIER().GetText(),
+		Value: l.expression(ctx.Expression()),
+	}
+}
+
+func (l *lowerer) expression(ctx parser.IExpressionContext) ast.Expression {
+	e, ok := ctx.(*parser.ExpressionContext)
+	if !ok {
+		return nil
+	}
+	pos := l.position(e)
+	exprs := e.AllExpression()
+
+	switch {
+	case len(exprs) == 3:
+		return &ast.Conditional{
+			Pos:       pos,
+			Condition: l.expression(exprs[0]),
+			Then:      l.expression(exprs[1]),
+			Else:      l.expression(exprs[2]),
+		}
+	case len(exprs) == 2 && e.LBRACKET() != nil:
+		return &ast.Index{Pos: pos, Collection: l.expression(exprs[0]), Key: l.expression(exprs[1])}
+	case len(exprs) == 2:
+		return &ast.Binary{
+			Pos:   pos,
+			Op:    e.GetChild(1).(antlr.ParseTree).GetText(),
+			Left:  l.expression(exprs[0]),
+			Right: l.expression(exprs[1]),
+		}
+	case len(exprs) == 1 && e.DOT() != nil:
+		return &ast.Selector{Pos: pos, Object: l.expression(exprs[0]), Name: e.IDENTIFIER().GetText()}
+	case len(exprs) == 1 && (e.MINUS() != nil || e.NOT() != nil):
+		return &ast.Unary{Pos: pos, Op: e.GetChild(0).(antlr.ParseTree).GetText(), Operand: l.expression(exprs[0])}
+	case len(exprs) == 1:
+		return l.expression(exprs[0])
+
+	case e.STRING_LITERAL() != nil:
+		return l.template(pos, unquote(e.STRING_LITERAL().GetText()))
+	case e.NUMBER() != nil:
+		value, _ := strconv.ParseFloat(e.NUMBER().GetText(), 64)
+		return &ast.NumberLiteral{Pos: pos, Value: value}
+	case e.BOOLEAN() != nil:
+		return &ast.BooleanLiteral{Pos: pos, Value: e.BOOLEAN().GetText() == "true"}
+	case e.IDENTIFIER() != nil:
+		return &ast.Identifier{Pos: pos, Name: e.IDENTIFIER().GetText()}
+	case e.ObjectLiteral() != nil:
+		return l.objectLiteral(e.ObjectLiteral())
+	case e.ArrayLiteral() != nil:
+		arr := &ast.ArrayLiteral{Pos: pos}
+		for _, elem := range e.ArrayLiteral().AllExpression() {
+			arr.Elements = append(arr.Elements, l.expression(elem))
+		}
+		return arr
+	case e.ListComprehension() != nil:
+		return l.listComprehension(e.ListComprehension().(*parser.ListComprehensionContext))
+	case e.MapComprehension() != nil:
+		return l.mapComprehension(e.MapComprehension().(*parser.MapComprehensionContext))
+	case e.FunctionCall() != nil:
+		return l.call(e.FunctionCall())
+	}
+
+	return nil
+}
+
+func (l *lowerer) objectLiteral(ctx parser.IObjectLiteralContext) *ast.ObjectLiteral {
+	obj := &ast.ObjectLiteral{Pos: l.position(ctx)}
+	for _, prop := range ctx.AllObjectProperty() {
+		obj.Properties = append(obj.Properties, l.property(prop))
+	}
+	return obj
+}
+
+func (l *lowerer) listComprehension(ctx *parser.ListComprehensionContext) ast.Expression {
+	exprs := ctx.AllExpression()
+	comp := &ast.ListComprehension{
+		Pos:        l.position(ctx),
+		Vars:       identifiers(ctx.AllIDENTIFIER()),
+		Collection: l.expression(exprs[0]),
+		Value:      l.expression(exprs[1]),
+	}
+	if ctx.IF() != nil {
+		comp.Condition = l.expression(exprs[2])
+	}
+	return comp
+}
+
+func (l *lowerer) mapComprehension(ctx *parser.MapComprehensionContext) ast.Expression {
+	exprs := ctx.AllExpression()
+	comp := &ast.MapComprehension{
+		Pos:        l.position(ctx),
+		Vars:       identifiers(ctx.AllIDENTIFIER()),
+		Collection: l.expression(exprs[0]),
+		Key:        l.expression(exprs[1]),
+		Value:      l.expression(exprs[2]),
+	}
+	if ctx.IF() != nil {
+		comp.Condition = l.expression(exprs[3])
+	}
+	return comp
+}
+
+func (l *lowerer) call(ctx parser.IFunctionCallContext) *ast.Call {
+	call := &ast.Call{Pos: l.position(ctx), Name: ctx.IDENTIFIER().GetText()}
+	if ctx.ArgumentList() != nil {
+		for _, arg := range ctx.ArgumentList().AllExpression() {
+			call.Args = append(call.Args, l.expression(arg))
+		}
+	}
+	return call
+}
+
+func identifiers(nodes []antlr.TerminalNode) []string {
+	names := make([]string, len(nodes))
+	for i, node := range nodes {
+		names[i] = node.GetText()
+	}
+	return names
+}
+
+func unquote(s string) string {
+	return strings.Trim(s, `"'`)
+}
@@ -0,0 +1,142 @@
+package compiler
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/tblang/core/internal/ast"
+	"github.com/tblang/core/parser"
+)
+
+type syntaxErrorListener struct {
+	*antlr.DefaultErrorListener
+	msg    string
+	line   int
+	column int
+}
+
+func (l *syntaxErrorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
+	if l.msg == "" {
+		l.msg = msg
+		l.line = line
+		l.column = column
+	}
+}
+
+// template lowers the contents of a string literal, parsing its ${...}
+// placeholders into expressions. A literal "${" can be written as "$${".
+func (l *lowerer) template(pos ast.Position, raw string) ast.Expression {
+	if !strings.Contains(raw, "${") {
+		return &ast.StringLiteral{Pos: pos, Value: raw}
+	}
+
+	tmpl := &ast.Template{Pos: pos}
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			tmpl.Parts = append(tmpl.Parts, ast.TemplatePart{Text: text.String()})
+			text.Reset()
+		}
+	}
+
+	placeholders := 0
+	for i := 0; i < len(raw); {
+		if strings.HasPrefix(raw[i:], "$${") {
+			text.WriteString("${")
+			i += 3
+			continue
+		}
+		if !strings.HasPrefix(raw[i:], "${") {
+			text.WriteByte(raw[i])
+			i++
+			continue
+		}
+
+		end := findPlaceholderEnd(raw, i+2)
+		if end < 0 {
+			l.addError(pos, "unterminated placeholder in string %q", raw)
+			return nil
+		}
+
+		source := strings.TrimSpace(raw[i+2 : end])
+		if source == "" {
+			l.addError(pos, "empty placeholder in string %q", raw)
+			return nil
+		}
+
+		expr, err := parseExpressionSource(source)
+		if err != nil {
+			l.addError(pos, "invalid placeholder ${%s}: %v", source, err)
+			return nil
+		}
+
+		flush()
+		tmpl.Parts = append(tmpl.Parts, ast.TemplatePart{Source: source, Expr: l.placeholder(pos, expr)})
+		placeholders++
+		i = end + 1
+	}
+	flush()
+
+	if placeholders == 0 {
+		return &ast.StringLiteral{Pos: pos, Value: tmpl.Parts[0].Text}
+	}
+	return tmpl
+}
+
+func (l *lowerer) placeholder(pos ast.Position, expr parser.IExpressionContext) ast.Expression {
+	saved := l.at
+	l.at = &pos
+	defer func() { l.at = saved }()
+	return l.expression(expr)
+}
+
+// findPlaceholderEnd returns the index of the "}" closing a placeholder whose
+// body starts at start, skipping nested braces and quoted strings.
+func findPlaceholderEnd(s string, start int) int {
+	depth := 0
+	var quote byte
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '{':
+			depth++
+		case c == '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// parseExpressionSource parses source as a single standalone expression.
+func parseExpressionSource(source string) (parser.IExpressionContext, error) {
+	listener := &syntaxErrorListener{DefaultErrorListener: antlr.NewDefaultErrorListener()}
+
+	lexer := parser.NewtblangLexer(antlr.NewInputStream(source))
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(listener)
+	stream := antlr.NewCommonTokenStream(lexer, 0)
+	p := parser.NewtblangParser(stream)
+	p.RemoveErrorListeners()
+	p.AddErrorListener(listener)
+
+	expr := p.Expression()
+	if listener.msg == "" && stream.LA(1) != antlr.TokenEOF {
+		listener.msg = "unexpected input '" + stream.LT(1).GetText() + "'"
+	}
+	if listener.msg != "" {
+		return nil, errors.New(listener.msg)
+	}
+	return expr, nil
+}
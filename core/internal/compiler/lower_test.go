@@ -0,0 +1,210 @@
+package compiler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tblang/core/internal/ast"
+)
+
+// sexpr prints an expression as an s-expression, so that tests can state
+// the shape of the tree in one line.
+func sexpr(expr ast.Expression) string {
+	switch e := expr.(type) {
+	case *ast.StringLiteral:
+		return strconv.Quote(e.Value)
+	case *ast.NumberLiteral:
+		return strconv.FormatFloat(e.Value, 'g', -1, 64)
+	case *ast.BooleanLiteral:
+		return strconv.FormatBool(e.Value)
+	case *ast.Identifier:
+		return e.Name
+	case *ast.Template:
+		parts := []string{"template"}
+		for _, part := range e.Parts {
+			if part.Expr != nil {
+				parts = append(parts, "${"+sexpr(part.Expr)+"}")
+			} else {
+				parts = append(parts, strconv.Quote(part.Text))
+			}
+		}
+		return "(" + strings.Join(parts, " ") + ")"
+	case *ast.ObjectLiteral:
+		parts := []string{"object"}
+		for _, prop := range e.Properties {
+			parts = append(parts, prop.Key+"="+sexpr(prop.Value))
+		}
+		return "(" + strings.Join(parts, " ") + ")"
+	case *ast.ArrayLiteral:
+		parts := []string{"list"}
+		for _, elem := range e.Elements {
+			parts = append(parts, sexpr(elem))
+		}
+		return "(" + strings.Join(parts, " ") + ")"
+	case *ast.ListComprehension:
+		s := fmt.Sprintf("(for-list %s %s %s", strings.Join(e.Vars, ","), sexpr(e.Collection), sexpr(e.Value))
+		if e.Condition != nil {
+			s += " if " + sexpr(e.Condition)
+		}
+		return s + ")"
+	case *ast.Selector:
+		return "(. " + sexpr(e.Object) + " " + e.Name + ")"
+	case *ast.Index:
+		return "([] " + sexpr(e.Collection) + " " + sexpr(e.Key) + ")"
+	case *ast.Unary:
+		return "(" + e.Op + " " + sexpr(e.Operand) + ")"
+	case *ast.Binary:
+		return "(" + e.Op + " " + sexpr(e.Left) + " " + sexpr(e.Right) + ")"
+	case *ast.Conditional:
+		return "(? " + sexpr(e.Condition) + " " + sexpr(e.Then) + " " + sexpr(e.Else) + ")"
+	case *ast.Call:
+		parts := []string{e.Name}
+		for _, arg := range e.Args {
+			parts = append(parts, sexpr(arg))
+		}
+		return "(" + strings.Join(parts, " ") + ")"
+	}
+	return fmt.Sprintf("<%T>", expr)
+}
+
+// lowerSource parses and lowers src without checking or evaluating it.
+func lowerSource(t *testing.T, src string) (*ast.File, error) {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "main.tbl")
+	if err := os.WriteFile(filename, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return New().loadFile(filename)
+}
+
+func TestLowerExpressions(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`1 + 2 * 3`, `(+ 1 (* 2 3))`},
+		{`(1 + 2) * 3`, `(* (+ 1 2) 3)`},
+		{`a && b || !c`, `(|| (&& a b) (! c))`},
+		{`-x < 2 == true`, `(== (< (- x) 2) true)`},
+		{`c ? "a" : "b"`, `(? c "a" "b")`},
+		{`m.key[0].name`, `(. ([] (. m key) 0) name)`},
+		{`"plain"`, `"plain"`},
+		{`"${env}-vpc"`, `(template ${env} "-vpc")`},
+		{`"n=${n + 1}"`, `(template "n=" ${(+ n 1)})`},
+		{`[1, "two", [true]]`, `(list 1 "two" (list true))`},
+		{`{ a: 1, b: { c: x } }`, `(object a=1 b=(object c=x))`},
+		{`[for v in xs: v * 2 if v > 0]`, `(for-list v xs (* v 2) if (> v 0))`},
+		{`max(1, length(xs))`, `(max 1 (length xs))`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			file, err := lowerSource(t, "declare x = "+tt.src+";\n")
+			if err != nil {
+				t.Fatal(err)
+			}
+			decl, ok := file.Statements[0].(*ast.VariableDeclaration)
+			if !ok {
+				t.Fatalf("got %T, want a variable declaration", file.Statements[0])
+			}
+			if got := sexpr(decl.Value); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLowerStatements(t *testing.T) {
+	file, err := lowerSource(t, `cloud_vendor "aws" {
+    region = "us-east-1"
+}
+
+variable "azs" {
+    type = list
+    default = []
+}
+
+func double(x) {
+    return x * 2;
+}
+
+for i, az in azs {
+    if (i == 0) {
+        print(az);
+    } else if (i == 1) {
+        print(double(i));
+    }
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var kinds []string
+	for _, stmt := range file.Statements {
+		kinds = append(kinds, fmt.Sprintf("%T", stmt))
+	}
+	want := "*ast.BlockDeclaration *ast.VariableBlock *ast.FunctionDeclaration *ast.ForLoop"
+	if got := strings.Join(kinds, " "); got != want {
+		t.Fatalf("statements: got %s, want %s", got, want)
+	}
+
+	block := file.Statements[1].(*ast.VariableBlock)
+	if block.Name != "azs" || block.Type != "list" || sexpr(block.Default) != "(list)" {
+		t.Errorf("variable block: got %s %s %s", block.Name, block.Type, sexpr(block.Default))
+	}
+
+	fn := file.Statements[2].(*ast.FunctionDeclaration)
+	if fn.Name != "double" || strings.Join(fn.Params, ",") != "x" || len(fn.Body) != 1 {
+		t.Errorf("function: got %s(%s) with %d statements", fn.Name, strings.Join(fn.Params, ","), len(fn.Body))
+	}
+
+	loop := file.Statements[3].(*ast.ForLoop)
+	if strings.Join(loop.Vars, ",") != "i,az" || loop.Pos.Line != 14 || loop.Pos.Column != 1 {
+		t.Errorf("loop: got vars %v at %s", loop.Vars, loop.Pos)
+	}
+	ifStmt := loop.Body[0].(*ast.IfStatement)
+	if ifStmt.ElseIf == nil || sexpr(ifStmt.ElseIf.Condition) != "(== i 1)" || ifStmt.Else != nil {
+		t.Errorf("else if was not lowered to a nested if statement")
+	}
+}
+
+func TestLowerErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "unterminated placeholder",
+			src:  "declare s = \"${\";\n",
+			want: []string{`1:13: unterminated placeholder in string "${"`},
+		},
+		{
+			name: "empty placeholder",
+			src:  "declare s = \"a${}b\";\n",
+			want: []string{`1:13: empty placeholder in string "a${}b"`},
+		},
+		{
+			name: "unknown variable type",
+			src:  "variable \"x\" {\n    type = strng\n}\n",
+			want: []string{"2:12: unknown type strng for variable x"},
+		},
+		{
+			name: "unsupported variable attribute",
+			src:  "variable \"x\" {\n    type = string\n    secret = true\n}\n",
+			want: []string{"3:5: unsupported attribute secret in variable x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lowerSource(t, tt.src)
+			checkDiagnostics(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package compiler
+
+import (
+	"github.com/tblang/core/internal/ast"
+)
+
+// resolver checks that every variable and function used in a file is
+// declared in an enclosing scope, and that declarations are well formed.
+// Top-level declarations are visible throughout the file, so they can be
+// used before the statement declaring them; inside loops and functions
+// names must be declared before they are used.
+type resolver struct {
+	compiler *Compiler
+	errors   []error
+	scope    *resolveScope
+	inputs   map[string]bool
+
+	// functionDepth counts the function bodies being resolved, and
+	// placeholder is the template part being resolved, if any.
+	functionDepth int
+	placeholder   *ast.TemplatePart
+}
+
+type resolveScope struct {
+	parent    *resolveScope
+	variables map[string]bool
+	functions map[string]*ast.FunctionDeclaration
+}
+
+func (c *Compiler) resolve(file *ast.File) []error {
+	r := &resolver{compiler: c, inputs: make(map[string]bool)}
+	r.push()
+	r.declareTopLevel(file.Statements)
+	r.statements(file.Statements, true)
+	return r.errors
+}
+
+func (r *resolver) addError(node ast.Node, format string, args ...interface{}) {
+	r.errors = append(r.errors, r.compiler.diagnostic(node.Position(), format, args...))
+}
+
+func (r *resolver) push() {
+	r.scope = &resolveScope{
+		parent:    r.scope,
+		variables: make(map[string]bool),
+		functions: make(map[string]*ast.FunctionDeclaration),
+	}
+}
+
+func (r *resolver) pop() {
+	r.scope = r.scope.parent
+}
+
+func (r *resolver) variable(name string) bool {
+	for s := r.scope; s != nil; s = s.parent {
+		if s.variables[name] {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *resolver) function(name string) *ast.FunctionDeclaration {
+	for s := r.scope; s != nil; s = s.parent {
+		if fn, exists := s.functions[name]; exists {
+			return fn
+		}
+	}
+	return nil
+}
+
+// declareTopLevel declares the names of the top-level statements of a file
+// before any of them is resolved.
+func (r *resolver) declareTopLevel(stmts []ast.Statement) {
+	declarations := make(map[string]int)
+	for _, stmt := range stmts {
+		if name := declaredName(stmt); name != "" {
+			declarations[name]++
+			r.scope.variables[name] = true
+		}
+	}
+
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *ast.FunctionDeclaration:
+			r.declareFunction(s)
+		case *ast.ImportStatement:
+			if declarations[s.Alias] > 1 {
+				r.addError(s, "module alias %s conflicts with an existing variable", s.Alias)
+			}
+		}
+	}
+}
+
+func (r *resolver) declareFunction(fn *ast.FunctionDeclaration) {
+	if fn.Name == "print" || fn.Name == "output" || isBuiltin(fn.Name) || isResourceType(fn.Name) || isDataSourceType(fn.Name) {
+		r.addError(fn, "cannot redefine built-in function %s", fn.Name)
+		return
+	}
+	if _, exists := r.scope.functions[fn.Name]; exists {
+		r.addError(fn, "function %s is already declared", fn.Name)
+		return
+	}
+	r.scope.functions[fn.Name] = fn
+}
+
+// statements resolves a list of statements in the current scope. topLevel is
+// set for the statements of a file, whose declarations are already known.
+func (r *resolver) statements(stmts []ast.Statement, topLevel bool) {
+	for _, stmt := range stmts {
+		r.statement(stmt, topLevel)
+	}
+}
+
+func (r *resolver) statement(stmt ast.Statement, topLevel bool) {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		r.expression(s.Value)
+		r.scope.variables[s.Name] = true
+
+	case *ast.VariableBlock:
+		if r.inputs[s.Name] {
+			r.addError(s, "variable %s is already declared", s.Name)
+		}
+		r.inputs[s.Name] = true
+		r.scope.variables[s.Name] = true
+		r.expression(s.Default)
+		r.expression(s.Validate)
+		r.expression(s.ErrorMessage)
+
+	case *ast.BlockDeclaration:
+		for _, prop := range s.Properties {
+			r.expression(prop.Value)
+		}
+
+	case *ast.ForLoop:
+		r.expression(s.Collection)
+		r.push()
+		for _, name := range s.Vars {
+			r.scope.variables[name] = true
+		}
+		r.statements(s.Body, false)
+		r.pop()
+
+	case *ast.IfStatement:
+		// The branches of an if share the enclosing scope.
+		r.expression(s.Condition)
+		r.statements(s.Then, false)
+		if s.ElseIf != nil {
+			r.statement(s.ElseIf, false)
+		}
+		r.statements(s.Else, false)
+
+	case *ast.FunctionDeclaration:
+		if !topLevel {
+			r.declareFunction(s)
+		}
+		r.functionBody(s)
+
+	case *ast.ReturnStatement:
+		if r.functionDepth == 0 {
+			r.addError(s, "return outside of function")
+		}
+		r.expression(s.Value)
+
+	case *ast.ImportStatement:
+		if !topLevel {
+			r.addError(s, "import is only allowed at the top level of a file")
+		}
+		if s.Inputs != nil {
+			r.expression(s.Inputs)
+		}
+		r.scope.variables[s.Alias] = true
+
+	case *ast.CallStatement:
+		r.expression(s.Call)
+	}
+}
+
+func (r *resolver) functionBody(fn *ast.FunctionDeclaration) {
+	r.push()
+	defer r.pop()
+
+	for _, param := range fn.Params {
+		if r.scope.variables[param] {
+			r.addError(fn, "duplicate parameter %s in function %s", param, fn.Name)
+			return
+		}
+		r.scope.variables[param] = true
+	}
+
+	r.functionDepth++
+	r.statements(fn.Body, false)
+	r.functionDepth--
+}
+
+func (r *resolver) expression(expr ast.Expression) {
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		if r.variable(e.Name) {
+			return
+		}
+		if r.placeholder != nil {
+			r.addError(e, "unresolved reference %q in placeholder ${%s}", e.Name, r.placeholder.Source)
+			return
+		}
+		r.addError(e, "undefined variable %s%s", e.Name, didYouMean(e.Name, r.variableNames()))
+
+	case *ast.Template:
+		saved := r.placeholder
+		for i := range e.Parts {
+			r.placeholder = &e.Parts[i]
+			r.expression(e.Parts[i].Expr)
+		}
+		r.placeholder = saved
+
+	case *ast.ObjectLiteral:
+		for _, prop := range e.Properties {
+			r.expression(prop.Value)
+		}
+
+	case *ast.ArrayLiteral:
+		for _, elem := range e.Elements {
+			r.expression(elem)
+		}
+
+	case *ast.ListComprehension:
+		r.expression(e.Collection)
+		r.push()
+		for _, name := range e.Vars {
+			r.scope.variables[name] = true
+		}
+		r.expression(e.Value)
+		r.expression(e.Condition)
+		r.pop()
+
+	case *ast.MapComprehension:
+		r.expression(e.Collection)
+		r.push()
+		for _, name := range e.Vars {
+			r.scope.variables[name] = true
+		}
+		r.expression(e.Key)
+		r.expression(e.Value)
+		r.expression(e.Condition)
+		r.pop()
+
+	case *ast.Selector:
+		r.expression(e.Object)
+
+	case *ast.Index:
+		r.expression(e.Collection)
+		r.expression(e.Key)
+
+	case *ast.Unary:
+		r.expression(e.Operand)
+
+	case *ast.Binary:
+		r.expression(e.Left)
+		r.expression(e.Right)
+
+	case *ast.Conditional:
+		r.expression(e.Condition)
+		r.expression(e.Then)
+		r.expression(e.Else)
+
+	case *ast.Call:
+		r.call(e)
+	}
+}
+
+func (r *resolver) call(call *ast.Call) {
+	switch {
+	case isResourceType(call.Name) || isDataSourceType(call.Name):
+		r.resourceCall(call)
+		return
+	case call.Name == "print" || call.Name == "output" || isBuiltin(call.Name):
+	default:
+		fn := r.function(call.Name)
+		if fn == nil {
+			r.addError(call, "undefined function %s%s", call.Name, didYouMean(call.Name, r.functionNames()))
+		} else if len(call.Args) != len(fn.Params) {
+			r.addError(call, "function %s expects %d argument(s), got %d", fn.Name, len(fn.Params), len(call.Args))
+		}
+	}
+
+	for _, arg := range call.Args {
+		r.expression(arg)
+	}
+}
+
+// resourceCall resolves the arguments of a resource declaration. The config
+// of a resource using count or for_each can refer to count or each.
+func (r *resolver) resourceCall(call *ast.Call) {
+	for i, arg := range call.Args {
+		config, ok := arg.(*ast.ObjectLiteral)
+		if i != 1 || !ok {
+			r.expression(arg)
+			continue
+		}
+
+		r.push()
+		if config.Property("count") != nil {
+			r.scope.variables["count"] = true
+		}
+		if config.Property("for_each") != nil {
+			r.scope.variables["each"] = true
+		}
+		r.expression(config)
+		r.pop()
+	}
+}
+
+func (r *resolver) variableNames() []string {
+	seen := make(map[string]bool)
+	for s := r.scope; s != nil; s = s.parent {
+		for name := range s.variables {
+			seen[name] = true
+		}
+	}
+	return sortedNames(seen)
+}
+
+func (r *resolver) functionNames() []string {
+	seen := make(map[string]bool)
+	for s := r.scope; s != nil; s = s.parent {
+		for name := range s.functions {
+			seen[name] = true
+		}
+	}
+	for _, name := range Builtins() {
+		seen[name] = true
+	}
+	for _, name := range ResourceTypes() {
+		seen[name] = true
+	}
+	return sortedNames(seen)
+}
@@ -0,0 +1,126 @@
+package compiler
+
+import "testing"
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "forward declarations",
+			src: `declare a = b + double(1);
+declare b = 2;
+
+func double(x) {
+    return x * 2;
+}
+`,
+		},
+		{
+			name: "function locals stay in the function",
+			src: `func f() {
+    declare inner = 1;
+    return inner;
+}
+declare x = f();
+declare y = inner;
+`,
+			want: []string{"6:13: undefined variable inner"},
+		},
+		{
+			name: "loop variables stay in the loop",
+			src: `for item in [1, 2] {
+    print(item);
+}
+print(item);
+`,
+			want: []string{"4:7: undefined variable item"},
+		},
+		{
+			name: "parameters shadow globals",
+			src: `declare x = "global";
+func f(x) {
+    return x + 1;
+}
+declare y = f(1);
+`,
+		},
+		{
+			name: "suggestions",
+			src: `declare region = "us-east-1";
+declare a = regoin;
+declare b = lenght([1]);
+`,
+			want: []string{
+				"2:13: undefined variable regoin (did you mean region?)",
+				"3:13: undefined function lenght (did you mean length?)",
+			},
+		},
+		{
+			name: "arity",
+			src: `func g(a, b) {
+    return a;
+}
+declare q = g(1);
+`,
+			want: []string{"4:13: function g expects 2 argument(s), got 1"},
+		},
+		{
+			name: "duplicate declarations",
+			src: `func g() {
+    return 1;
+}
+func g() {
+    return 2;
+}
+func h(a, a) {
+    return a;
+}
+variable "v" {
+    default = 1
+}
+variable "v" {
+    default = 2
+}
+`,
+			want: []string{
+				"4:1: function g is already declared",
+				"7:1: duplicate parameter a in function h",
+				"13:1: variable v is already declared",
+			},
+		},
+		{
+			name: "built-in names",
+			src: `func length(x) {
+    return 0;
+}
+func vpc() {
+    return 0;
+}
+`,
+			want: []string{
+				"1:1: cannot redefine built-in function length",
+				"4:1: cannot redefine built-in function vpc",
+			},
+		},
+		{
+			name: "return outside of a function",
+			src:  "return 1;\n",
+			want: []string{"1:1: return outside of function"},
+		},
+		{
+			name: "placeholder",
+			src:  "declare s = \"${nope}-x\";\n",
+			want: []string{`1:13: unresolved reference "nope" in placeholder ${nope}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileSource(t, tt.src, nil)
+			checkDiagnostics(t, err, tt.want)
+		})
+	}
+}
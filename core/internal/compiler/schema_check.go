@@ -0,0 +1,110 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/tblang/core/internal/ast"
+	"github.com/tblang/core/pkg/plugin"
+)
+
+type schemaError struct {
+	pos ast.Position
+	msg string
+}
+
+// CheckResourceSchemas validates the attributes of every resource and data
+// source in program against the schemas reported by the providers: unknown
+// attributes, missing required ones, values for computed ones and values of
+// the wrong type are all reported, ordered by position.
+func (c *Compiler) CheckResourceSchemas(program *Program, schemas *plugin.GetSchemaResponse) error {
+	var found []schemaError
+
+	for _, resource := range program.Resources {
+		schema, exists := schemas.ResourceSchemas[resource.Type]
+		if !exists {
+			schema, exists = schemas.DataSourceSchemas[resource.Type]
+		}
+		if !exists || schema == nil || schema.Block == nil {
+			found = append(found, schemaError{resource.Pos, fmt.Sprintf("no provider supports resource type %s", resource.Type)})
+			continue
+		}
+
+		found = append(found, checkResourceAttributes(resource, schema.Block)...)
+	}
+
+	if len(found) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		a, b := found[i].pos, found[j].pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	errs := make([]error, len(found))
+	for i, e := range found {
+		errs[i] = c.diagnostic(e.pos, "%s", e.msg)
+	}
+	return errors.Join(errs...)
+}
+
+func checkResourceAttributes(resource *ast.Resource, block *plugin.SchemaBlock) []schemaError {
+	var found []schemaError
+
+	attrPos := func(name string) ast.Position {
+		if pos, exists := resource.AttributePos[name]; exists {
+			return pos
+		}
+		return resource.Pos
+	}
+
+	for _, name := range sortedKeys(resource.Properties) {
+		value := resource.Properties[name]
+
+		if _, isBlock := block.BlockTypes[name]; isBlock {
+			continue
+		}
+
+		attr, exists := block.Attributes[name]
+		if !exists {
+			found = append(found, schemaError{attrPos(name), fmt.Sprintf("%s %s has no attribute %q", resource.Type, resource.Name, name)})
+			continue
+		}
+
+		if attr.Computed && !attr.Required && !attr.Optional {
+			found = append(found, schemaError{attrPos(name), fmt.Sprintf("attribute %s of %s %s is computed and cannot be set", name, resource.Type, resource.Name)})
+			continue
+		}
+
+		if value == nil || isInvalid(value) || attr.Type == "" || attr.Type == "any" {
+			continue
+		}
+		if _, deferred := value.(*ast.Reference); deferred {
+			continue
+		}
+		if actual := typeName(value); actual != attr.Type {
+			found = append(found, schemaError{attrPos(name), fmt.Sprintf("attribute %s of %s %s must be a %s, got %s", name, resource.Type, resource.Name, attr.Type, actual)})
+		}
+	}
+
+	var required []string
+	for name, attr := range block.Attributes {
+		if attr.Required && resource.Properties[name] == nil {
+			required = append(required, name)
+		}
+	}
+	sort.Strings(required)
+	for _, name := range required {
+		found = append(found, schemaError{resource.Pos, fmt.Sprintf("%s %s is missing required attribute %s", resource.Type, resource.Name, name)})
+	}
+
+	return found
+}
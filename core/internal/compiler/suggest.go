@@ -0,0 +1,65 @@
+package compiler
+
+import "sort"
+
+func (w *ASTWalker) variableNames() []string {
+	seen := make(map[string]bool)
+	for s := w.scope; s != nil; s = s.parent {
+		for name := range s.variables {
+			seen[name] = true
+		}
+	}
+	for name, pending := range w.pending {
+		if len(pending) > 0 {
+			seen[name] = true
+		}
+	}
+	return sortedNames(seen)
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// didYouMean returns a " (did you mean x?)" hint naming the candidate closest
+// to name, or "" if none is close enough to be a likely typo.
+func didYouMean(name string, candidates []string) string {
+	best, bestDistance := "", len(name)/3+1
+	for _, candidate := range candidates {
+		if d := editDistance(name, candidate); d <= bestDistance && (best == "" || d < editDistance(name, best)) {
+			best, bestDistance = candidate, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return " (did you mean " + best + "?)"
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,259 @@
+package compiler
+
+import (
+	"github.com/tblang/core/internal/ast"
+)
+
+// builtinResults is the type of value returned by each built-in function
+// whose result type does not depend on its arguments.
+var builtinResults = map[string]string{
+	"length":       "number",
+	"join":         "string",
+	"split":        "list",
+	"format":       "string",
+	"merge":        "map",
+	"concat":       "list",
+	"keys":         "list",
+	"values":       "list",
+	"upper":        "string",
+	"lower":        "string",
+	"replace":      "string",
+	"range":        "list",
+	"base64encode": "string",
+	"jsonencode":   "string",
+	"file":         "string",
+	"templatefile": "string",
+}
+
+// typeChecker reports operations whose operands are known from the source
+// alone to have the wrong type, such as arithmetic on a string literal or a
+// condition that is a list, and calls of built-in functions with the wrong
+// number of arguments. Types are inferred from literals, operators and
+// built-in results; the type of a variable is only known once the file is
+// evaluated, so operations on variables are left to the evaluator.
+type typeChecker struct {
+	compiler *Compiler
+	errors   []error
+}
+
+func (c *Compiler) typeCheck(file *ast.File) []error {
+	t := &typeChecker{compiler: c}
+	t.statements(file.Statements)
+	return t.errors
+}
+
+func (t *typeChecker) addError(node ast.Node, format string, args ...interface{}) {
+	t.errors = append(t.errors, t.compiler.diagnostic(node.Position(), format, args...))
+}
+
+func (t *typeChecker) statements(stmts []ast.Statement) {
+	for _, stmt := range stmts {
+		t.statement(stmt)
+	}
+}
+
+func (t *typeChecker) statement(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		t.expression(s.Value)
+
+	case *ast.VariableBlock:
+		if s.Default != nil {
+			if typ := t.expression(s.Default); typ != "" && s.Type != "any" && typ != s.Type {
+				t.addError(s, "variable %s expects a %s value, got %s", s.Name, s.Type, typ)
+			}
+		}
+		if s.Validate != nil {
+			t.condition(s.Validate)
+		}
+		if s.ErrorMessage != nil {
+			t.expression(s.ErrorMessage)
+		}
+
+	case *ast.BlockDeclaration:
+		for _, prop := range s.Properties {
+			t.expression(prop.Value)
+		}
+
+	case *ast.ForLoop:
+		t.iterable(s.Collection)
+		t.statements(s.Body)
+
+	case *ast.IfStatement:
+		t.condition(s.Condition)
+		t.statements(s.Then)
+		if s.ElseIf != nil {
+			t.statement(s.ElseIf)
+		}
+		t.statements(s.Else)
+
+	case *ast.FunctionDeclaration:
+		t.statements(s.Body)
+
+	case *ast.ReturnStatement:
+		t.expression(s.Value)
+
+	case *ast.ImportStatement:
+		if s.Inputs != nil {
+			t.expression(s.Inputs)
+		}
+
+	case *ast.CallStatement:
+		t.expression(s.Call)
+	}
+}
+
+func (t *typeChecker) condition(expr ast.Expression) {
+	if typ := t.expression(expr); typ != "" && typ != "bool" {
+		t.addError(expr, "condition must be bool, got %s", typ)
+	}
+}
+
+func (t *typeChecker) iterable(expr ast.Expression) {
+	switch typ := t.expression(expr); typ {
+	case "string", "number", "bool":
+		t.addError(expr, "%s is not iterable, got %s", describe(expr), typ)
+	}
+}
+
+// expression checks expr and returns its type, or "" if it is not known
+// before evaluation.
+func (t *typeChecker) expression(expr ast.Expression) string {
+	switch e := expr.(type) {
+	case *ast.StringLiteral:
+		return "string"
+
+	case *ast.Template:
+		for _, part := range e.Parts {
+			if part.Expr != nil {
+				t.expression(part.Expr)
+			}
+		}
+		return "string"
+
+	case *ast.NumberLiteral:
+		return "number"
+
+	case *ast.BooleanLiteral:
+		return "bool"
+
+	case *ast.ObjectLiteral:
+		for _, prop := range e.Properties {
+			t.expression(prop.Value)
+		}
+		return "map"
+
+	case *ast.ArrayLiteral:
+		for _, elem := range e.Elements {
+			t.expression(elem)
+		}
+		return "list"
+
+	case *ast.ListComprehension:
+		t.iterable(e.Collection)
+		t.expression(e.Value)
+		if e.Condition != nil {
+			t.condition(e.Condition)
+		}
+		return "list"
+
+	case *ast.MapComprehension:
+		t.iterable(e.Collection)
+		if typ := t.expression(e.Key); typ != "" && typ != "string" {
+			t.addError(e.Key, "map comprehension key must be a string, got %s", typ)
+		}
+		t.expression(e.Value)
+		if e.Condition != nil {
+			t.condition(e.Condition)
+		}
+		return "map"
+
+	case *ast.Selector:
+		t.expression(e.Object)
+
+	case *ast.Index:
+		collection, key := t.expression(e.Collection), t.expression(e.Key)
+		switch {
+		case collection == "list" && key != "" && key != "number":
+			t.addError(e.Key, "list index must be a number, got %s", key)
+		case collection == "map" && key != "" && key != "string":
+			t.addError(e.Key, "map key must be a string, got %s", key)
+		case collection == "string" || collection == "number" || collection == "bool":
+			t.addError(e, "cannot index into %s", collection)
+		}
+
+	case *ast.Unary:
+		want := "number"
+		if e.Op == "!" {
+			want = "bool"
+		}
+		if typ := t.expression(e.Operand); typ != "" && typ != want {
+			t.addError(e, "operator %s cannot be applied to %s", e.Op, typ)
+		}
+		return want
+
+	case *ast.Binary:
+		return t.binary(e)
+
+	case *ast.Conditional:
+		t.condition(e.Condition)
+		if then, els := t.expression(e.Then), t.expression(e.Else); then == els {
+			return then
+		}
+
+	case *ast.Call:
+		for _, arg := range e.Args {
+			t.expression(arg)
+		}
+		if fn, exists := builtins[e.Name]; exists {
+			if err := fn.checkArity(e.Name, len(e.Args)); err != nil {
+				t.addError(e, "%v", err)
+			}
+			return builtinResults[e.Name]
+		}
+	}
+
+	return ""
+}
+
+func (t *typeChecker) binary(e *ast.Binary) string {
+	left, right := t.expression(e.Left), t.expression(e.Right)
+
+	switch e.Op {
+	case "&&", "||":
+		for _, typ := range []string{left, right} {
+			if typ != "" && typ != "bool" {
+				t.addError(e, "operator %s requires bool operands, got %s", e.Op, typ)
+				break
+			}
+		}
+		return "bool"
+	case "==", "!=":
+		return "bool"
+	}
+
+	comparison := e.Op == "<" || e.Op == "<=" || e.Op == ">" || e.Op == ">="
+	result := "number"
+	switch {
+	case comparison:
+		result = "bool"
+	case e.Op == "+" && (left != "number" || right != "number"):
+		result = ""
+		if left == "string" && right == "string" {
+			result = "string"
+		}
+	}
+
+	if left == "" || right == "" {
+		return result
+	}
+	if left == "number" && right == "number" {
+		return result
+	}
+	if left == "string" && right == "string" && (e.Op == "+" || comparison) {
+		return result
+	}
+
+	t.addError(e, "operator %s cannot be applied to %s and %s", e.Op, left, right)
+	return result
+}
@@ -0,0 +1,93 @@
+package compiler
+
+import "testing"
+
+func TestTypeCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "operators",
+			src: `declare a = "x" - 1;
+declare b = !3;
+declare c = 1 && true;
+declare d = "a" < "b";
+declare e = "a" + "b";
+`,
+			want: []string{
+				"1:13: operator - cannot be applied to string and number",
+				"2:13: operator ! cannot be applied to number",
+				"3:13: operator && requires bool operands, got number",
+			},
+		},
+		{
+			name: "types flow through declarations",
+			src: `declare n = 1 + 2;
+declare s = n + "x";
+`,
+			want: []string{"2:13: operator + cannot be applied to number and string"},
+		},
+		{
+			name: "indexing",
+			src: `declare a = [1]["k"];
+declare b = { k: 1 }[0];
+declare c = 5[0];
+`,
+			want: []string{
+				"1:17: list index must be a number, got string",
+				"2:22: map key must be a string, got number",
+				"3:13: cannot index into number",
+			},
+		},
+		{
+			name: "conditions and iteration",
+			src: `if (1) {
+}
+for v in 3 {
+}
+declare m = {for k in ["a"]: 1 => k};
+`,
+			want: []string{
+				"1:5: condition must be bool, got number",
+				"3:10: 3 is not iterable, got number",
+				"5:30: map comprehension key must be a string, got number",
+			},
+		},
+		{
+			name: "variable defaults",
+			src: `variable "port" {
+    type = number
+    default = "80"
+}
+variable "any" {
+    default = "80"
+}
+`,
+			want: []string{"1:1: variable port expects a number value, got string"},
+		},
+		{
+			name: "built-in arity",
+			src:  "declare a = length();\n",
+			want: []string{"1:13: length expects 1 argument(s), got 0"},
+		},
+		{
+			name: "unknown types are left to evaluation",
+			src: `func f(x) {
+    return x;
+}
+declare a = f("a") + "x";
+declare b = f(1) + "x";
+`,
+			want: []string{"5:13: operator + cannot be applied to number and string"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileSource(t, tt.src, nil)
+			checkDiagnostics(t, err, tt.want)
+		})
+	}
+}
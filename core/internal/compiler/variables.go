@@ -0,0 +1,148 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tblang/core/internal/ast"
+)
+
+const envVariablePrefix = "TBLANG_VAR_"
+
+// InputVariables carries values for root variable blocks. TBLANG_VAR_*
+// environment variables are read automatically; var files override them and
+// -var flags override both.
+type InputVariables struct {
+	Flags map[string]string
+	Files []string
+}
+
+// rawInput is a variable value given as text on the command line or in the
+// environment; it is converted once the variable's declared type is known.
+type rawInput string
+
+func (c *Compiler) SetInputVariables(vars *InputVariables) {
+	c.inputVariables = vars
+}
+
+// AllowUnsetVariables makes required root variables that have no value
+// unknown rather than an error, so that the rest of a file can still be
+// checked, e.g. in an editor.
+func (c *Compiler) AllowUnsetVariables() {
+	c.allowUnset = true
+}
+
+func (c *Compiler) resolveInputVariables() (map[string]interface{}, map[string]string, error) {
+	values := make(map[string]interface{})
+	sources := make(map[string]string)
+
+	for _, env := range os.Environ() {
+		key, value, found := strings.Cut(env, "=")
+		if !found || !strings.HasPrefix(key, envVariablePrefix) {
+			continue
+		}
+		values[strings.TrimPrefix(key, envVariablePrefix)] = rawInput(value)
+	}
+
+	if c.inputVariables == nil {
+		return values, sources, nil
+	}
+
+	for _, file := range c.inputVariables.Files {
+		fileValues, err := c.loadVarFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		for name, value := range fileValues {
+			values[name] = value
+			sources[name] = file
+		}
+	}
+
+	for name, value := range c.inputVariables.Flags {
+		values[name] = rawInput(value)
+		sources[name] = "-var"
+	}
+
+	return values, sources, nil
+}
+
+func (c *Compiler) loadVarFile(filename string) (map[string]interface{}, error) {
+	p, listener, err := c.newParser(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read var file: %w", err)
+	}
+
+	tree := p.VarFile()
+	if err := listener.err(); err != nil {
+		return nil, err
+	}
+
+	l := &lowerer{compiler: c, filename: filename}
+	var props []*ast.Property
+	for _, prop := range tree.AllProperty() {
+		props = append(props, l.property(prop))
+	}
+	if len(l.errors) > 0 {
+		return nil, errors.Join(l.errors...)
+	}
+
+	values := make(map[string]interface{})
+	var errs []error
+	for _, prop := range props {
+		value, bad := literalValue(prop.Value)
+		if bad != nil {
+			errs = append(errs, c.diagnostic(bad.Position(), "variable values must be literals, got %s", describe(bad)))
+			continue
+		}
+		values[prop.Key] = value
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return values, nil
+}
+
+// literalValue evaluates the value of an input variable. Only literals are
+// allowed: strings without placeholders, numbers, bools and lists and maps
+// of them. Anything else, such as a call, would run as part of the program,
+// and is returned as the second result.
+func literalValue(expr ast.Expression) (interface{}, ast.Expression) {
+	switch e := expr.(type) {
+	case *ast.StringLiteral:
+		return e.Value, nil
+	case *ast.NumberLiteral:
+		return e.Value, nil
+	case *ast.BooleanLiteral:
+		return e.Value, nil
+	case *ast.Unary:
+		if num, ok := e.Operand.(*ast.NumberLiteral); ok && e.Op == "-" {
+			return -num.Value, nil
+		}
+	case *ast.ArrayLiteral:
+		list := make([]interface{}, 0, len(e.Elements))
+		for _, elem := range e.Elements {
+			value, bad := literalValue(elem)
+			if bad != nil {
+				return nil, bad
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case *ast.ObjectLiteral:
+		obj := make(map[string]interface{}, len(e.Properties))
+		for _, prop := range e.Properties {
+			value, bad := literalValue(prop.Value)
+			if bad != nil {
+				return nil, bad
+			}
+			obj[prop.Key] = value
+		}
+		return obj, nil
+	}
+
+	return nil, expr
+}
@@ -1 +1,141 @@
 package compiler
+
+import (
+	"strings"
+
+	"github.com/tblang/core/internal/ast"
+)
+
+// run executes the statements of a file in order. Top-level functions are
+// declared up front, and a top-level variable, variable block or import used
+// before its turn is executed on first use, so that names can be used before
+// the statement declaring them.
+func (w *ASTWalker) run(file *ast.File) {
+	w.pending = make(map[string][]ast.Statement)
+	for _, stmt := range file.Statements {
+		if fn, ok := stmt.(*ast.FunctionDeclaration); ok {
+			w.declareFunction(fn)
+		} else if name := declaredName(stmt); name != "" {
+			w.pending[name] = append(w.pending[name], stmt)
+		}
+	}
+
+	for _, stmt := range file.Statements {
+		if _, ok := stmt.(*ast.FunctionDeclaration); ok {
+			continue
+		}
+		if name := declaredName(stmt); name != "" {
+			if !w.takePending(name, stmt) {
+				continue
+			}
+			w.initialize(name, stmt)
+			continue
+		}
+		w.executeStatement(stmt)
+	}
+}
+
+// declaredName returns the name declared by a statement that can be used
+// before it, or "".
+func declaredName(stmt ast.Statement) string {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		return s.Name
+	case *ast.VariableBlock:
+		return s.Name
+	case *ast.ImportStatement:
+		return s.Alias
+	}
+	return ""
+}
+
+// takePending removes stmt from the pending declarations of name, reporting
+// false if it has already been executed.
+func (w *ASTWalker) takePending(name string, stmt ast.Statement) bool {
+	for i, pending := range w.pending[name] {
+		if pending == stmt {
+			w.pending[name] = append(w.pending[name][:i:i], w.pending[name][i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (w *ASTWalker) initialize(name string, stmt ast.Statement) {
+	w.initializing = append(w.initializing, name)
+	w.executeStatement(stmt)
+	w.initializing = w.initializing[:len(w.initializing)-1]
+}
+
+// lookup returns the value of the variable name used at node. A top-level
+// declaration of name that has not run yet is executed first, at the top
+// level of the file.
+func (w *ASTWalker) lookup(node ast.Node, name string) (interface{}, bool) {
+	if value, exists := w.scope.lookup(name); exists {
+		return value, true
+	}
+
+	for i, initializing := range w.initializing {
+		if initializing == name {
+			cycle := append(append([]string{}, w.initializing[i:]...), name)
+			w.addError(node, "declaration cycle: %s", strings.Join(cycle, " -> "))
+			return nil, true
+		}
+	}
+
+	if len(w.pending[name]) == 0 {
+		return nil, false
+	}
+	stmt := w.pending[name][0]
+	w.pending[name] = w.pending[name][1:]
+
+	savedScope, savedCallStack := w.scope, w.callStack
+	w.scope, w.callStack = w.globals, nil
+	w.initialize(name, stmt)
+	w.scope, w.callStack = savedScope, savedCallStack
+
+	value, _ := w.globals.lookup(name)
+	return value, true
+}
+
+// isDefined reports whether name is a variable in scope or a top-level
+// declaration that has not run yet.
+func (w *ASTWalker) isDefined(name string) bool {
+	if _, exists := w.scope.lookup(name); exists {
+		return true
+	}
+	return len(w.pending[name]) > 0
+}
+
+func (w *ASTWalker) executeStatement(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		w.declareVariable(s)
+	case *ast.BlockDeclaration:
+		w.declareBlock(s)
+	case *ast.VariableBlock:
+		w.declareVariableBlock(s)
+	case *ast.ForLoop:
+		w.executeForLoop(s)
+	case *ast.IfStatement:
+		w.executeIfStatement(s)
+	case *ast.FunctionDeclaration:
+		w.declareFunction(s)
+	case *ast.ReturnStatement:
+		w.returnValue = w.evaluateExpression(s.Value)
+		w.returning = true
+	case *ast.ImportStatement:
+		w.importModule(s)
+	case *ast.CallStatement:
+		w.evaluateCall(s.Call)
+	}
+}
+
+func (w *ASTWalker) executeStatements(statements []ast.Statement) {
+	for _, stmt := range statements {
+		if w.returning {
+			break
+		}
+		w.executeStatement(stmt)
+	}
+}
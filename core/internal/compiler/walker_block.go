@@ -2,33 +2,27 @@ package compiler
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/tblang/core/internal/ast"
-	"github.com/tblang/core/parser"
 )
 
-func (w *ASTWalker) EnterBlockDeclaration(ctx *parser.BlockDeclarationContext) {
-	blockType := ctx.IDENTIFIER().GetText()
-	blockName := strings.Trim(ctx.STRING_LITERAL().GetText(), `"'`)
+func (w *ASTWalker) declareBlock(block *ast.BlockDeclaration) {
+	properties := make(map[string]interface{})
 
-	if blockType == "cloud_vendor" {
-
-		properties := make(map[string]interface{})
-
-		for _, prop := range ctx.AllProperty() {
-			propCtx := prop.(*parser.PropertyContext)
-			key := propCtx.IDENTIFIER().GetText()
-			value := w.evaluateExpression(propCtx.Expression())
-			properties[key] = value
-		}
+	for _, prop := range block.Properties {
+		properties[prop.Key] = w.evaluateExpression(prop.Value)
+	}
 
+	switch block.Type {
+	case "output":
+		w.declareOutputBlock(block, properties)
+	case "cloud_vendor":
 		cloudVendor := &ast.CloudVendor{
-			Name:       blockName,
+			Name:       block.Name,
 			Properties: properties,
 		}
 
-		w.compiler.cloudVendors[blockName] = cloudVendor
-		fmt.Printf("Registered cloud vendor: %s\n", blockName)
+		w.compiler.cloudVendors[block.Name] = cloudVendor
+		fmt.Printf("Registered cloud vendor: %s\n", block.Name)
 	}
 }
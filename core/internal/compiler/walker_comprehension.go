@@ -0,0 +1,73 @@
+package compiler
+
+import (
+	"github.com/tblang/core/internal/ast"
+)
+
+func (w *ASTWalker) evaluateListComprehension(comp *ast.ListComprehension) interface{} {
+	result := make([]interface{}, 0)
+	ok := w.comprehend(comp.Vars, comp.Collection, comp.Condition, func() bool {
+		result = append(result, w.evaluateExpression(comp.Value))
+		return true
+	})
+	if !ok {
+		return nil
+	}
+	return result
+}
+
+func (w *ASTWalker) evaluateMapComprehension(comp *ast.MapComprehension) interface{} {
+	result := make(map[string]interface{})
+	ok := w.comprehend(comp.Vars, comp.Collection, comp.Condition, func() bool {
+		key := w.evaluateExpression(comp.Key)
+		name, ok := key.(string)
+		if !ok {
+			w.addError(comp.Key, "map comprehension key must be a string, got %s", typeName(key))
+			return false
+		}
+		if _, exists := result[name]; exists {
+			w.addError(comp.Key, "duplicate key %q in map comprehension", name)
+			return false
+		}
+		result[name] = w.evaluateExpression(comp.Value)
+		return true
+	})
+	if !ok {
+		return nil
+	}
+	return result
+}
+
+// comprehend calls emit once for every element of collection that satisfies
+// condition, with the loop variables bound in a scope of their own. It stops
+// and reports false as soon as emit or the condition fails.
+func (w *ASTWalker) comprehend(vars []string, collection, condition ast.Expression, emit func() bool) bool {
+	keys, values, ok := w.iterationItems(collection, len(vars) == 1)
+	if !ok {
+		return false
+	}
+
+	saved := w.scope
+	defer func() { w.scope = saved }()
+
+	for i := range keys {
+		w.scope = newScope(saved)
+		w.bindLoopVariables(vars, keys[i], values[i])
+
+		if condition != nil {
+			include, ok := w.evaluateCondition(condition)
+			if !ok {
+				return false
+			}
+			if !include {
+				continue
+			}
+		}
+
+		if !emit() {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,55 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/tblang/core/internal/ast"
+)
+
+func (w *ASTWalker) executeIfStatement(stmt *ast.IfStatement) {
+	cond, ok := w.evaluateCondition(stmt.Condition)
+	if !ok {
+		return
+	}
+
+	if cond {
+		fmt.Printf("Condition is true, executing if branch\n")
+		w.executeStatements(stmt.Then)
+		return
+	}
+
+	if stmt.ElseIf != nil {
+		w.executeIfStatement(stmt.ElseIf)
+		return
+	}
+	if stmt.Else == nil {
+		return
+	}
+
+	fmt.Printf("Condition is false, executing else branch\n")
+	w.executeStatements(stmt.Else)
+}
+
+func (w *ASTWalker) evaluateCondition(expr ast.Expression) (bool, bool) {
+	value := w.evaluateExpression(expr)
+	if isInvalid(value) || !w.checkKnown(expr, "condition", value) {
+		return false, false
+	}
+	cond, ok := value.(bool)
+	if !ok {
+		w.addError(expr, "condition must be bool, got %s", typeName(value))
+		return false, false
+	}
+	return cond, true
+}
+
+func (w *ASTWalker) evaluateConditionalExpression(e *ast.Conditional) interface{} {
+	cond, ok := w.evaluateCondition(e.Condition)
+	if !ok {
+		return invalid
+	}
+	if cond {
+		return w.evaluateExpression(e.Then)
+	}
+	return w.evaluateExpression(e.Else)
+}
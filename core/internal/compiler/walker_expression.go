@@ -1,104 +1,139 @@
 package compiler
 
 import (
-	"strconv"
-	"strings"
+	"math"
 
-	"github.com/tblang/core/parser"
+	"github.com/tblang/core/internal/ast"
 )
 
-func (w *ASTWalker) evaluateExpression(expr parser.IExpressionContext) interface{} {
-	if expr == nil {
-		return nil
-	}
-
+func (w *ASTWalker) evaluateExpression(expr ast.Expression) interface{} {
 	switch e := expr.(type) {
-	case *parser.ExpressionContext:
-		if e.DOT() != nil && e.Expression() != nil && e.IDENTIFIER() != nil {
-			obj := w.evaluateExpression(e.Expression())
-			propName := e.IDENTIFIER().GetText()
-
-			if objMap, ok := obj.(map[string]interface{}); ok {
-				if val, exists := objMap[propName]; exists {
-					return val
-				}
-			}
-			return nil
-		}
-
-		if e.STRING_LITERAL() != nil {
-			return strings.Trim(e.STRING_LITERAL().GetText(), `"'`)
-		}
-		if e.NUMBER() != nil {
-			if val, err := strconv.ParseFloat(e.NUMBER().GetText(), 64); err == nil {
-				return val
-			}
-		}
-		if e.BOOLEAN() != nil {
-			return e.BOOLEAN().GetText() == "true"
-		}
-		if e.IDENTIFIER() != nil {
-			varName := e.IDENTIFIER().GetText()
-			if w.variables != nil {
-				if val, exists := w.variables[varName]; exists {
-					return val
-				}
-			}
-			return varName
-		}
-		if e.ObjectLiteral() != nil {
-			return w.evaluateObjectLiteral(e.ObjectLiteral())
-		}
-		if e.ArrayLiteral() != nil {
-			return w.evaluateArrayLiteral(e.ArrayLiteral())
-		}
-		if e.FunctionCall() != nil {
-			return w.evaluateFunctionCall(e.FunctionCall())
-		}
-
-		if e.LPAREN() != nil && e.Expression() != nil {
-			return w.evaluateExpression(e.Expression())
+	case *ast.StringLiteral:
+		return e.Value
+	case *ast.Template:
+		return w.evaluateTemplate(e)
+	case *ast.NumberLiteral:
+		return e.Value
+	case *ast.BooleanLiteral:
+		return e.Value
+	case *ast.Identifier:
+		if value, exists := w.lookup(e, e.Name); exists {
+			return value
 		}
+		w.addError(e, "undefined variable %s%s", e.Name, didYouMean(e.Name, w.variableNames()))
+		return invalid
+	case *ast.ObjectLiteral:
+		return w.evaluateObjectLiteral(e)
+	case *ast.ArrayLiteral:
+		return w.evaluateArrayLiteral(e)
+	case *ast.ListComprehension:
+		return w.evaluateListComprehension(e)
+	case *ast.MapComprehension:
+		return w.evaluateMapComprehension(e)
+	case *ast.Selector:
+		return w.evaluateSelector(e)
+	case *ast.Index:
+		return w.evaluateIndexExpression(e)
+	case *ast.Unary:
+		return w.evaluateUnaryExpression(e)
+	case *ast.Binary:
+		return w.evaluateBinaryExpression(e)
+	case *ast.Conditional:
+		return w.evaluateConditionalExpression(e)
+	case *ast.Call:
+		return w.evaluateCall(e)
 	}
 
 	return nil
 }
 
-func (w *ASTWalker) evaluateObjectLiteral(obj parser.IObjectLiteralContext) map[string]interface{} {
+func (w *ASTWalker) evaluateObjectLiteral(obj *ast.ObjectLiteral) map[string]interface{} {
 	result := make(map[string]interface{})
 
-	if objCtx, ok := obj.(*parser.ObjectLiteralContext); ok {
-		for _, prop := range objCtx.AllObjectProperty() {
-			key := prop.IDENTIFIER().GetText()
-			value := w.evaluateExpression(prop.Expression())
-			result[key] = value
-		}
+	for _, prop := range obj.Properties {
+		result[prop.Key] = w.evaluateExpression(prop.Value)
 	}
 
 	return result
 }
 
-func (w *ASTWalker) evaluateArrayLiteral(arr parser.IArrayLiteralContext) []interface{} {
+func (w *ASTWalker) evaluateArrayLiteral(arr *ast.ArrayLiteral) []interface{} {
 	var result []interface{}
 
-	if arrCtx, ok := arr.(*parser.ArrayLiteralContext); ok {
-		for _, expr := range arrCtx.AllExpression() {
-			result = append(result, w.evaluateExpression(expr))
-		}
+	for _, elem := range arr.Elements {
+		result = append(result, w.evaluateExpression(elem))
 	}
 
 	return result
 }
 
-func (w *ASTWalker) evaluateFunctionCall(funcCall parser.IFunctionCallContext) interface{} {
-	if funcCtx, ok := funcCall.(*parser.FunctionCallContext); ok {
-		funcName := funcCtx.IDENTIFIER().GetText()
-		args := w.extractArguments(funcCtx.ArgumentList())
+// evaluateSelector returns the property of a map, or a reference to an
+// attribute of a resource when the object is a resource name.
+func (w *ASTWalker) evaluateSelector(e *ast.Selector) interface{} {
+	obj := w.evaluateExpression(e.Object)
+	if isInvalid(obj) {
+		return invalid
+	}
 
-		if w.isResourceType(funcName) && len(args) > 0 {
-			return w.extractStringValue(args[0])
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		if val, exists := o[e.Name]; exists {
+			return val
+		}
+		if alias, ok := w.moduleAlias(e.Object, o); ok {
+			w.addError(e, "module %s has no output %q%s", alias, e.Name, didYouMean(e.Name, sortedKeys(o)))
+			return invalid
+		}
+		w.addError(e, "key %q not found in map", e.Name)
+		return invalid
+	case string:
+		if _, exists := w.compiler.resources[o]; exists {
+			return &ast.Reference{Resource: o, Attribute: e.Name}
 		}
 	}
 
-	return nil
+	w.addError(e, "cannot select %s from %s", e.Name, typeName(obj))
+	return invalid
+}
+
+func (w *ASTWalker) evaluateIndexExpression(e *ast.Index) interface{} {
+	collection := w.evaluateExpression(e.Collection)
+	index := w.evaluateExpression(e.Key)
+	if isInvalid(collection, index) {
+		return invalid
+	}
+
+	switch c := collection.(type) {
+	case []interface{}:
+		num, ok := index.(float64)
+		if !ok {
+			w.addError(e.Key, "list index must be a number, got %s", typeName(index))
+			return invalid
+		}
+		if num != math.Trunc(num) {
+			w.addError(e.Key, "list index must be a whole number, got %v", num)
+			return invalid
+		}
+		i := int(num)
+		if i < 0 || i >= len(c) {
+			w.addError(e.Key, "index %d out of range for list of length %d", i, len(c))
+			return invalid
+		}
+		return c[i]
+	case map[string]interface{}:
+		key, ok := index.(string)
+		if !ok {
+			w.addError(e.Key, "map key must be a string, got %s", typeName(index))
+			return invalid
+		}
+		val, exists := c[key]
+		if !exists {
+			w.addError(e.Key, "key %q not found in map", key)
+			return invalid
+		}
+		return val
+	}
+
+	w.addError(e, "cannot index into %s", typeName(collection))
+	return invalid
 }
@@ -1,68 +1,43 @@
 package compiler
 
 import (
-	"fmt"
-
 	"github.com/tblang/core/internal/ast"
-	"github.com/tblang/core/parser"
 )
 
-func (w *ASTWalker) EnterFunctionCall(ctx *parser.FunctionCallContext) {
-
-	if !w.inManualExecution && w.processedContexts != nil && w.processedContexts[ctx] {
-		return
+func (w *ASTWalker) evaluateCall(call *ast.Call) interface{} {
+	if isResourceType(call.Name) || isDataSourceType(call.Name) {
+		return w.declareResource(call, call.Name)
 	}
 
-	funcName := ctx.IDENTIFIER().GetText()
-
-	if funcName == "print" {
-		args := w.extractArguments(ctx.ArgumentList())
-		w.handlePrint(args)
-		return
+	args := w.evaluateArguments(call.Args)
+	if isInvalid(args...) {
+		return invalid
 	}
 
-	if funcName == "output" {
-		args := w.extractArguments(ctx.ArgumentList())
+	switch call.Name {
+	case "print":
+		w.handlePrint(args)
+		return nil
+	case "output":
 		w.handleOutput(args)
-		return
+		return nil
 	}
 
-	if w.isDataSourceType(funcName) {
-		args := w.extractArguments(ctx.ArgumentList())
-
-		if len(args) >= 2 {
-			dataSourceName := w.extractStringValue(args[0])
-			dataSourceConfig := args[1]
-
-			dataSource := &ast.Resource{
-				Name:       dataSourceName,
-				Type:       funcName,
-				Properties: w.convertToMap(dataSourceConfig),
-				DependsOn:  []string{},
-			}
-
-			w.compiler.resources[dataSourceName] = dataSource
-			fmt.Printf("Created data source: %s (%s)\n", dataSourceName, funcName)
-		}
-		return
+	if fn := w.scope.function(call.Name); fn != nil {
+		return w.callUserFunction(call, fn, args)
 	}
 
-	if w.isResourceType(funcName) {
-		args := w.extractArguments(ctx.ArgumentList())
-
-		if len(args) >= 2 {
-			resourceName := w.extractStringValue(args[0])
-			resourceConfig := args[1]
+	if isBuiltin(call.Name) {
+		return w.callBuiltin(call, call.Name, args)
+	}
 
-			resource := &ast.Resource{
-				Name:       resourceName,
-				Type:       funcName,
-				Properties: w.convertToMap(resourceConfig),
-				DependsOn:  []string{},
-			}
+	return nil
+}
 
-			w.compiler.resources[resourceName] = resource
-			fmt.Printf("Created resource: %s (%s)\n", resourceName, funcName)
-		}
+func (w *ASTWalker) evaluateArguments(exprs []ast.Expression) []interface{} {
+	args := make([]interface{}, 0, len(exprs))
+	for _, expr := range exprs {
+		args = append(args, w.evaluateExpression(expr))
 	}
+	return args
 }
@@ -2,12 +2,39 @@ package compiler
 
 import (
 	"fmt"
+	"strconv"
 
-	"github.com/tblang/core/parser"
+	"github.com/tblang/core/internal/ast"
 )
 
-func (w *ASTWalker) isResourceType(funcName string) bool {
-	resourceTypes := []string{"vpc", "subnet", "security_group", "ec2", "internet_gateway", "route_table", "eip", "nat_gateway"}
+var resourceTypes = []string{"vpc", "subnet", "security_group", "ec2", "internet_gateway", "route_table", "eip", "nat_gateway"}
+
+var dataSourceTypes = []string{"data_ami", "data_vpc", "data_subnet", "data_availability_zones", "data_caller_identity"}
+
+// idAttributes names the attribute holding the ID of each resource and data
+// source type, which a bare reference to one, as in vpc_id: main_vpc, stands
+// for.
+var idAttributes = map[string]string{
+	"vpc":              "vpc_id",
+	"subnet":           "subnet_id",
+	"security_group":   "group_id",
+	"ec2":              "instance_id",
+	"internet_gateway": "gateway_id",
+	"route_table":      "route_table_id",
+	"eip":              "allocation_id",
+	"nat_gateway":      "nat_gateway_id",
+	"data_ami":         "ami_id",
+	"data_vpc":         "vpc_id",
+	"data_subnet":      "subnet_id",
+}
+
+// ResourceTypes returns the function names that declare a resource or data
+// source.
+func ResourceTypes() []string {
+	return append(append([]string{}, resourceTypes...), dataSourceTypes...)
+}
+
+func isResourceType(funcName string) bool {
 	for _, rt := range resourceTypes {
 		if rt == funcName {
 			return true
@@ -16,8 +43,7 @@ func (w *ASTWalker) isResourceType(funcName string) bool {
 	return false
 }
 
-func (w *ASTWalker) isDataSourceType(funcName string) bool {
-	dataSourceTypes := []string{"data_ami", "data_vpc", "data_subnet", "data_availability_zones", "data_caller_identity"}
+func isDataSourceType(funcName string) bool {
 	for _, dt := range dataSourceTypes {
 		if dt == funcName {
 			return true
@@ -26,24 +52,44 @@ func (w *ASTWalker) isDataSourceType(funcName string) bool {
 	return false
 }
 
-func (w *ASTWalker) extractArguments(argList parser.IArgumentListContext) []interface{} {
-	if argList == nil {
-		return []interface{}{}
+func (w *ASTWalker) extractStringValue(value interface{}) string {
+	if str, ok := value.(string); ok {
+		return str
 	}
+	return fmt.Sprintf("%v", value)
+}
 
-	var args []interface{}
-	for _, expr := range argList.(*parser.ArgumentListContext).AllExpression() {
-		val := w.evaluateExpression(expr)
-		args = append(args, val)
+// findReference returns the first reference in value, in sorted key order,
+// or nil if it holds none. A reference is only known once its resource has
+// been applied, so it cannot be used where the value is needed now.
+func findReference(value interface{}) *ast.Reference {
+	switch v := value.(type) {
+	case *ast.Reference:
+		return v
+	case []interface{}:
+		for _, item := range v {
+			if ref := findReference(item); ref != nil {
+				return ref
+			}
+		}
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			if ref := findReference(v[key]); ref != nil {
+				return ref
+			}
+		}
 	}
-	return args
+	return nil
 }
 
-func (w *ASTWalker) extractStringValue(value interface{}) string {
-	if str, ok := value.(string); ok {
-		return str
+// checkKnown reports an error and returns false if value holds a reference,
+// as it is used where its value must be known now. what says where.
+func (w *ASTWalker) checkKnown(node ast.Node, what string, value interface{}) bool {
+	if ref := findReference(value); ref != nil {
+		w.addError(node, "%s refers to %s, which is only known after apply", what, ref)
+		return false
 	}
-	return fmt.Sprintf("%v", value)
+	return true
 }
 
 func (w *ASTWalker) convertToMap(value interface{}) map[string]interface{} {
@@ -52,3 +98,65 @@ func (w *ASTWalker) convertToMap(value interface{}) map[string]interface{} {
 	}
 	return make(map[string]interface{})
 }
+
+func (w *ASTWalker) addError(node ast.Node, format string, args ...interface{}) {
+	w.errors = append(w.errors, w.compiler.diagnostic(node.Position(), format, args...))
+}
+
+// invalidValue is bound to a variable whose declaration failed, so that its
+// uses are not also reported as undefined, or whose value is not known, as
+// with AllowUnsetVariables. Anything evaluated from it is invalid in turn,
+// without further errors.
+type invalidValue struct{}
+
+var invalid = invalidValue{}
+
+func isInvalid(values ...interface{}) bool {
+	for _, value := range values {
+		if _, ok := value.(invalidValue); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "map"
+	case *ast.Reference:
+		return "reference"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// describe names expr in an error message: variables and attributes by
+// their path, literals by their value.
+func describe(expr ast.Expression) string {
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		return e.Name
+	case *ast.Selector:
+		return describe(e.Object) + "." + e.Name
+	case *ast.NumberLiteral:
+		return strconv.FormatFloat(e.Value, 'g', -1, 64)
+	case *ast.BooleanLiteral:
+		return strconv.FormatBool(e.Value)
+	case *ast.StringLiteral:
+		return strconv.Quote(e.Value)
+	case *ast.Call:
+		return e.Name + "(...)"
+	}
+	return "value"
+}
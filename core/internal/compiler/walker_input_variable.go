@@ -0,0 +1,125 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/tblang/core/internal/ast"
+)
+
+var variableTypes = map[string]bool{
+	"any":    true,
+	"string": true,
+	"number": true,
+	"bool":   true,
+	"list":   true,
+	"map":    true,
+}
+
+// declareVariableBlock binds a variable block to its input value, falling back
+// to the default. The variable is bound before validate is evaluated since
+// validate refers to the variable itself.
+func (w *ASTWalker) declareVariableBlock(block *ast.VariableBlock) {
+	name := block.Name
+
+	if w.declaredInputs == nil {
+		w.declaredInputs = make(map[string]bool)
+	}
+	if w.declaredInputs[name] {
+		w.addError(block, "variable %s is already declared", name)
+		return
+	}
+	w.declaredInputs[name] = true
+
+	// Until it has a valid value the variable is bound to invalid.
+	w.scope.variables[name] = invalid
+
+	value, provided := w.inputs[name]
+	if raw, ok := value.(rawInput); ok {
+		converted, err := convertRawInput(string(raw), block.Type)
+		if err != nil {
+			w.addError(block, "invalid value for variable %s: %v", name, err)
+			return
+		}
+		value = converted
+	}
+
+	if !provided {
+		if block.Default == nil {
+			if w.modulePrefix == "" && w.compiler.allowUnset {
+				return
+			}
+			if w.modulePrefix == "" {
+				w.addError(block, "no value given for required variable %s (use -var, -var-file or %s%s)", name, envVariablePrefix, name)
+			} else {
+				w.addError(block, "no value given for required variable %s", name)
+			}
+			return
+		}
+		errCount := len(w.errors)
+		value = w.evaluateExpression(block.Default)
+		if len(w.errors) > errCount || isInvalid(value) {
+			return
+		}
+	}
+
+	if block.Type != "any" && typeName(value) != block.Type {
+		w.addError(block, "variable %s expects a %s value, got %s", name, block.Type, typeName(value))
+		return
+	}
+
+	w.scope.variables[name] = value
+	w.registerVariable(name, value)
+
+	if block.Validate != nil {
+		valid, ok := w.evaluateCondition(block.Validate)
+		if ok && !valid {
+			msg := fmt.Sprintf("invalid value for variable %s", name)
+			if block.ErrorMessage != nil {
+				msg += ": " + w.extractStringValue(w.evaluateExpression(block.ErrorMessage))
+			}
+			w.addError(block.Validate, "%s", msg)
+			w.scope.variables[name] = invalid
+			return
+		}
+	}
+
+	fmt.Printf("Declared variable: %s\n", name)
+}
+
+func convertRawInput(raw, varType string) (interface{}, error) {
+	switch varType {
+	case "any", "string":
+		return raw, nil
+	case "number":
+		num, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", raw)
+		}
+		return num, nil
+	case "bool":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a bool", raw)
+		}
+		return b, nil
+	}
+
+	expr, err := parseExpressionSource(raw)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse %q as a %s: %v", raw, varType, err)
+	}
+
+	l := &lowerer{}
+	lowered := l.expression(expr)
+	if len(l.errors) > 0 {
+		return nil, errors.New(l.errors[0].(*Diagnostic).Message)
+	}
+
+	value, bad := literalValue(lowered)
+	if bad != nil {
+		return nil, fmt.Errorf("variable values must be literals, got %s", describe(bad))
+	}
+	return value, nil
+}
@@ -0,0 +1,74 @@
+package compiler
+
+import (
+	"strings"
+
+	"github.com/tblang/core/internal/ast"
+)
+
+func (w *ASTWalker) evaluateTemplate(tmpl *ast.Template) interface{} {
+	var sb strings.Builder
+	for _, part := range tmpl.Parts {
+		if part.Expr == nil {
+			sb.WriteString(part.Text)
+			continue
+		}
+
+		value, ok := w.evaluatePlaceholder(tmpl, part)
+		if !ok {
+			return invalid
+		}
+		sb.WriteString(w.extractStringValue(value))
+	}
+
+	return sb.String()
+}
+
+func (w *ASTWalker) evaluatePlaceholder(tmpl *ast.Template, part ast.TemplatePart) (interface{}, bool) {
+	if name := w.unresolvedIdentifier(part.Expr); name != "" {
+		w.addError(tmpl, "unresolved reference %q in placeholder ${%s}", name, part.Source)
+		return nil, false
+	}
+
+	errCount := len(w.errors)
+	value := w.evaluateExpression(part.Expr)
+	if len(w.errors) > errCount || isInvalid(value) {
+		return value, false
+	}
+	if value == nil {
+		w.addError(tmpl, "placeholder ${%s} evaluated to null", part.Source)
+		return nil, false
+	}
+	if ref, ok := value.(*ast.Reference); ok {
+		w.addError(tmpl, "placeholder ${%s} refers to %s, which is only known after apply", part.Source, ref)
+		return nil, false
+	}
+
+	return value, true
+}
+
+// unresolvedIdentifier returns the first identifier in expr that is not a
+// known variable. Only the collection of a comprehension is checked, since
+// its body refers to the comprehension's own loop variables.
+func (w *ASTWalker) unresolvedIdentifier(expr ast.Expression) string {
+	name := ""
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if name != "" {
+			return false
+		}
+		switch n := node.(type) {
+		case *ast.ListComprehension:
+			name = w.unresolvedIdentifier(n.Collection)
+			return false
+		case *ast.MapComprehension:
+			name = w.unresolvedIdentifier(n.Collection)
+			return false
+		case *ast.Identifier:
+			if !w.isDefined(n.Name) {
+				name = n.Name
+			}
+		}
+		return true
+	})
+	return name
+}
@@ -3,114 +3,77 @@ package compiler
 import (
 	"fmt"
 
-	"github.com/tblang/core/parser"
+	"github.com/tblang/core/internal/ast"
 )
 
-func (w *ASTWalker) EnterForLoop(ctx *parser.ForLoopContext) {
-	if w.processedContexts != nil && w.processedContexts[ctx] {
-		return
-	}
-
-	if w.processedContexts == nil {
-		w.processedContexts = make(map[interface{}]bool)
+func (w *ASTWalker) executeForLoop(loop *ast.ForLoop) {
+	if len(loop.Vars) == 1 {
+		fmt.Printf("Processing for loop: %s in collection\n", loop.Vars[0])
+	} else {
+		fmt.Printf("Processing for loop: %s, %s in collection\n", loop.Vars[0], loop.Vars[1])
 	}
-	w.processedContexts[ctx] = true
 
-	for _, stmt := range ctx.AllStatement() {
-		w.markStatementAsProcessed(stmt)
+	keys, values, ok := w.iterationItems(loop.Collection, len(loop.Vars) == 1)
+	if !ok {
+		return
 	}
 
-	iterator := ctx.IDENTIFIER().GetText()
-	collectionExpr := ctx.Expression()
-
-	fmt.Printf("Processing for loop: %s in collection\n", iterator)
+	saved := w.scope
 
-	var collectionName string
-	if collectionExpr.IDENTIFIER() != nil {
-		collectionName = collectionExpr.IDENTIFIER().GetText()
-	}
+	for i := range keys {
+		w.scope = newScope(saved)
+		w.bindLoopVariables(loop.Vars, keys[i], values[i])
 
-	var items []interface{}
-	if w.compiler.variables != nil {
-		if val, exists := w.compiler.variables[collectionName]; exists {
-			if arr, ok := val.Value.([]interface{}); ok {
-				items = arr
-				fmt.Printf("  Found collection '%s' with %d items\n", collectionName, len(items))
-			} else {
-				fmt.Printf("  Collection '%s' is not an array: %T\n", collectionName, val.Value)
-			}
-		} else {
-			fmt.Printf("  Collection '%s' not found in variables\n", collectionName)
+		w.executeStatements(loop.Body)
+		if w.returning {
+			break
 		}
-	} else {
-		fmt.Printf("  No variables available\n")
 	}
 
-	savedVars := w.variables
-
-	statements := ctx.AllStatement()
-
-	for _, item := range items {
-
-		if w.variables == nil {
-			w.variables = make(map[string]interface{})
-		} else {
-
-			newVars := make(map[string]interface{})
-			for k, v := range savedVars {
-				newVars[k] = v
-			}
-			w.variables = newVars
-		}
-
-		w.variables[iterator] = item
+	w.scope = saved
+}
 
-		w.inManualExecution = true
-		for _, stmt := range statements {
-			w.executeStatement(stmt)
-		}
-		w.inManualExecution = false
+// bindLoopVariables binds a single loop variable to the value, or a pair of
+// them to the key and the value.
+func (w *ASTWalker) bindLoopVariables(vars []string, key, value interface{}) {
+	if len(vars) == 1 {
+		w.scope.variables[vars[0]] = value
+		return
 	}
-
-	w.variables = savedVars
+	w.scope.variables[vars[0]] = key
+	w.scope.variables[vars[1]] = value
 }
 
-func (w *ASTWalker) markStatementAsProcessed(stmt parser.IStatementContext) {
-	stmtCtx := stmt.(*parser.StatementContext)
-
-	if stmtCtx.VariableDeclaration() != nil {
-		varDecl := stmtCtx.VariableDeclaration().(*parser.VariableDeclarationContext)
-		w.processedContexts[varDecl] = true
-
-		expr := varDecl.Expression()
-		if exprCtx, ok := expr.(*parser.ExpressionContext); ok {
-			if exprCtx.FunctionCall() != nil {
-				w.processedContexts[exprCtx.FunctionCall()] = true
+// iterationItems evaluates a loop collection. Lists yield their indexes and
+// elements, maps their keys in sorted order and values. With a single loop
+// variable the values of a map are replaced by its keys.
+func (w *ASTWalker) iterationItems(expr ast.Expression, single bool) ([]interface{}, []interface{}, bool) {
+	switch c := w.evaluateExpression(expr).(type) {
+	case []interface{}:
+		keys := make([]interface{}, len(c))
+		for i := range c {
+			keys[i] = float64(i)
+		}
+		return keys, c, true
+	case map[string]interface{}:
+		sorted := sortedKeys(c)
+		keys := make([]interface{}, len(sorted))
+		values := make([]interface{}, len(sorted))
+		for i, key := range sorted {
+			keys[i] = key
+			values[i] = c[key]
+			if single {
+				values[i] = key
 			}
 		}
-	} else if stmtCtx.FunctionCall() != nil {
-		w.processedContexts[stmtCtx.FunctionCall()] = true
-	} else if stmtCtx.BlockDeclaration() != nil {
-		w.processedContexts[stmtCtx.BlockDeclaration()] = true
-	} else if stmtCtx.ForLoop() != nil {
-		w.processedContexts[stmtCtx.ForLoop()] = true
-	}
-}
-
-func (w *ASTWalker) executeStatement(stmt parser.IStatementContext) {
-	stmtCtx := stmt.(*parser.StatementContext)
-
-	if stmtCtx.VariableDeclaration() != nil {
-		ctx := stmtCtx.VariableDeclaration().(*parser.VariableDeclarationContext)
-		w.EnterVariableDeclaration(ctx)
-	} else if stmtCtx.FunctionCall() != nil {
-		ctx := stmtCtx.FunctionCall().(*parser.FunctionCallContext)
-		w.EnterFunctionCall(ctx)
-	} else if stmtCtx.BlockDeclaration() != nil {
-		ctx := stmtCtx.BlockDeclaration().(*parser.BlockDeclarationContext)
-		w.EnterBlockDeclaration(ctx)
-	} else if stmtCtx.ForLoop() != nil {
-		ctx := stmtCtx.ForLoop().(*parser.ForLoopContext)
-		w.EnterForLoop(ctx)
+		return keys, values, true
+	case *ast.Reference:
+		w.addError(expr, "cannot iterate over %s, which is only known after apply", c)
+		return nil, nil, false
+	case invalidValue:
+		return nil, nil, false
+	default:
+		w.addError(expr, "%s is not iterable, got %s", describe(expr), typeName(c))
+		return nil, nil, false
 	}
 }
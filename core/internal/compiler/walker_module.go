@@ -0,0 +1,108 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/tblang/core/internal/ast"
+)
+
+func (w *ASTWalker) importModule(stmt *ast.ImportStatement) {
+	path := w.resolvePath(stmt.Path)
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		absPath = path
+	}
+	for i, imported := range w.importStack {
+		if imported == absPath {
+			cycle := append(append([]string{}, w.importStack[i:]...), absPath)
+			w.addError(stmt, "import cycle: %s", strings.Join(cycle, " -> "))
+			return
+		}
+	}
+
+	inputs := make(map[string]interface{})
+	if stmt.Inputs != nil {
+		inputs = w.evaluateObjectLiteral(stmt.Inputs)
+	}
+
+	file, err := w.compiler.loadFile(path)
+	if err != nil {
+		var syntaxErr *Diagnostic
+		if errors.As(err, &syntaxErr) {
+			w.errors = append(w.errors, err)
+			w.addError(stmt, "cannot import %s: it has syntax errors", stmt.Path)
+			return
+		}
+		w.addError(stmt, "cannot import %s: %v", stmt.Path, err)
+		return
+	}
+	if errs := w.compiler.check(file); len(errs) > 0 {
+		w.errors = append(w.errors, errs...)
+		return
+	}
+
+	fmt.Printf("Importing module %s from %s\n", stmt.Alias, stmt.Path)
+
+	module := w.compiler.newWalker(path, w.modulePrefix+stmt.Alias+".", inputs, w.importStack)
+	module.run(file)
+	module.checkDependsOn()
+
+	w.errors = append(w.errors, module.errors...)
+	for name := range inputs {
+		if !module.declaredInputs[name] {
+			w.addError(stmt, "module %s has no variable %q", stmt.Alias, name)
+		}
+	}
+
+	outputs := module.outputs
+	if outputs == nil {
+		outputs = make(map[string]interface{})
+	}
+
+	w.scope.variables[stmt.Alias] = outputs
+	if w.modules == nil {
+		w.modules = make(map[string]map[string]interface{})
+	}
+	w.modules[stmt.Alias] = outputs
+}
+
+// moduleAlias returns the alias of the module whose outputs expr evaluated
+// to, or false if it is not the outputs of an imported module.
+func (w *ASTWalker) moduleAlias(expr ast.Expression, value map[string]interface{}) (string, bool) {
+	id, ok := expr.(*ast.Identifier)
+	if !ok {
+		return "", false
+	}
+	outputs, exists := w.modules[id.Name]
+	if !exists || reflect.ValueOf(outputs).Pointer() != reflect.ValueOf(value).Pointer() {
+		return "", false
+	}
+	return id.Name, true
+}
+
+func (w *ASTWalker) declareOutputBlock(block *ast.BlockDeclaration, properties map[string]interface{}) {
+	value, exists := properties["value"]
+	if !exists {
+		w.addError(block, "output %s is missing a value", block.Name)
+		return
+	}
+
+	if w.modulePrefix == "" {
+		w.handleOutput([]interface{}{block.Name, value})
+		return
+	}
+
+	if w.outputs == nil {
+		w.outputs = make(map[string]interface{})
+	}
+	w.outputs[block.Name] = value
+}
+
+func (w *ASTWalker) qualifiedName(name string) string {
+	return w.modulePrefix + name
+}
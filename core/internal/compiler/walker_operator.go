@@ -0,0 +1,144 @@
+package compiler
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/tblang/core/internal/ast"
+)
+
+func (w *ASTWalker) evaluateUnaryExpression(e *ast.Unary) interface{} {
+	operand := w.evaluateExpression(e.Operand)
+	if isInvalid(operand) || !w.checkKnown(e, "operand of "+e.Op, operand) {
+		return invalid
+	}
+
+	if e.Op == "-" {
+		num, ok := operand.(float64)
+		if !ok {
+			w.addError(e, "operator - cannot be applied to %s", typeName(operand))
+			return invalid
+		}
+		return -num
+	}
+
+	b, ok := operand.(bool)
+	if !ok {
+		w.addError(e, "operator ! cannot be applied to %s", typeName(operand))
+		return invalid
+	}
+	return !b
+}
+
+func (w *ASTWalker) evaluateBinaryExpression(e *ast.Binary) interface{} {
+	op := e.Op
+
+	left := w.evaluateExpression(e.Left)
+	if isInvalid(left) {
+		return invalid
+	}
+
+	if op == "&&" || op == "||" {
+		if !w.checkKnown(e, "operand of "+op, left) {
+			return invalid
+		}
+		lb, ok := left.(bool)
+		if !ok {
+			w.addError(e, "operator %s requires bool operands, got %s", op, typeName(left))
+			return invalid
+		}
+		if (op == "&&" && !lb) || (op == "||" && lb) {
+			return lb
+		}
+
+		right := w.evaluateExpression(e.Right)
+		if isInvalid(right) || !w.checkKnown(e, "operand of "+op, right) {
+			return invalid
+		}
+		rb, ok := right.(bool)
+		if !ok {
+			w.addError(e, "operator %s requires bool operands, got %s", op, typeName(right))
+			return invalid
+		}
+		return rb
+	}
+
+	right := w.evaluateExpression(e.Right)
+	if isInvalid(right) {
+		return invalid
+	}
+
+	what := fmt.Sprintf("operand of %s", op)
+	if !w.checkKnown(e, what, left) || !w.checkKnown(e, what, right) {
+		return invalid
+	}
+
+	switch op {
+	case "==":
+		return reflect.DeepEqual(left, right)
+	case "!=":
+		return !reflect.DeepEqual(left, right)
+	}
+
+	if op == "+" {
+		if ls, ok := left.(string); ok {
+			if rs, ok := right.(string); ok {
+				return ls + rs
+			}
+		}
+	}
+
+	if op == "<" || op == "<=" || op == ">" || op == ">=" {
+		if ls, ok := left.(string); ok {
+			if rs, ok := right.(string); ok {
+				return compareOrdered(op, ls, rs)
+			}
+		}
+	}
+
+	ln, lok := left.(float64)
+	rn, rok := right.(float64)
+	if !lok || !rok {
+		w.addError(e, "operator %s cannot be applied to %s and %s", op, typeName(left), typeName(right))
+		return invalid
+	}
+
+	switch op {
+	case "+":
+		return ln + rn
+	case "-":
+		return ln - rn
+	case "*":
+		return ln * rn
+	case "/":
+		if rn == 0 {
+			w.addError(e, "division by zero")
+			return invalid
+		}
+		return ln / rn
+	case "%":
+		if rn == 0 {
+			w.addError(e, "division by zero")
+			return invalid
+		}
+		return math.Mod(ln, rn)
+	case "<", "<=", ">", ">=":
+		return compareOrdered(op, ln, rn)
+	}
+
+	return nil
+}
+
+func compareOrdered[T float64 | string](op string, left, right T) bool {
+	switch op {
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case ">":
+		return left > right
+	default:
+		return left >= right
+	}
+}
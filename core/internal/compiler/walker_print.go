@@ -2,6 +2,8 @@ package compiler
 
 import (
 	"fmt"
+
+	"github.com/tblang/core/internal/ast"
 )
 
 func (w *ASTWalker) handlePrint(args []interface{}) {
@@ -34,17 +36,17 @@ func (w *ASTWalker) handleOutput(args []interface{}) {
 func (w *ASTWalker) printValue(value interface{}) {
 	switch v := value.(type) {
 	case string:
-		if w.variables != nil {
-			if resolved, exists := w.variables[v]; exists {
-				w.printValue(resolved)
-				return
-			}
+		if resolved, exists := w.scope.lookup(v); exists {
+			w.printValue(resolved)
+			return
 		}
 		fmt.Printf("\033[32m\"%s\"\033[0m", v)
 	case float64:
 		fmt.Printf("\033[33m%v\033[0m", v)
 	case bool:
 		fmt.Printf("\033[35m%v\033[0m", v)
+	case *ast.Reference:
+		fmt.Printf("\033[90m%s (known after apply)\033[0m", v)
 	case map[string]interface{}:
 		fmt.Print("{\n")
 		for key, val := range v {
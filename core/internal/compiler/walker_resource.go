@@ -0,0 +1,353 @@
+package compiler
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tblang/core/internal/ast"
+)
+
+type dependsOnTarget struct {
+	call     *ast.Call
+	resource *ast.Resource
+	index    int
+}
+
+// declareResource registers a resource or data source call and returns its
+// qualified name. With count or for_each it registers one instance per
+// element and returns a list or map of the instance addresses instead.
+func (w *ASTWalker) declareResource(call *ast.Call, resourceType string) interface{} {
+	if len(call.Args) < 2 {
+		return nil
+	}
+
+	nameValue := w.evaluateExpression(call.Args[0])
+	if !isInvalid(nameValue) && !w.checkKnown(call.Args[0], "resource name", nameValue) {
+		nameValue = invalid
+	}
+	if isInvalid(nameValue) {
+		// Give the resource a name of its own so that its configuration
+		// is still checked.
+		w.compiler.unknownNames++
+		nameValue = fmt.Sprintf("%s#%d", resourceType, w.compiler.unknownNames)
+	}
+	name := w.qualifiedName(w.extractStringValue(nameValue))
+	config := call.Args[1]
+
+	countExpr := metaArgument(config, "count")
+	forEachExpr := metaArgument(config, "for_each")
+
+	switch {
+	case countExpr != nil && forEachExpr != nil:
+		w.addError(call, "resource %s cannot use both count and for_each", name)
+		return nil
+
+	case countExpr != nil:
+		value := w.evaluateExpression(countExpr)
+		if isInvalid(value) {
+			return nil
+		}
+		count, ok := value.(float64)
+		if !ok || count < 0 || count != math.Trunc(count) {
+			w.addError(countExpr, "count for %s must be a whole number of at least 0", name)
+			return nil
+		}
+
+		addresses := make([]interface{}, 0, int(count))
+		for i := 0; i < int(count); i++ {
+			address := fmt.Sprintf("%s[%d]", name, i)
+			w.withBinding("count", map[string]interface{}{"index": float64(i)}, func() {
+				w.createResource(call, resourceType, address, config)
+			})
+			addresses = append(addresses, address)
+		}
+		return addresses
+
+	case forEachExpr != nil:
+		elements, ok := w.forEachElements(forEachExpr, name)
+		if !ok {
+			return nil
+		}
+
+		addresses := make(map[string]interface{}, len(elements))
+		for _, key := range sortedKeys(elements) {
+			address := fmt.Sprintf("%s[%q]", name, key)
+			each := map[string]interface{}{"key": key, "value": elements[key]}
+			w.withBinding("each", each, func() {
+				w.createResource(call, resourceType, address, config)
+			})
+			addresses[key] = address
+		}
+		return addresses
+	}
+
+	w.createResource(call, resourceType, name, config)
+	return name
+}
+
+// forEachElements accepts a map, or a list of unique strings which are used
+// as both key and value.
+func (w *ASTWalker) forEachElements(expr ast.Expression, name string) (map[string]interface{}, bool) {
+	switch v := w.evaluateExpression(expr).(type) {
+	case invalidValue:
+		return nil, false
+	case map[string]interface{}:
+		return v, true
+	case []interface{}:
+		elements := make(map[string]interface{}, len(v))
+		for _, item := range v {
+			key, ok := item.(string)
+			if !ok {
+				w.addError(expr, "for_each list for %s must contain strings, got %s", name, typeName(item))
+				return nil, false
+			}
+			if _, exists := elements[key]; exists {
+				w.addError(expr, "for_each list for %s contains %q more than once", name, key)
+				return nil, false
+			}
+			elements[key] = key
+		}
+		return elements, true
+	default:
+		w.addError(expr, "for_each for %s must be a map or a list of strings, got %s", name, typeName(v))
+		return nil, false
+	}
+}
+
+// duplicateResource reports a resource whose name is already taken. The same
+// position twice means the declaration sits in a loop without a name that
+// varies per iteration, which is reported once rather than per iteration.
+func (w *ASTWalker) duplicateResource(resource, existing *ast.Resource) {
+	if resource.Pos == existing.Pos {
+		if w.loopDuplicates == nil {
+			w.loopDuplicates = make(map[string]bool)
+		}
+		if w.loopDuplicates[resource.Name] {
+			return
+		}
+		w.loopDuplicates[resource.Name] = true
+		w.errors = append(w.errors, w.compiler.diagnostic(resource.Pos,
+			"resource name %q is declared more than once by this loop; use count, for_each or a name built from the loop variable", resource.Name))
+		return
+	}
+
+	d := w.compiler.diagnostic(resource.Pos, "resource name %q is already declared", resource.Name)
+	d.Notes = append(d.Notes, w.compiler.diagnostic(existing.Pos, "note: %q first declared here as a %s", existing.Name, existing.Type))
+	w.errors = append(w.errors, d)
+}
+
+func (w *ASTWalker) createResource(call *ast.Call, resourceType, name string, config ast.Expression) {
+	props := w.convertToMap(w.evaluateExpression(config))
+	delete(props, "count")
+	delete(props, "for_each")
+
+	resource := &ast.Resource{
+		Name:         name,
+		Type:         resourceType,
+		Properties:   props,
+		DependsOn:    []string{},
+		Pos:          call.Pos,
+		AttributePos: make(map[string]ast.Position),
+	}
+	if obj, ok := config.(*ast.ObjectLiteral); ok {
+		for _, prop := range obj.Properties {
+			resource.AttributePos[prop.Key] = prop.Pos
+		}
+	}
+
+	if value, exists := props["depends_on"]; exists {
+		delete(props, "depends_on")
+		w.setDependsOn(call, resource, value)
+	}
+
+	if value, exists := props["lifecycle"]; exists {
+		delete(props, "lifecycle")
+		w.setLifecycle(call, resource, value)
+	}
+
+	if obj, ok := config.(*ast.ObjectLiteral); ok {
+		for _, prop := range obj.Properties {
+			if value, exists := props[prop.Key]; exists {
+				props[prop.Key] = w.idReferences(prop.Value, value)
+			}
+		}
+	}
+
+	if existing, exists := w.compiler.resources[name]; exists {
+		w.duplicateResource(resource, existing)
+		return
+	}
+
+	w.compiler.resources[name] = resource
+	if isDataSourceType(resourceType) {
+		fmt.Printf("Created data source: %s (%s)\n", name, resourceType)
+	} else {
+		fmt.Printf("Created resource: %s (%s)\n", name, resourceType)
+	}
+}
+
+// idReferences returns value, evaluated from expr, with the bare references
+// to resources in it, such as main_vpc, web[0] or net.vpc, replaced by
+// references to their ID. Only values written as a variable, index or
+// selector are replaced, so that a string which happens to be a resource
+// name, such as a Name tag, is kept.
+func (w *ASTWalker) idReferences(expr ast.Expression, value interface{}) interface{} {
+	switch e := expr.(type) {
+	case *ast.Identifier, *ast.Index, *ast.Selector:
+		return w.resourceIDs(value)
+	case *ast.ArrayLiteral:
+		if list, ok := value.([]interface{}); ok && len(list) == len(e.Elements) {
+			for i, elem := range e.Elements {
+				list[i] = w.idReferences(elem, list[i])
+			}
+		}
+	case *ast.ObjectLiteral:
+		if obj, ok := value.(map[string]interface{}); ok {
+			for _, prop := range e.Properties {
+				if item, exists := obj[prop.Key]; exists {
+					obj[prop.Key] = w.idReferences(prop.Value, item)
+				}
+			}
+		}
+	}
+	return value
+}
+
+// resourceIDs turns a resource name, or a list of them such as the
+// addresses of a resource with count, into references to their ID.
+func (w *ASTWalker) resourceIDs(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if resource, exists := w.compiler.resources[v]; exists {
+			if attr, ok := idAttributes[resource.Type]; ok {
+				return &ast.Reference{Resource: v, Attribute: attr}
+			}
+		}
+	case []interface{}:
+		ids := make([]interface{}, len(v))
+		for i, item := range v {
+			ids[i] = w.resourceIDs(item)
+		}
+		return ids
+	}
+	return value
+}
+
+// metaArgument returns the expression given for key when config is an object
+// literal.
+func metaArgument(config ast.Expression, key string) ast.Expression {
+	if obj, ok := config.(*ast.ObjectLiteral); ok {
+		return obj.Property(key)
+	}
+	return nil
+}
+
+// withBinding runs fn with name bound to value in a scope of its own.
+func (w *ASTWalker) withBinding(name string, value interface{}, fn func()) {
+	saved := w.scope
+	w.scope = newScope(saved)
+	w.scope.variables[name] = value
+	fn()
+	w.scope = saved
+}
+
+func (w *ASTWalker) setLifecycle(call *ast.Call, resource *ast.Resource, value interface{}) {
+	settings, ok := value.(map[string]interface{})
+	if !ok {
+		w.addError(call, "lifecycle for %s must be a map, got %s", resource.Name, typeName(value))
+		return
+	}
+
+	for _, key := range sortedKeys(settings) {
+		setting := settings[key]
+		switch key {
+		case "prevent_destroy", "create_before_destroy":
+			enabled, ok := setting.(bool)
+			if !ok {
+				w.addError(call, "lifecycle.%s for %s must be a bool, got %s", key, resource.Name, typeName(setting))
+				continue
+			}
+			if key == "prevent_destroy" {
+				resource.Lifecycle.PreventDestroy = enabled
+			} else {
+				resource.Lifecycle.CreateBeforeDestroy = enabled
+			}
+		case "ignore_changes":
+			attrs, ok := setting.([]interface{})
+			if !ok {
+				w.addError(call, "lifecycle.ignore_changes for %s must be a list, got %s", resource.Name, typeName(setting))
+				continue
+			}
+			for _, attr := range attrs {
+				name, ok := attr.(string)
+				if !ok {
+					w.addError(call, "lifecycle.ignore_changes for %s must list attribute names, got %s", resource.Name, typeName(attr))
+					continue
+				}
+				resource.Lifecycle.IgnoreChanges = append(resource.Lifecycle.IgnoreChanges, name)
+			}
+		default:
+			w.addError(call, "unsupported lifecycle setting %s for %s", key, resource.Name)
+		}
+	}
+}
+
+// setDependsOn records the depends_on targets of resource. A target may be a
+// resource name, a reference, or the list or map returned by a resource
+// declared with count or for_each.
+func (w *ASTWalker) setDependsOn(call *ast.Call, resource *ast.Resource, value interface{}) {
+	targets, ok := value.([]interface{})
+	if !ok {
+		w.addError(call, "depends_on for %s must be a list, got %s", resource.Name, typeName(value))
+		return
+	}
+
+	for len(targets) > 0 {
+		target := targets[0]
+		targets = targets[1:]
+
+		switch t := target.(type) {
+		case string:
+			resource.DependsOn = append(resource.DependsOn, t)
+		case *ast.Reference:
+			resource.DependsOn = append(resource.DependsOn, t.Resource)
+		case []interface{}:
+			targets = append(append([]interface{}{}, t...), targets...)
+			continue
+		case map[string]interface{}:
+			instances := make([]interface{}, 0, len(t))
+			for _, key := range sortedKeys(t) {
+				instances = append(instances, t[key])
+			}
+			targets = append(instances, targets...)
+			continue
+		default:
+			w.addError(call, "depends_on for %s must list resources, got %s", resource.Name, typeName(target))
+			continue
+		}
+		w.dependsOn = append(w.dependsOn, dependsOnTarget{call: call, resource: resource, index: len(resource.DependsOn) - 1})
+	}
+}
+
+// checkDependsOn resolves the depends_on entries recorded by this walker.
+// Plain names are looked up relative to the walker's module first.
+func (w *ASTWalker) checkDependsOn() {
+	reported := make(map[string]bool)
+	for _, dep := range w.dependsOn {
+		target := dep.resource.DependsOn[dep.index]
+		if _, exists := w.compiler.resources[w.qualifiedName(target)]; exists {
+			dep.resource.DependsOn[dep.index] = w.qualifiedName(target)
+			continue
+		}
+		if _, exists := w.compiler.resources[target]; exists {
+			continue
+		}
+
+		key := fmt.Sprintf("%p/%s", dep.call, target)
+		if !reported[key] {
+			reported[key] = true
+			w.addError(dep.call, "depends_on refers to unknown resource %q", target)
+		}
+	}
+	w.dependsOn = nil
+}
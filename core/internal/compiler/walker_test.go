@@ -0,0 +1,405 @@
+package compiler
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/tblang/core/internal/ast"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want map[string]interface{}
+	}{
+		{
+			name: "forward declarations",
+			src: `declare a = b + double(1);
+declare b = 2;
+func double(x) {
+    return x * 2;
+}
+`,
+			want: map[string]interface{}{"a": 4.0, "b": 2.0},
+		},
+		{
+			name: "function scope",
+			src: `declare x = 10;
+func f(x) {
+    declare y = x + 1;
+    return y;
+}
+declare r = f(1);
+`,
+			want: map[string]interface{}{"x": 10.0, "r": 2.0},
+		},
+		{
+			name: "recursion",
+			src: `func fib(n) {
+    if (n < 2) {
+        return n;
+    }
+    return fib(n - 1) + fib(n - 2);
+}
+declare f = fib(10);
+`,
+			want: map[string]interface{}{"f": 55.0},
+		},
+		{
+			name: "templates",
+			src: `declare env = "prod";
+declare n = 2;
+declare s = "${env}-${n}";
+`,
+			want: map[string]interface{}{"s": "prod-2"},
+		},
+		{
+			name: "comprehensions",
+			src: `declare xs = [1, 2, 3, 4];
+declare evens = [for x in xs: x * 10 if x % 2 == 0];
+declare names = {for i, x in ["a", "b"]: x => i};
+`,
+			want: map[string]interface{}{
+				"evens": []interface{}{20.0, 40.0},
+				"names": map[string]interface{}{"a": 0.0, "b": 1.0},
+			},
+		},
+		{
+			name: "conditionals and indexing",
+			src: `declare m = {size: "large", tags: ["a", "b"]};
+declare big = m.size == "large" ? m.tags[1] : "none";
+`,
+			want: map[string]interface{}{"big": "b"},
+		},
+		{
+			name: "resource values are their names",
+			src: `declare v = vpc("main", {cidr_block: "10.0.0.0/16"});
+declare subnets = subnet("web", {count: 2, vpc_id: v, cidr_block: "10.0.${count.index}.0/24"});
+declare tiers = subnet("tier", {for_each: ["app", "db"], vpc_id: v, cidr_block: "10.0.9.0/24"});
+`,
+			want: map[string]interface{}{
+				"v":       "main",
+				"subnets": []interface{}{"web[0]", "web[1]"},
+				"tiers":   map[string]interface{}{"app": `tier["app"]`, "db": `tier["db"]`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, err := compileSource(t, tt.src, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for name, want := range tt.want {
+				if got := variable(t, program, name); !reflect.DeepEqual(got, want) {
+					t.Errorf("%s = %#v, want %#v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "call depth",
+			src: `func f(n) {
+    return f(n + 1);
+}
+declare x = f(0);
+`,
+			want: []string{"2:12: maximum call depth of 100 exceeded calling f"},
+		},
+		{
+			name: "duplicate resource names",
+			src: `declare v = vpc("same", {});
+declare w = subnet("same", {});
+`,
+			want: []string{
+				`2:13: resource name "same" is already declared`,
+				`note 1:13: "same" first declared here as a vpc`,
+			},
+		},
+		{
+			name: "declaration cycle",
+			src: `declare a = b;
+declare b = a;
+`,
+			want: []string{"2:13: declaration cycle: a -> b -> a"},
+		},
+		{
+			name: "operand types",
+			src: `declare s = "x";
+declare n = s * 2;
+`,
+			want: []string{"2:13: operator * cannot be applied to string and number"},
+		},
+		{
+			name: "invalid values do not cascade",
+			src: `variable "v" {
+    type = number
+    default = "x"
+}
+declare a = v + 1;
+declare b = "${a}-${v}";
+declare s = subnet(b, {cidr_block: a});
+`,
+			want: []string{"1:1: variable v expects a number value, got string"},
+		},
+		{
+			name: "failed expressions do not cascade",
+			src: `declare n = [1][0];
+declare c = n ? "a" : "b";
+declare d = c + "x";
+declare m = {a: 1};
+declare e = m["b"] * 2;
+declare f = [n / 0, 2];
+declare g = f[0] + 1;
+declare s = subnet("s", {cidr_block: n ? "10.0.1.0/24" : e});
+`,
+			want: []string{
+				"2:13: condition must be bool, got number",
+				`5:15: key "b" not found in map`,
+				"6:14: division by zero",
+				"8:38: condition must be bool, got number",
+			},
+		},
+		{
+			name: "selectors",
+			src: `declare m = {size: "large"};
+declare a = m.missing;
+declare b = m["missing"];
+declare s = "text";
+declare c = s.length;
+`,
+			want: []string{
+				`2:13: key "missing" not found in map`,
+				`3:15: key "missing" not found in map`,
+				"5:13: cannot select length from string",
+			},
+		},
+		{
+			name: "references are only known after apply",
+			src: `declare v = vpc("main", {cidr_block: "10.0.0.0/16"});
+declare id = v.vpc_id;
+declare a = format("id-%s", id);
+declare b = jsonencode({vpc: id});
+declare c = id == "vpc-123";
+declare d = [id] != [];
+declare e = id ? 1 : 2;
+declare f = upper(id);
+declare g = "${id}";
+declare h = subnet(id, {vpc_id: id});
+declare ids = concat([id], [v.arn]);
+declare n = length(ids) + length(merge({a: id}, {}));
+`,
+			want: []string{
+				"3:13: argument of format refers to main.vpc_id, which is only known after apply",
+				"4:13: argument of jsonencode refers to main.vpc_id, which is only known after apply",
+				"5:13: operand of == refers to main.vpc_id, which is only known after apply",
+				"6:13: operand of != refers to main.vpc_id, which is only known after apply",
+				"7:13: condition refers to main.vpc_id, which is only known after apply",
+				"8:13: argument of upper refers to main.vpc_id, which is only known after apply",
+				"9:13: placeholder ${id} refers to main.vpc_id, which is only known after apply",
+				"10:20: resource name refers to main.vpc_id, which is only known after apply",
+			},
+		},
+		{
+			name: "count and for_each",
+			src: `declare a = vpc("a", {count: 1.5});
+declare b = vpc("b", {for_each: ["x", "x"]});
+declare c = vpc("c", {count: 1, for_each: ["y"]});
+`,
+			want: []string{
+				"1:30: count for a must be a whole number of at least 0",
+				`2:33: for_each list for b contains "x" more than once`,
+				"3:13: resource c cannot use both count and for_each",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compileSource(t, tt.src, nil)
+			checkDiagnostics(t, err, tt.want)
+		})
+	}
+}
+
+func TestEvaluateResources(t *testing.T) {
+	src := `declare v = vpc("main", {cidr_block: "10.0.0.0/16"});
+declare s = subnet("web", {vpc_id: v, cidr_block: "10.0.1.0/24", tags: {Name: "${v}"}});
+`
+	program, err := compileSource(t, src, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	resources := make(map[string]*ast.Resource)
+	for _, resource := range program.Resources {
+		names = append(names, resource.Name)
+		resources[resource.Name] = resource
+	}
+	sort.Strings(names)
+	if !reflect.DeepEqual(names, []string{"main", "web"}) {
+		t.Fatalf("resources = %v", names)
+	}
+
+	web := resources["web"]
+	if ref, ok := web.Properties["vpc_id"].(*ast.Reference); !ok || ref.Resource != "main" || ref.Attribute != "vpc_id" {
+		t.Errorf("vpc_id = %#v, want a reference to main.vpc_id", web.Properties["vpc_id"])
+	}
+	if tags := web.Properties["tags"].(map[string]interface{}); tags["Name"] != "main" {
+		t.Errorf("tags.Name = %#v, want the string main", tags["Name"])
+	}
+}
+
+func TestEvaluateModules(t *testing.T) {
+	files := map[string]string{
+		"network.tbl": `variable "cidr" {
+    type = string
+}
+declare v = vpc("vpc", {cidr_block: cidr});
+output "vpc" {
+    value = v
+}
+`,
+	}
+	src := `import "./network.tbl" as net {cidr: "10.1.0.0/16"};
+declare s = subnet("web", {vpc_id: net.vpc, cidr_block: "10.1.1.0/24"});
+`
+	program, err := compileSource(t, src, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resources := make(map[string]*ast.Resource)
+	for _, resource := range program.Resources {
+		resources[resource.Name] = resource
+	}
+	vpc, exists := resources["net.vpc"]
+	if !exists {
+		t.Fatalf("no resource net.vpc in %v", resources)
+	}
+	if got := vpc.Properties["cidr_block"]; got != "10.1.0.0/16" {
+		t.Errorf("net.vpc cidr_block = %#v", got)
+	}
+	if ref, ok := resources["web"].Properties["vpc_id"].(*ast.Reference); !ok || ref.Resource != "net.vpc" {
+		t.Errorf("vpc_id = %#v, want a reference to net.vpc", resources["web"].Properties["vpc_id"])
+	}
+}
+
+func TestEvaluateModuleOutputs(t *testing.T) {
+	files := map[string]string{
+		"network.tbl": `declare v = vpc("vpc", {cidr_block: "10.1.0.0/16"});
+output "vpc_id" {
+    value = v
+}
+`,
+	}
+	src := `import "./network.tbl" as net;
+declare a = net.vpc_ld;
+declare b = net.subnets;
+declare m = {vpc_ld: 1};
+declare c = m.vpc_ld;
+`
+	_, err := compileSource(t, src, files)
+	checkDiagnostics(t, err, []string{
+		`2:13: module net has no output "vpc_ld" (did you mean vpc_id?)`,
+		`3:13: module net has no output "subnets"`,
+	})
+}
+
+func TestInputVariables(t *testing.T) {
+	src := `variable "azs" {
+    type = list
+}
+variable "size" {
+    type = number
+    default = 1
+}
+`
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  map[string]interface{}
+		diags []string
+	}{
+		{
+			name:  "flags",
+			flags: map[string]string{"azs": `["a", "b"]`, "size": "3"},
+			want:  map[string]interface{}{"azs": []interface{}{"a", "b"}, "size": 3.0},
+		},
+		{
+			name:  "calls are not literals",
+			flags: map[string]string{"azs": `[vpc("zz", {})]`},
+			diags: []string{`1:1: invalid value for variable azs: variable values must be literals, got vpc(...)`},
+		},
+		{
+			name:  "required",
+			flags: map[string]string{},
+			diags: []string{"1:1: no value given for required variable azs (use -var, -var-file or TBLANG_VAR_azs)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			c.SetInputVariables(&InputVariables{Flags: tt.flags})
+			program, err := compileWith(t, c, src, nil)
+			if tt.diags != nil {
+				checkDiagnostics(t, err, tt.diags)
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for name, want := range tt.want {
+				if got := variable(t, program, name); !reflect.DeepEqual(got, want) {
+					t.Errorf("%s = %#v, want %#v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestCompileAST compiles a file built by hand, without the parser.
+func TestCompileAST(t *testing.T) {
+	pos := func(line, col int) ast.Position { return ast.Position{Filename: "built.tbl", Line: line, Column: col} }
+	file := &ast.File{
+		Filename: "built.tbl",
+		Statements: []ast.Statement{
+			&ast.VariableDeclaration{
+				Pos:  pos(1, 1),
+				Name: "x",
+				Value: &ast.Binary{
+					Pos:   pos(1, 13),
+					Op:    "*",
+					Left:  &ast.NumberLiteral{Pos: pos(1, 13), Value: 6},
+					Right: &ast.Identifier{Pos: pos(1, 17), Name: "y"},
+				},
+			},
+			&ast.VariableDeclaration{
+				Pos:   pos(2, 1),
+				Name:  "y",
+				Value: &ast.NumberLiteral{Pos: pos(2, 13), Value: 7},
+			},
+		},
+	}
+
+	program, err := New().Compile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := variable(t, program, "x"); got != 42.0 {
+		t.Errorf("x = %#v, want 42", got)
+	}
+}
@@ -1,13 +1,72 @@
 package compiler
 
 import (
-	"github.com/tblang/core/parser"
+	"github.com/tblang/core/internal/ast"
 )
 
+// ASTWalker evaluates the statements of a file, or of a module imported by
+// it, once they have been resolved and type checked.
 type ASTWalker struct {
-	*parser.BasetblangListener
-	compiler          *Compiler
-	variables         map[string]interface{}
-	processedContexts map[interface{}]bool
-	inManualExecution bool
+	compiler       *Compiler
+	scope          *scope
+	globals        *scope
+	errors         []error
+	callStack      []string
+	returning      bool
+	returnValue    interface{}
+	filename       string
+	modulePrefix   string
+	importStack    []string
+	inputs         map[string]interface{}
+	declaredInputs map[string]bool
+	outputs        map[string]interface{}
+	modules        map[string]map[string]interface{}
+	dependsOn      []dependsOnTarget
+	loopDuplicates map[string]bool
+
+	// pending holds the top-level declarations that have not run yet, by
+	// the name they declare, and initializing the names being declared.
+	pending      map[string][]ast.Statement
+	initializing []string
+}
+
+// scope holds the variables and functions declared in a file, a function
+// call, or one iteration of a loop or comprehension.
+type scope struct {
+	parent    *scope
+	variables map[string]interface{}
+	functions map[string]*userFunction
+}
+
+type userFunction struct {
+	name    string
+	params  []string
+	body    []ast.Statement
+	closure *scope
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{
+		parent:    parent,
+		variables: make(map[string]interface{}),
+		functions: make(map[string]*userFunction),
+	}
+}
+
+func (s *scope) lookup(name string) (interface{}, bool) {
+	for ; s != nil; s = s.parent {
+		if value, exists := s.variables[name]; exists {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func (s *scope) function(name string) *userFunction {
+	for ; s != nil; s = s.parent {
+		if fn, exists := s.functions[name]; exists {
+			return fn
+		}
+	}
+	return nil
 }
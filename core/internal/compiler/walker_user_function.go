@@ -0,0 +1,73 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/tblang/core/internal/ast"
+)
+
+const maxCallDepth = 100
+
+type callDepthExceeded struct {
+	call *ast.Call
+	name string
+}
+
+// declareFunction binds a function in the current scope, which it closes
+// over. Names and parameters have already been checked by the resolver.
+func (w *ASTWalker) declareFunction(decl *ast.FunctionDeclaration) {
+	w.scope.functions[decl.Name] = &userFunction{
+		name:    decl.Name,
+		params:  decl.Params,
+		body:    decl.Body,
+		closure: w.scope,
+	}
+
+	fmt.Printf("Declared function: %s(%d params)\n", decl.Name, len(decl.Params))
+}
+
+func (w *ASTWalker) callUserFunction(call *ast.Call, fn *userFunction, args []interface{}) (result interface{}) {
+	if len(args) != len(fn.params) {
+		w.addError(call, "function %s expects %d argument(s), got %d", fn.name, len(fn.params), len(args))
+		return invalid
+	}
+
+	if len(w.callStack) >= maxCallDepth {
+		panic(&callDepthExceeded{call: call, name: fn.name})
+	}
+
+	saved := w.scope
+
+	if len(w.callStack) == 0 {
+		defer func() {
+			if r := recover(); r != nil {
+				exceeded, ok := r.(*callDepthExceeded)
+				if !ok {
+					panic(r)
+				}
+				w.addError(exceeded.call, "maximum call depth of %d exceeded calling %s", maxCallDepth, exceeded.name)
+				w.scope = saved
+				w.callStack = nil
+				w.returning = false
+				w.returnValue = nil
+				result = invalid
+			}
+		}()
+	}
+
+	w.scope = newScope(fn.closure)
+	for i, param := range fn.params {
+		w.scope.variables[param] = args[i]
+	}
+	w.callStack = append(w.callStack, fn.name)
+
+	w.executeStatements(fn.body)
+	result = w.returnValue
+
+	w.returning = false
+	w.returnValue = nil
+	w.callStack = w.callStack[:len(w.callStack)-1]
+	w.scope = saved
+
+	return result
+}
@@ -2,8 +2,12 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 
+	"github.com/tblang/core/internal/compiler"
+	"github.com/tblang/core/internal/graph"
 	"github.com/tblang/core/internal/state"
 )
 
@@ -12,19 +16,27 @@ func (e *Engine) Apply(ctx context.Context, filename string) error {
 
 	program, err := e.compiler.CompileFile(filename)
 	if err != nil {
-		return fmt.Errorf("compilation failed: %w", err)
+		return fmt.Errorf("compilation failed:\n%w", err)
 	}
 
 	if err := e.loadAndConfigurePlugins(ctx, program); err != nil {
 		return fmt.Errorf("failed to load plugins: %w", err)
 	}
 
+	schemas, err := e.validateSchemas(ctx, program)
+	if err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
 	currentState, err := e.stateManager.LoadState()
 	if err != nil {
 		currentState = &state.State{Resources: make(map[string]*state.ResourceState)}
 	}
 
-	changes := e.calculateChanges(program, currentState)
+	changes, err := e.calculateChanges(ctx, program, currentState, schemas)
+	if err != nil {
+		return fmt.Errorf("plan failed: %w", err)
+	}
 
 	e.displayPlan(changes)
 
@@ -37,7 +49,41 @@ func (e *Engine) Apply(ctx context.Context, filename string) error {
 		return nil
 	}
 
-	if err := e.applyChanges(ctx, changes, currentState); err != nil {
+	return e.execute(ctx, program, changes, currentState)
+}
+
+// ApplyPlan executes a plan saved by plan -out, without asking for
+// confirmation. It refuses to run if the state has changed since the plan
+// was made, as the plan may no longer be what it would be now.
+func (e *Engine) ApplyPlan(ctx context.Context, planFile string) error {
+	infoColor.Printf("Applying saved plan %s...\n", planFile)
+
+	plan, program, changes, err := readPlanFile(planFile)
+	if err != nil {
+		return err
+	}
+
+	currentState, err := e.stateManager.LoadState()
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	if currentState.Serial != plan.StateSerial {
+		return fmt.Errorf("saved plan %s is stale: the state has changed since it was made (serial %d, now %d); run plan again", planFile, plan.StateSerial, currentState.Serial)
+	}
+
+	if err := e.loadAndConfigurePlugins(ctx, program); err != nil {
+		return fmt.Errorf("failed to load plugins: %w", err)
+	}
+
+	e.displayPlan(changes)
+
+	return e.execute(ctx, program, changes, currentState)
+}
+
+func (e *Engine) execute(ctx context.Context, program *compiler.Program, changes *PlanChanges, currentState *state.State) error {
+	recordLifecycle(program, currentState)
+
+	if err := e.applyChanges(ctx, program, changes, currentState); err != nil {
 		return fmt.Errorf("apply failed: %w", err)
 	}
 
@@ -45,47 +91,194 @@ func (e *Engine) Apply(ctx context.Context, filename string) error {
 	return nil
 }
 
-func (e *Engine) applyChanges(ctx context.Context, changes *PlanChanges, currentState *state.State) error {
+// applyChanges applies the changes in dependency order, starting each
+// resource as soon as the ones it depends on are done and running up to
+// e.parallelism of them at once. Deleted resources, and the previous
+// instances of replaced ones, are destroyed in the same walk, after the
+// resources that depended on them. A resource that fails only stops the
+// resources that depend on it.
+func (e *Engine) applyChanges(ctx context.Context, program *compiler.Program, changes *PlanChanges, currentState *state.State) error {
+	configs := make(map[string]map[string]interface{}, len(program.Resources))
+	for _, resource := range program.Resources {
+		configs[resource.Name] = resource.Properties
+	}
 
+	actions := make(map[string]func() error)
 	for _, resource := range changes.Create {
-		resourceColor := e.getResourceColor(resource.Type)
-		resourceColor.Printf("\nCreating %s (%s)...\n", resource.Name, resource.Type)
+		actions[resource.Name] = func() error { return e.applyCreate(ctx, resource, configs[resource.Name], currentState) }
+	}
+	for _, resource := range changes.Update {
+		actions[resource.Name] = func() error { return e.applyUpdate(ctx, resource, configs[resource.Name], currentState) }
+	}
+	for _, resource := range changes.Replace {
+		prior := currentState.Resources[resource.Name]
+		actions[resource.Name] = func() error { return e.applyCreate(ctx, resource, configs[resource.Name], currentState) }
+		actions[destroyNode(resource.Name)] = func() error { return e.destroyPrevious(ctx, resource, prior, currentState) }
+	}
+	for _, resource := range changes.Delete {
+		actions[destroyNode(resource.Name)] = func() error { return e.destroyResource(ctx, resource, currentState) }
+	}
 
-		newState, err := e.createResourceWithPlugin(ctx, resource)
-		if err != nil {
-			errorColor.Printf("  ✗ Failed to create %s: %v\n", resource.Name, err)
-			return fmt.Errorf("failed to create %s: %w", resource.Name, err)
-		}
+	dg, err := applyGraph(program, changes, currentState)
+	if err != nil {
+		return err
+	}
 
-		if newState != nil {
-			if stateMap, ok := newState.(map[string]interface{}); ok {
-				resource.Attributes = stateMap
-			}
+	errs := dg.Walk(e.parallelism, func(name string) error {
+		if action, ok := actions[name]; ok {
+			return action()
 		}
+		return nil
+	})
+
+	return walkErrors(errs)
+}
+
+// applyGraph returns the graph of the changes, with a node for every
+// configured resource and one destroying each deleted resource and the
+// previous instance of each replaced one. It records the configured
+// dependencies of the resources in state.
+func applyGraph(program *compiler.Program, changes *PlanChanges, currentState *state.State) (*graph.DependencyGraph, error) {
+	// Resources are destroyed by the dependencies they were applied with,
+	// so they are read before being replaced by those configured now.
+	recorded := make(map[string][]string, len(currentState.Resources))
+	for name, resource := range currentState.Resources {
+		recorded[name] = resource.Dependencies
+	}
+
+	dg, err := programGraph(program)
+	if err != nil {
+		return nil, err
+	}
 
-		resource.Status = "created"
-		currentState.Resources[resource.Name] = resource
-		if err := e.stateManager.SaveState(currentState); err != nil {
-			return fmt.Errorf("failed to save state: %w", err)
+	for _, resources := range [][]*state.ResourceState{changes.Create, changes.Update, changes.Replace} {
+		recordDependencies(dg, resources...)
+	}
+	for _, resource := range program.Resources {
+		if current, exists := currentState.Resources[resource.Name]; exists {
+			recordDependencies(dg, current)
 		}
+	}
 
-		successColor.Printf("  ✓ Created %s (%s)\n", resource.Name, resource.Type)
+	if err := addDestroys(dg, changes, recorded); err != nil {
+		return nil, err
 	}
+	return dg, nil
+}
 
-	for _, resource := range changes.Delete {
-		warningColor.Printf("\nDeleting %s (%s)...\n", resource.Name, resource.Type)
+// walkErrors reports the resources skipped by a walk and joins its errors,
+// the failures first and then the skipped resources, each sorted by name.
+func walkErrors(errs map[string]error) error {
+	names := make([]string, 0, len(errs))
+	for name := range errs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-		if err := e.destroyResourceWithPlugin(ctx, resource); err != nil {
-			errorColor.Printf("  ✗ Failed to delete %s: %v\n", resource.Name, err)
-		} else {
-			successColor.Printf("  ✓ Deleted %s (%s)\n", resource.Name, resource.Type)
+	var failed, skipped []error
+	for _, name := range names {
+		var depErr *graph.DependencyError
+		if errors.As(errs[name], &depErr) {
+			warningColor.Printf("  - Skipped %s: %s failed\n", name, depErr.Dependency)
+			skipped = append(skipped, errs[name])
+			continue
 		}
+		failed = append(failed, errs[name])
+	}
+	return errors.Join(append(failed, skipped...)...)
+}
+
+// updateState changes the resources in currentState and saves it. Resources
+// are applied concurrently, so currentState must not be touched otherwise
+// while they are.
+func (e *Engine) updateState(currentState *state.State, update func(resources map[string]*state.ResourceState)) error {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	update(currentState.Resources)
+	if err := e.stateManager.SaveState(currentState); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	return nil
+}
+
+func (e *Engine) priorResource(currentState *state.State, name string) *state.ResourceState {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+	return currentState.Resources[name]
+}
+
+func (e *Engine) applyCreate(ctx context.Context, resource *state.ResourceState, config map[string]interface{}, currentState *state.State) error {
+	resourceColor := e.getResourceColor(resource.Type)
+	resourceColor.Printf("\nCreating %s (%s)...\n", resource.Name, resource.Type)
+
+	newState, err := e.createResourceWithPlugin(ctx, resource, config, currentState)
+	if err != nil {
+		errorColor.Printf("  ✗ Failed to create %s: %v\n", resource.Name, err)
+		return fmt.Errorf("failed to create %s: %w", resource.Name, err)
+	}
 
-		delete(currentState.Resources, resource.Name)
-		if err := e.stateManager.SaveState(currentState); err != nil {
-			return fmt.Errorf("failed to save state: %w", err)
+	if newState != nil {
+		if stateMap, ok := newState.(map[string]interface{}); ok {
+			resource.Attributes = stateMap
 		}
 	}
 
+	resource.Status = "created"
+	if err := e.updateState(currentState, func(resources map[string]*state.ResourceState) {
+		resources[resource.Name] = resource
+	}); err != nil {
+		return err
+	}
+
+	successColor.Printf("  ✓ Created %s (%s)\n", resource.Name, resource.Type)
+	return nil
+}
+
+// applyUpdate changes the existing resource in place, keeping the attributes
+// the provider computed when it was created.
+func (e *Engine) applyUpdate(ctx context.Context, resource *state.ResourceState, config map[string]interface{}, currentState *state.State) error {
+	prior := e.priorResource(currentState, resource.Name)
+
+	resourceColor := e.getResourceColor(resource.Type)
+	resourceColor.Printf("\nUpdating %s (%s)...\n", resource.Name, resource.Type)
+
+	newState, err := e.updateResourceWithPlugin(ctx, prior, resource, config, currentState)
+	if err != nil {
+		errorColor.Printf("  ✗ Failed to update %s: %v\n", resource.Name, err)
+		return fmt.Errorf("failed to update %s: %w", resource.Name, err)
+	}
+
+	if stateMap, ok := newState.(map[string]interface{}); ok {
+		resource.Attributes = stateMap
+	}
+
+	resource.Status = "created"
+	if err := e.updateState(currentState, func(resources map[string]*state.ResourceState) {
+		resources[resource.Name] = resource
+	}); err != nil {
+		return err
+	}
+
+	successColor.Printf("  ✓ Updated %s (%s)\n", resource.Name, resource.Type)
 	return nil
 }
+
+// destroyPrevious destroys prior, the instance of resource it replaces.
+// Unless the new instance has already taken its place in state, as with
+// create_before_destroy, prior is removed from it.
+func (e *Engine) destroyPrevious(ctx context.Context, resource, prior *state.ResourceState, currentState *state.State) error {
+	warningColor.Printf("\nDestroying previous %s (%s)...\n", prior.Name, prior.Type)
+	if err := e.destroyResourceWithPlugin(ctx, prior); err != nil {
+		errorColor.Printf("  ✗ Failed to destroy %s: %v\n", prior.Name, err)
+		return fmt.Errorf("failed to replace %s: %w", prior.Name, err)
+	}
+	successColor.Printf("  ✓ Destroyed previous %s (%s)\n", prior.Name, prior.Type)
+
+	if resource.Lifecycle != nil && resource.Lifecycle.CreateBeforeDestroy {
+		return nil
+	}
+	return e.updateState(currentState, func(resources map[string]*state.ResourceState) {
+		delete(resources, resource.Name)
+	})
+}
@@ -0,0 +1,202 @@
+package engine
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/tblang/core/internal/ast"
+	"github.com/tblang/core/internal/compiler"
+	"github.com/tblang/core/internal/state"
+	"github.com/tblang/core/pkg/plugin"
+)
+
+// walkOrder walks the graph apply would walk for changes and returns the
+// order in which its nodes are visited.
+func walkOrder(t *testing.T, program *compiler.Program, changes *PlanChanges, currentState *state.State) map[string]int {
+	t.Helper()
+	dg, err := applyGraph(program, changes, currentState)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	order := make(map[string]int)
+	errs := dg.Walk(4, func(name string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		order[name] = len(order)
+		return nil
+	})
+	if len(errs) != 0 {
+		t.Fatalf("walk failed: %v", errs)
+	}
+	return order
+}
+
+func checkBefore(t *testing.T, order map[string]int, pairs [][2]string) {
+	t.Helper()
+	for _, pair := range pairs {
+		first, firstOK := order[pair[0]]
+		then, thenOK := order[pair[1]]
+		if !firstOK || !thenOK {
+			t.Errorf("%s or %s was not visited", pair[0], pair[1])
+			continue
+		}
+		if first > then {
+			t.Errorf("%s was visited after %s", pair[0], pair[1])
+		}
+	}
+}
+
+func ref(resource, attribute string) *ast.Reference {
+	return &ast.Reference{Resource: resource, Attribute: attribute}
+}
+
+func TestApplyGraphDeletions(t *testing.T) {
+	// The VPC is replaced, and the old subnet in it and the instance in
+	// that subnet are deleted. Separately, web moves to a new security
+	// group and the old one is deleted.
+	program := &compiler.Program{
+		Resources: []*ast.Resource{
+			{Name: "vpc", Type: "vpc"},
+			{Name: "subnet_b", Type: "subnet", Properties: map[string]interface{}{"vpc_id": ref("vpc", "vpc_id")}},
+			{Name: "sg_b", Type: "security_group"},
+			{Name: "web", Type: "ec2", Properties: map[string]interface{}{"security_groups": []interface{}{ref("sg_b", "group_id")}}},
+		},
+	}
+	current := map[string]*state.ResourceState{
+		"vpc":      {Name: "vpc", Type: "vpc"},
+		"subnet_a": {Name: "subnet_a", Type: "subnet", Dependencies: []string{"vpc"}},
+		"ec2":      {Name: "ec2", Type: "ec2", Dependencies: []string{"subnet_a"}},
+		"sg_a":     {Name: "sg_a", Type: "security_group"},
+		"web":      {Name: "web", Type: "ec2", Dependencies: []string{"sg_a"}},
+	}
+	changes := &PlanChanges{
+		Create:  []*state.ResourceState{{Name: "subnet_b", Type: "subnet"}, {Name: "sg_b", Type: "security_group"}},
+		Update:  []*state.ResourceState{{Name: "web", Type: "ec2"}},
+		Replace: []*state.ResourceState{{Name: "vpc", Type: "vpc"}},
+		Delete:  []*state.ResourceState{current["subnet_a"], current["ec2"], current["sg_a"]},
+	}
+
+	order := walkOrder(t, program, changes, &state.State{Resources: current})
+	checkBefore(t, order, [][2]string{
+		{destroyNode("ec2"), destroyNode("subnet_a")},
+		{destroyNode("subnet_a"), destroyNode("vpc")},
+		{destroyNode("vpc"), "vpc"},
+		{"vpc", "subnet_b"},
+		{"sg_b", "web"},
+		{"web", destroyNode("sg_a")},
+	})
+
+	if deps := current["web"].Dependencies; len(deps) != 1 || deps[0] != "sg_b" {
+		t.Errorf("web dependencies = %v, want [sg_b]", deps)
+	}
+}
+
+func TestApplyGraphReplacements(t *testing.T) {
+	program := &compiler.Program{
+		Resources: []*ast.Resource{
+			{Name: "vpc", Type: "vpc"},
+			{Name: "subnet", Type: "subnet", Properties: map[string]interface{}{"vpc_id": ref("vpc", "vpc_id")}},
+			{Name: "ec2", Type: "ec2", Properties: map[string]interface{}{"subnet_id": ref("subnet", "subnet_id")}},
+		},
+	}
+	currentState := func() *state.State {
+		return &state.State{Resources: map[string]*state.ResourceState{
+			"vpc":    {Name: "vpc", Type: "vpc"},
+			"subnet": {Name: "subnet", Type: "subnet", Dependencies: []string{"vpc"}},
+			"ec2":    {Name: "ec2", Type: "ec2", Dependencies: []string{"subnet"}},
+		}}
+	}
+
+	t.Run("destroy before create", func(t *testing.T) {
+		// The subnet is replaced with the VPC, since it cannot outlive it,
+		// and the instance is updated to the new subnet.
+		changes := &PlanChanges{
+			Update: []*state.ResourceState{{Name: "ec2", Type: "ec2"}},
+			Replace: []*state.ResourceState{
+				{Name: "vpc", Type: "vpc"},
+				{Name: "subnet", Type: "subnet"},
+			},
+		}
+		order := walkOrder(t, program, changes, currentState())
+		checkBefore(t, order, [][2]string{
+			{destroyNode("subnet"), destroyNode("vpc")},
+			{destroyNode("vpc"), "vpc"},
+			{"vpc", "subnet"},
+			{destroyNode("subnet"), "subnet"},
+			{"subnet", "ec2"},
+		})
+	})
+
+	t.Run("create before destroy", func(t *testing.T) {
+		// The subnet moves to the new VPC before the old one is destroyed.
+		changes := &PlanChanges{
+			Update: []*state.ResourceState{{Name: "subnet", Type: "subnet"}},
+			Replace: []*state.ResourceState{
+				{Name: "vpc", Type: "vpc", Lifecycle: &ast.Lifecycle{CreateBeforeDestroy: true}},
+			},
+		}
+		order := walkOrder(t, program, changes, currentState())
+		checkBefore(t, order, [][2]string{
+			{"vpc", "subnet"},
+			{"subnet", destroyNode("vpc")},
+			{"vpc", destroyNode("vpc")},
+		})
+	})
+}
+
+func TestApplyIgnoreChanges(t *testing.T) {
+	// The tags were changed outside tblang and are ignored, so enabling DNS
+	// must not put back the configured ones.
+	resource := &ast.Resource{
+		Name: "main",
+		Type: "vpc",
+		Properties: map[string]interface{}{
+			"cidr_block": "10.0.0.0/16",
+			"enable_dns": true,
+			"tags":       map[string]interface{}{"Name": "main"},
+		},
+		Lifecycle: ast.Lifecycle{IgnoreChanges: []string{"tags"}},
+	}
+	program := &compiler.Program{Resources: []*ast.Resource{resource}}
+	currentState := &state.State{Resources: map[string]*state.ResourceState{
+		"main": {Name: "main", Type: "vpc", Attributes: map[string]interface{}{
+			"cidr_block": "10.0.0.0/16",
+			"tags":       map[string]interface{}{"Name": "main", "Owner": "ops"},
+			"id":         "vpc-1",
+		}},
+	}}
+	wantTags := map[string]interface{}{"Name": "main", "Owner": "ops"}
+
+	provider := &fakeProvider{}
+	e := newTestEngine(t, provider)
+	ctx := context.Background()
+
+	changes, err := e.calculateChanges(ctx, program, currentState, &plugin.GetSchemaResponse{ResourceSchemas: testSchemas})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes.Update) != 1 {
+		t.Fatalf("planned %+v, want main updated", changes)
+	}
+	if got := changes.Update[0].Attributes["tags"]; !reflect.DeepEqual(got, wantTags) {
+		t.Errorf("planned tags = %v, want %v", got, wantTags)
+	}
+
+	if err := e.applyChanges(ctx, program, changes, currentState); err != nil {
+		t.Fatal(err)
+	}
+	req := provider.request(t, "vpc")
+	if got := req.PlannedState.(map[string]interface{})["tags"]; !reflect.DeepEqual(got, wantTags) {
+		t.Errorf("applied tags = %v, want %v", got, wantTags)
+	}
+	if got := currentState.Resources["main"].Attributes["tags"]; !reflect.DeepEqual(got, wantTags) {
+		t.Errorf("tags in state = %v, want %v", got, wantTags)
+	}
+	if got := currentState.Resources["main"].Attributes["enable_dns"]; got != true {
+		t.Errorf("enable_dns in state = %v, want true", got)
+	}
+}
@@ -1,25 +1,67 @@
 package engine
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/tblang/core/internal/ast"
 	"github.com/tblang/core/internal/compiler"
 	"github.com/tblang/core/internal/state"
+	"github.com/tblang/core/pkg/plugin"
 )
 
-func (e *Engine) calculateChanges(program *compiler.Program, currentState *state.State) *PlanChanges {
+func (e *Engine) calculateChanges(ctx context.Context, program *compiler.Program, currentState *state.State, schemas *plugin.GetSchemaResponse) (*PlanChanges, error) {
 	changes := &PlanChanges{
-		Create: make([]*state.ResourceState, 0),
-		Update: make([]*state.ResourceState, 0),
-		Delete: make([]*state.ResourceState, 0),
+		Create:  make([]*state.ResourceState, 0),
+		Update:  make([]*state.ResourceState, 0),
+		Replace: make([]*state.ResourceState, 0),
+		Delete:  make([]*state.ResourceState, 0),
+		Diffs:   make(map[string][]AttributeDiff),
 	}
 
+	var errs []error
+
+	// Resources come in dependency order, so a resource referring to one
+	// being replaced sees the values that change with it as unknown. If the
+	// one it refers to is destroyed before it is created again, it must be
+	// replaced too, since it cannot outlive what it refers to.
+	replaced := make(map[string]bool)
+	destroyedFirst := make(map[string]bool)
+
 	for _, resource := range program.Resources {
-		if _, exists := currentState.Resources[resource.Name]; !exists {
-			changes.Create = append(changes.Create, &state.ResourceState{
-				Name:       resource.Name,
-				Type:       resource.Type,
-				Status:     "planned",
-				Attributes: resource.Properties,
-			})
+		current := currentState.Resources[resource.Name]
+		attributes, diffs, replace, err := e.planResource(ctx, resource, current, currentState, replaced, destroyedFirst, resourceSchema(schemas, resource.Type))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", resource.Name, err))
+			continue
+		}
+		if current != nil && len(diffs) == 0 {
+			continue
+		}
+		changes.Diffs[resource.Name] = diffs
+
+		planned := &state.ResourceState{
+			Name:       resource.Name,
+			Type:       resource.Type,
+			Status:     "planned",
+			Attributes: attributes,
+			Lifecycle:  lifecycleState(resource.Lifecycle),
+		}
+
+		switch {
+		case current == nil:
+			changes.Create = append(changes.Create, planned)
+		case !replace:
+			changes.Update = append(changes.Update, planned)
+		case resource.Lifecycle.PreventDestroy:
+			errs = append(errs, fmt.Errorf("%s must be replaced to change %v, but lifecycle.prevent_destroy is set", resource.Name, forcingAttributes(diffs)))
+		default:
+			changes.Replace = append(changes.Replace, planned)
+			replaced[resource.Name] = true
+			destroyedFirst[resource.Name] = !resource.Lifecycle.CreateBeforeDestroy
 		}
 	}
 
@@ -30,9 +72,278 @@ func (e *Engine) calculateChanges(program *compiler.Program, currentState *state
 
 	for name, resource := range currentState.Resources {
 		if !programResources[name] {
+			if resource.Lifecycle != nil && resource.Lifecycle.PreventDestroy {
+				errs = append(errs, fmt.Errorf("%s is no longer in the configuration, but lifecycle.prevent_destroy is set", name))
+				continue
+			}
 			changes.Delete = append(changes.Delete, resource)
 		}
 	}
 
-	return changes
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return changes, nil
+}
+
+// planResource asks the provider to plan the change from current, which is
+// nil for a new resource, to the configuration of resource. It returns the
+// state the provider planned, which apply sends back to it, the attribute
+// diffs, which are empty if nothing changes, and whether the change replaces
+// the resource. Without a loaded provider the planned state is the
+// configuration itself and every change is a replacement. Changing an
+// attribute that refers to a resource in destroyedFirst also replaces it.
+func (e *Engine) planResource(ctx context.Context, resource *ast.Resource, current *state.ResourceState, currentState *state.State, replaced, destroyedFirst map[string]bool, schema map[string]*plugin.Attribute) (map[string]interface{}, []AttributeDiff, bool, error) {
+	var prior map[string]interface{}
+	if current != nil {
+		prior = current.Attributes
+	}
+	proposed, unknown := proposedState(resource, prior, currentState, replaced)
+
+	planned := proposed
+	var requiresReplace []string
+	providerPlanned := false
+
+	if pluginInstance, err := e.pluginManager.GetPlugin("aws"); err == nil {
+		req := &plugin.PlanResourceChangeRequest{
+			TypeName:         resource.Type,
+			ProposedNewState: proposed,
+			Config:           proposed,
+		}
+		if prior != nil {
+			req.PriorState = prior
+		}
+
+		resp, err := pluginInstance.Client.PlanResourceChange(ctx, req)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("plugin error: %w", err)
+		}
+		for _, diag := range resp.Diagnostics {
+			if diag.Severity == "error" {
+				return nil, nil, false, fmt.Errorf("%s: %s", diag.Summary, diag.Detail)
+			}
+		}
+
+		if plannedState, ok := resp.PlannedState.(map[string]interface{}); ok {
+			planned = plannedState
+		}
+		requiresReplace = resp.RequiresReplace
+		providerPlanned = true
+	}
+
+	diffs := diffAttributes(prior, planned, unknown, schema)
+	if len(diffs) == 0 {
+		return nil, nil, false, nil
+	}
+
+	forces := make(map[string]bool)
+	for _, name := range requiresReplace {
+		forces[name] = true
+	}
+
+	replace := false
+	for i := range diffs {
+		if prior != nil && refersTo(resource.Properties[diffs[i].Name], destroyedFirst) {
+			forces[diffs[i].Name] = true
+		}
+		if forces[diffs[i].Name] || (prior != nil && !providerPlanned) {
+			diffs[i].ForcesReplacement = true
+			replace = true
+		}
+	}
+
+	if prior == nil || replace {
+		diffs = append(diffs, computedAttributes(prior, planned, schema)...)
+		sort.SliceStable(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+	}
+
+	return withUnknown(planned, resource, unknown), diffs, replace, nil
+}
+
+// proposedState resolves the configured attributes of resource against
+// currentState. Attributes that refer to resources not created yet or being
+// replaced are left out and returned in unknown, and attributes listed in
+// ignore_changes keep their prior value.
+func proposedState(resource *ast.Resource, prior map[string]interface{}, currentState *state.State, replaced map[string]bool) (map[string]interface{}, map[string]bool) {
+	proposed := make(map[string]interface{})
+	unknown := make(map[string]bool)
+
+	for key, value := range resource.Properties {
+		if refersTo(value, replaced) {
+			unknown[key] = true
+			continue
+		}
+		resolved, err := resolveAttributes(map[string]interface{}{key: value}, currentState)
+		if err != nil {
+			unknown[key] = true
+			continue
+		}
+		proposed[key] = resolved[key]
+	}
+
+	if prior != nil {
+		for _, key := range resource.Lifecycle.IgnoreChanges {
+			delete(unknown, key)
+			delete(proposed, key)
+			if value, exists := prior[key]; exists {
+				proposed[key] = value
+			}
+		}
+	}
+
+	return proposed, unknown
+}
+
+// withUnknown returns attrs together with the attributes of resource left
+// unknown, as the references apply resolves once their resources exist.
+func withUnknown(attrs map[string]interface{}, resource *ast.Resource, unknown map[string]bool) map[string]interface{} {
+	result := make(map[string]interface{}, len(attrs)+len(unknown))
+	for key, value := range attrs {
+		result[key] = value
+	}
+	for key := range unknown {
+		result[key] = resource.Properties[key]
+	}
+	return result
+}
+
+// refersTo reports whether value contains a reference to one of resources.
+func refersTo(value interface{}, resources map[string]bool) bool {
+	switch v := value.(type) {
+	case *ast.Reference:
+		return resources[v.Resource]
+	case map[string]interface{}:
+		for _, item := range v {
+			if refersTo(item, resources) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if refersTo(item, resources) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// diffAttributes compares the configurable attributes of prior and planned,
+// sorted by name. Attributes the schema declares as computed are left out,
+// as are attributes missing from planned when there is no schema to tell
+// whether they were configured.
+func diffAttributes(prior, planned map[string]interface{}, unknown map[string]bool, schema map[string]*plugin.Attribute) []AttributeDiff {
+	names := make(map[string]bool)
+	for name := range prior {
+		names[name] = true
+	}
+	for name := range planned {
+		names[name] = true
+	}
+	for name := range unknown {
+		names[name] = true
+	}
+
+	var diffs []AttributeDiff
+	for _, name := range sortedKeys(names) {
+		before, hadBefore := prior[name]
+		after, hasAfter := planned[name]
+		configured := hasAfter || unknown[name]
+
+		if schema == nil && !configured {
+			continue
+		}
+		if attr, exists := schema[name]; schema != nil && (!exists || attr.Computed) && !configured {
+			continue
+		}
+
+		diff := AttributeDiff{Name: name, Before: before, After: after, Unknown: unknown[name]}
+		switch {
+		case !hadBefore:
+			diff.Action = "create"
+		case !configured:
+			diff.Action = "delete"
+		case unknown[name] || !reflect.DeepEqual(before, after):
+			diff.Action = "update"
+		default:
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
+// computedAttributes returns the attributes the schema declares as computed
+// that planned leaves unknown, for a resource being created or replaced.
+func computedAttributes(prior, planned map[string]interface{}, schema map[string]*plugin.Attribute) []AttributeDiff {
+	var diffs []AttributeDiff
+	for name, attr := range schema {
+		if _, known := planned[name]; known || !attr.Computed {
+			continue
+		}
+
+		diff := AttributeDiff{Name: name, Action: "create", Unknown: true}
+		if before, exists := prior[name]; exists {
+			diff.Action = "update"
+			diff.Before = before
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+// forcingAttributes returns the names of the diffs that force replacement.
+func forcingAttributes(diffs []AttributeDiff) []string {
+	var names []string
+	for _, diff := range diffs {
+		if diff.ForcesReplacement {
+			names = append(names, diff.Name)
+		}
+	}
+	return names
+}
+
+// resourceSchema returns the attributes the providers declare for
+// resourceType, or nil if no loaded provider declares it.
+func resourceSchema(schemas *plugin.GetSchemaResponse, resourceType string) map[string]*plugin.Attribute {
+	if schemas == nil {
+		return nil
+	}
+	schema, exists := schemas.ResourceSchemas[resourceType]
+	if !exists {
+		schema, exists = schemas.DataSourceSchemas[resourceType]
+	}
+	if !exists || schema.Block == nil {
+		return nil
+	}
+	return schema.Block.Attributes
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func lifecycleState(lifecycle ast.Lifecycle) *ast.Lifecycle {
+	if !lifecycle.PreventDestroy && !lifecycle.CreateBeforeDestroy && len(lifecycle.IgnoreChanges) == 0 {
+		return nil
+	}
+	return &lifecycle
+}
+
+// recordLifecycle copies the configured lifecycle of every resource that is
+// already in state, so that prevent_destroy keeps protecting a resource after
+// it is removed from the configuration.
+func recordLifecycle(program *compiler.Program, currentState *state.State) {
+	for _, resource := range program.Resources {
+		if current, exists := currentState.Resources[resource.Name]; exists {
+			current.Lifecycle = lifecycleState(resource.Lifecycle)
+		}
+	}
 }
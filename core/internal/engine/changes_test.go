@@ -0,0 +1,232 @@
+package engine
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/tblang/core/internal/ast"
+	"github.com/tblang/core/internal/compiler"
+	"github.com/tblang/core/internal/state"
+	"github.com/tblang/core/pkg/plugin"
+)
+
+// plannedActions returns the action planned for each resource in changes,
+// and the attributes forcing each replacement.
+func plannedActions(changes *PlanChanges) (map[string]string, map[string][]string) {
+	actions := make(map[string]string)
+	for action, resources := range map[string][]*state.ResourceState{
+		"create":  changes.Create,
+		"update":  changes.Update,
+		"replace": changes.Replace,
+		"delete":  changes.Delete,
+	} {
+		for _, resource := range resources {
+			actions[resource.Name] = action
+		}
+	}
+
+	forces := make(map[string][]string)
+	for _, resource := range changes.Replace {
+		forces[resource.Name] = forcingAttributes(changes.Diffs[resource.Name])
+	}
+	return actions, forces
+}
+
+func TestCalculateChanges(t *testing.T) {
+	// web only refers to main in its tags, which can be updated in place.
+	configure := func(cidr, name string, lifecycle ast.Lifecycle) []*ast.Resource {
+		return []*ast.Resource{
+			{
+				Name:       "main",
+				Type:       "vpc",
+				Properties: map[string]interface{}{"cidr_block": cidr, "tags": map[string]interface{}{"Name": name}},
+				Lifecycle:  lifecycle,
+			},
+			{
+				Name:       "web",
+				Type:       "subnet",
+				Properties: map[string]interface{}{"cidr_block": "10.0.1.0/24", "tags": map[string]interface{}{"Vpc": ref("main", "id")}},
+			},
+		}
+	}
+	applied := func(extra ...string) map[string]*state.ResourceState {
+		resources := map[string]*state.ResourceState{
+			"main": {Name: "main", Type: "vpc", Attributes: map[string]interface{}{
+				"cidr_block": "10.0.0.0/16",
+				"tags":       map[string]interface{}{"Name": "main"},
+				"id":         "vpc-0a1",
+			}},
+			"web": {Name: "web", Type: "subnet", Dependencies: []string{"main"}, Attributes: map[string]interface{}{
+				"cidr_block": "10.0.1.0/24",
+				"tags":       map[string]interface{}{"Vpc": "vpc-0a1"},
+				"id":         "subnet-0b2",
+			}},
+		}
+		for _, name := range extra {
+			resources[name] = &state.ResourceState{Name: name, Type: "subnet"}
+		}
+		return resources
+	}
+
+	tests := []struct {
+		name       string
+		resources  []*ast.Resource
+		state      map[string]*state.ResourceState
+		noProvider bool
+
+		want    map[string]string
+		forces  map[string][]string
+		planned map[string]map[string]interface{}
+	}{
+		{
+			name:      "create",
+			resources: configure("10.0.0.0/16", "main", ast.Lifecycle{}),
+			state:     map[string]*state.ResourceState{},
+			want:      map[string]string{"main": "create", "web": "create"},
+			forces:    map[string][]string{},
+			planned: map[string]map[string]interface{}{
+				"web": {"tags": map[string]interface{}{"Vpc": ref("main", "id")}},
+			},
+		},
+		{
+			name:      "unchanged",
+			resources: configure("10.0.0.0/16", "main", ast.Lifecycle{}),
+			state:     applied(),
+			want:      map[string]string{},
+			forces:    map[string][]string{},
+		},
+		{
+			// The provider keeps the id it computed in the planned state.
+			name:      "update in place",
+			resources: configure("10.0.0.0/16", "core", ast.Lifecycle{}),
+			state:     applied(),
+			want:      map[string]string{"main": "update"},
+			forces:    map[string][]string{},
+			planned: map[string]map[string]interface{}{
+				"main": {"id": "vpc-0a1", "tags": map[string]interface{}{"Name": "core"}},
+			},
+		},
+		{
+			// web cannot outlive the VPC it refers to, so it is replaced
+			// with it.
+			name:      "force new",
+			resources: configure("10.1.0.0/16", "main", ast.Lifecycle{}),
+			state:     applied(),
+			want:      map[string]string{"main": "replace", "web": "replace"},
+			forces:    map[string][]string{"main": {"cidr_block"}, "web": {"tags"}},
+			planned: map[string]map[string]interface{}{
+				"web": {"tags": map[string]interface{}{"Vpc": ref("main", "id")}},
+			},
+		},
+		{
+			name:      "force new with create before destroy",
+			resources: configure("10.1.0.0/16", "main", ast.Lifecycle{CreateBeforeDestroy: true}),
+			state:     applied(),
+			want:      map[string]string{"main": "replace", "web": "update"},
+			forces:    map[string][]string{"main": {"cidr_block"}},
+		},
+		{
+			name:      "delete",
+			resources: configure("10.0.0.0/16", "main", ast.Lifecycle{}),
+			state:     applied("old"),
+			want:      map[string]string{"old": "delete"},
+			forces:    map[string][]string{},
+		},
+		{
+			// Without a provider to say what can change in place, every
+			// change replaces the resource.
+			name:       "no provider",
+			resources:  configure("10.0.0.0/16", "core", ast.Lifecycle{}),
+			state:      applied(),
+			noProvider: true,
+			want:       map[string]string{"main": "replace", "web": "replace"},
+			forces:     map[string][]string{"main": {"tags"}, "web": {"tags"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var provider plugin.ProviderPlugin = &fakeProvider{}
+			if tt.noProvider {
+				provider = nil
+			}
+			e := newTestEngine(t, provider)
+			program := &compiler.Program{Resources: tt.resources}
+			schemas := &plugin.GetSchemaResponse{ResourceSchemas: testSchemas}
+
+			changes, err := e.calculateChanges(context.Background(), program, &state.State{Resources: tt.state}, schemas)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actions, forces := plannedActions(changes)
+			if !reflect.DeepEqual(actions, tt.want) {
+				t.Errorf("actions = %v, want %v", actions, tt.want)
+			}
+			if !reflect.DeepEqual(forces, tt.forces) {
+				t.Errorf("forced by %v, want %v", forces, tt.forces)
+			}
+
+			planned := make(map[string]*state.ResourceState)
+			for _, resources := range [][]*state.ResourceState{changes.Create, changes.Update, changes.Replace} {
+				for _, resource := range resources {
+					planned[resource.Name] = resource
+				}
+			}
+			for name, attrs := range tt.planned {
+				for key, want := range attrs {
+					if got := planned[name].Attributes[key]; !reflect.DeepEqual(got, want) {
+						t.Errorf("planned %s.%s = %#v, want %#v", name, key, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDiffAttributes(t *testing.T) {
+	schema := testSchemas["vpc"].Block.Attributes
+	prior := map[string]interface{}{
+		"cidr_block": "10.0.0.0/16",
+		"tags":       map[string]interface{}{"Name": "main"},
+		"id":         "vpc-0a1",
+	}
+
+	tests := []struct {
+		name    string
+		planned map[string]interface{}
+		unknown map[string]bool
+		want    []AttributeDiff
+	}{
+		{
+			name:    "computed attributes are left out",
+			planned: map[string]interface{}{"cidr_block": "10.0.0.0/16", "tags": map[string]interface{}{"Name": "main"}},
+		},
+		{
+			name:    "changed and removed",
+			planned: map[string]interface{}{"cidr_block": "10.1.0.0/16", "enable_dns": true},
+			want: []AttributeDiff{
+				{Name: "cidr_block", Action: "update", Before: "10.0.0.0/16", After: "10.1.0.0/16"},
+				{Name: "enable_dns", Action: "create", After: true},
+				{Name: "tags", Action: "delete", Before: map[string]interface{}{"Name": "main"}},
+			},
+		},
+		{
+			name:    "unknown",
+			planned: map[string]interface{}{"cidr_block": "10.0.0.0/16"},
+			unknown: map[string]bool{"tags": true},
+			want: []AttributeDiff{
+				{Name: "tags", Action: "update", Before: map[string]interface{}{"Name": "main"}, Unknown: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffAttributes(prior, tt.planned, tt.unknown, schema); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffs = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,10 @@ import (
 	"github.com/tblang/core/internal/state"
 )
 
+// DefaultParallelism is how many resources are applied at once unless
+// SetParallelism says otherwise.
+const DefaultParallelism = 10
+
 func New() *Engine {
 	workingDir, _ := os.Getwd()
 
@@ -35,5 +39,14 @@ func New() *Engine {
 		stateManager:  state.NewManager(filepath.Join(workingDir, ".tblang")),
 		pluginManager: NewPluginManager(pluginDir),
 		workingDir:    workingDir,
+		parallelism:   DefaultParallelism,
 	}
 }
+
+func (e *Engine) SetInputVariables(vars *compiler.InputVariables) {
+	e.compiler.SetInputVariables(vars)
+}
+
+func (e *Engine) SetParallelism(n int) {
+	e.parallelism = n
+}
@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/tblang/core/internal/ast"
+	"github.com/tblang/core/internal/compiler"
+	"github.com/tblang/core/internal/graph"
+	"github.com/tblang/core/internal/state"
+)
+
+func programGraph(program *compiler.Program) (*graph.DependencyGraph, error) {
+	dg := graph.NewDependencyGraph()
+	for _, resource := range program.Resources {
+		dg.AddResource(resource)
+	}
+	if err := dg.AnalyzeDependencies(); err != nil {
+		return nil, err
+	}
+	return dg, nil
+}
+
+// stateGraph is the dependency graph of resources as recorded in state.
+// Dependencies on resources not among them are left out.
+func stateGraph(resources []*state.ResourceState) (*graph.DependencyGraph, error) {
+	dg := graph.NewDependencyGraph()
+	names := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		dg.AddResource(&ast.Resource{Name: resource.Name, Type: resource.Type})
+		names[resource.Name] = true
+	}
+
+	for _, resource := range resources {
+		for _, dep := range resource.Dependencies {
+			if !names[dep] {
+				continue
+			}
+			if err := dg.AddDependency(resource.Name, dep); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return dg, nil
+}
+
+// recordDependencies records in each of resources what it depends on in dg,
+// so that it can be destroyed in the right order even once it is no longer
+// configured.
+func recordDependencies(dg *graph.DependencyGraph, resources ...*state.ResourceState) {
+	for _, resource := range resources {
+		deps := dg.GetDependencies(resource.Name)
+		if len(deps) == 0 {
+			resource.Dependencies = nil
+			continue
+		}
+		resource.Dependencies = append([]string(nil), deps...)
+		sort.Strings(resource.Dependencies)
+	}
+}
+
+// destroyNode is the name of the node destroying resource name in the graph
+// walked by apply, apart from the node creating or updating it.
+func destroyNode(name string) string {
+	return name + " (destroy)"
+}
+
+// addDestroys adds to dg, the graph of the configuration, a node destroying
+// each resource in changes.Delete and the previous instance of each resource
+// in changes.Replace. The previous instance is destroyed before the new one
+// is created or, with create_before_destroy, once the new one has been
+// created and the resources depending on it have moved to it. By the
+// dependencies recorded in state, a resource is destroyed after the ones
+// that depended on it have been destroyed or updated.
+func addDestroys(dg *graph.DependencyGraph, changes *PlanChanges, recorded map[string][]string) error {
+	destroyed := make(map[string]bool)
+	for _, resource := range changes.Delete {
+		destroyed[resource.Name] = true
+		dg.AddResource(&ast.Resource{Name: destroyNode(resource.Name), Type: resource.Type})
+	}
+
+	// The dependents in the configuration, read before destroy nodes
+	// become dependents too.
+	dependents := make(map[string][]string, len(changes.Replace))
+	for _, resource := range changes.Replace {
+		dependents[resource.Name] = append([]string(nil), dg.GetDependents(resource.Name)...)
+	}
+
+	var errs []error
+	replacedFirst := make(map[string]bool)
+	for _, resource := range changes.Replace {
+		name := resource.Name
+		destroyed[name] = true
+		dg.AddResource(&ast.Resource{Name: destroyNode(name), Type: resource.Type})
+
+		if resource.Lifecycle == nil || !resource.Lifecycle.CreateBeforeDestroy {
+			replacedFirst[name] = true
+			errs = append(errs, dg.AddDependency(name, destroyNode(name)))
+			continue
+		}
+		errs = append(errs, dg.AddDependency(destroyNode(name), name))
+		for _, dependent := range dependents[name] {
+			errs = append(errs, dg.AddDependency(destroyNode(name), dependent))
+		}
+	}
+
+	for name, deps := range recorded {
+		node := name
+		if destroyed[name] {
+			node = destroyNode(name)
+		}
+		for _, dep := range deps {
+			// A resource kept in place that refers to one replaced first is
+			// planned as a replacement too, so one that still depends on it
+			// does so by depends_on alone, and is left to the new instance.
+			if !destroyed[dep] || (!destroyed[name] && replacedFirst[dep]) {
+				continue
+			}
+			errs = append(errs, dg.AddDependency(destroyNode(dep), node))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -2,8 +2,11 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 
+	"github.com/tblang/core/internal/compiler"
 	"github.com/tblang/core/internal/state"
 )
 
@@ -12,11 +15,7 @@ func (e *Engine) Destroy(ctx context.Context, filename string) error {
 
 	program, err := e.compiler.CompileFile(filename)
 	if err != nil {
-		return fmt.Errorf("compilation failed: %w", err)
-	}
-
-	if err := e.loadAndConfigurePlugins(ctx, program); err != nil {
-		return fmt.Errorf("failed to load plugins: %w", err)
+		return fmt.Errorf("compilation failed:\n%w", err)
 	}
 
 	currentState, err := e.stateManager.LoadState()
@@ -25,6 +24,14 @@ func (e *Engine) Destroy(ctx context.Context, filename string) error {
 		return nil
 	}
 
+	if err := checkPreventDestroy(program, currentState); err != nil {
+		return err
+	}
+
+	if err := e.loadAndConfigurePlugins(ctx, program); err != nil {
+		return fmt.Errorf("failed to load plugins: %w", err)
+	}
+
 	fmt.Println("\nThe following resources will be destroyed:")
 	for name, resource := range currentState.Resources {
 		fmt.Printf("  - %s (%s)\n", name, resource.Type)
@@ -39,7 +46,21 @@ func (e *Engine) Destroy(ctx context.Context, filename string) error {
 		return nil
 	}
 
-	if err := e.destroyResources(ctx, currentState); err != nil {
+	// Resources applied before their dependencies were recorded in state
+	// get them from the configuration.
+	dg, err := programGraph(program)
+	if err != nil {
+		return err
+	}
+	resources := make([]*state.ResourceState, 0, len(currentState.Resources))
+	for _, resource := range currentState.Resources {
+		if resource.Dependencies == nil {
+			recordDependencies(dg, resource)
+		}
+		resources = append(resources, resource)
+	}
+
+	if err := e.destroyResources(ctx, resources, currentState); err != nil {
 		return fmt.Errorf("failed to destroy resources: %w", err)
 	}
 
@@ -47,71 +68,69 @@ func (e *Engine) Destroy(ctx context.Context, filename string) error {
 	return nil
 }
 
-func (e *Engine) destroyResources(ctx context.Context, currentState *state.State) error {
-
-	var ec2Instances []*state.ResourceState
-	var natGateways []*state.ResourceState
-	var eips []*state.ResourceState
-	var routeTables []*state.ResourceState
-	var securityGroups []*state.ResourceState
-	var subnets []*state.ResourceState
-	var internetGateways []*state.ResourceState
-	var vpcs []*state.ResourceState
-	var dataSources []*state.ResourceState
-	var others []*state.ResourceState
+// destroyResources destroys resources in reverse dependency order, each once
+// the resources that depended on it are gone, up to e.parallelism at once. A
+// resource that fails to be destroyed stays in state, and so do the
+// resources it depends on.
+func (e *Engine) destroyResources(ctx context.Context, resources []*state.ResourceState, currentState *state.State) error {
+	dg, err := stateGraph(resources)
+	if err != nil {
+		return err
+	}
 
-	for _, resource := range currentState.Resources {
-		switch resource.Type {
-		case "ec2":
-			ec2Instances = append(ec2Instances, resource)
-		case "nat_gateway":
-			natGateways = append(natGateways, resource)
-		case "eip":
-			eips = append(eips, resource)
-		case "route_table":
-			routeTables = append(routeTables, resource)
-		case "security_group":
-			securityGroups = append(securityGroups, resource)
-		case "subnet":
-			subnets = append(subnets, resource)
-		case "internet_gateway":
-			internetGateways = append(internetGateways, resource)
-		case "vpc":
-			vpcs = append(vpcs, resource)
-		case "data_ami", "data_vpc", "data_subnet", "data_availability_zones", "data_caller_identity":
-			dataSources = append(dataSources, resource)
-		default:
-			others = append(others, resource)
-		}
+	byName := make(map[string]*state.ResourceState, len(resources))
+	for _, resource := range resources {
+		byName[resource.Name] = resource
 	}
 
-	orderedResources := append([]*state.ResourceState{}, ec2Instances...)
-	orderedResources = append(orderedResources, natGateways...)
-	orderedResources = append(orderedResources, eips...)
-	orderedResources = append(orderedResources, routeTables...)
-	orderedResources = append(orderedResources, securityGroups...)
-	orderedResources = append(orderedResources, subnets...)
-	orderedResources = append(orderedResources, internetGateways...)
-	orderedResources = append(orderedResources, others...)
-	orderedResources = append(orderedResources, vpcs...)
+	errs := dg.WalkReverse(e.parallelism, func(name string) error {
+		return e.destroyResource(ctx, byName[name], currentState)
+	})
 
-	orderedResources = append(orderedResources, dataSources...)
+	return walkErrors(errs)
+}
 
-	for _, resource := range orderedResources {
-		warningColor.Printf("Destroying %s (%s)...\n", resource.Name, resource.Type)
+// destroyResource destroys resource and removes it from state.
+func (e *Engine) destroyResource(ctx context.Context, resource *state.ResourceState, currentState *state.State) error {
+	warningColor.Printf("\nDestroying %s (%s)...\n", resource.Name, resource.Type)
 
-		if err := e.destroyResourceWithPlugin(ctx, resource); err != nil {
-			errorColor.Printf("  Error: failed to destroy %s: %v\n", resource.Name, err)
+	if err := e.destroyResourceWithPlugin(ctx, resource); err != nil {
+		errorColor.Printf("  ✗ Failed to destroy %s: %v\n", resource.Name, err)
+		return fmt.Errorf("failed to destroy %s: %w", resource.Name, err)
+	}
+	successColor.Printf("  ✓ Destroyed %s (%s)\n", resource.Name, resource.Type)
 
-		} else {
-			successColor.Printf("Deleted %s (%s)\n", resource.Name, resource.Type)
-		}
+	return e.updateState(currentState, func(resources map[string]*state.ResourceState) {
+		delete(resources, resource.Name)
+	})
+}
 
-		delete(currentState.Resources, resource.Name)
-		if err := e.stateManager.SaveState(currentState); err != nil {
-			return fmt.Errorf("failed to save state: %w", err)
+// checkPreventDestroy refuses to destroy resources protected by
+// lifecycle.prevent_destroy, either in the configuration or, for resources
+// no longer configured, in state.
+func checkPreventDestroy(program *compiler.Program, currentState *state.State) error {
+	configured := make(map[string]bool)
+	var protected []string
+	for _, resource := range program.Resources {
+		configured[resource.Name] = true
+		if _, exists := currentState.Resources[resource.Name]; exists && resource.Lifecycle.PreventDestroy {
+			protected = append(protected, resource.Name)
+		}
+	}
+	for name, resource := range currentState.Resources {
+		if !configured[name] && resource.Lifecycle != nil && resource.Lifecycle.PreventDestroy {
+			protected = append(protected, name)
 		}
 	}
 
-	return nil
+	if len(protected) == 0 {
+		return nil
+	}
+
+	sort.Strings(protected)
+	errs := make([]error, len(protected))
+	for i, name := range protected {
+		errs[i] = fmt.Errorf("cannot destroy %s: lifecycle.prevent_destroy is set", name)
+	}
+	return errors.Join(errs...)
 }
@@ -13,7 +13,7 @@ import (
 func (e *Engine) Graph(ctx context.Context, filename string) error {
 	program, err := e.compiler.CompileFile(filename)
 	if err != nil {
-		return fmt.Errorf("compilation failed: %w", err)
+		return fmt.Errorf("compilation failed:\n%w", err)
 	}
 
 	e.displayVisualGraph(program)
@@ -37,6 +37,7 @@ func (e *Engine) displayVisualGraph(program *compiler.Program) {
 				}
 			}
 		}
+		deps = append(deps, resource.DependsOn...)
 		dependencies[resource.Name] = e.removeDuplicates(deps)
 	}
 
@@ -77,7 +78,7 @@ func (e *Engine) displayVisualGraph(program *compiler.Program) {
 		}
 		resourceColor.Print(resource.Name)
 	}
-	fmt.Println("\n")
+	fmt.Print("\n\n")
 }
 
 func (e *Engine) findResourceReferences(value interface{}, resources []*ast.Resource) []string {
@@ -89,10 +90,9 @@ func (e *Engine) findResourceReferences(value interface{}, resources []*ast.Reso
 	}
 
 	switch v := value.(type) {
-	case string:
-
-		if resourceNames[v] {
-			refs = append(refs, v)
+	case *ast.Reference:
+		if resourceNames[v.Resource] {
+			refs = append(refs, v.Resource)
 		}
 	case map[string]interface{}:
 		for _, val := range v {
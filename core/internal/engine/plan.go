@@ -3,32 +3,54 @@ package engine
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/tblang/core/internal/ast"
 	"github.com/tblang/core/internal/state"
 )
 
-func (e *Engine) Plan(ctx context.Context, filename string) error {
+// Plan shows the changes applying filename would make. If out is set, the
+// plan is also saved there for apply to execute.
+func (e *Engine) Plan(ctx context.Context, filename, out string) error {
 	fmt.Println("Planning infrastructure changes...")
 
 	program, err := e.compiler.CompileFile(filename)
 	if err != nil {
-		return fmt.Errorf("compilation failed: %w", err)
+		return fmt.Errorf("compilation failed:\n%w", err)
 	}
 
 	if err := e.loadRequiredPlugins(ctx, program); err != nil {
 		return fmt.Errorf("failed to load plugins: %w", err)
 	}
 
+	schemas, err := e.validateSchemas(ctx, program)
+	if err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
 	currentState, err := e.stateManager.LoadState()
 	if err != nil {
 		fmt.Println("No existing state found, will create new infrastructure")
 		currentState = &state.State{Resources: make(map[string]*state.ResourceState)}
 	}
 
-	changes := e.calculateChanges(program, currentState)
+	changes, err := e.calculateChanges(ctx, program, currentState, schemas)
+	if err != nil {
+		return fmt.Errorf("plan failed: %w", err)
+	}
 
 	e.displayPlan(changes)
 
+	if out != "" {
+		if err := writePlanFile(out, program, currentState, changes); err != nil {
+			return err
+		}
+		successColor.Printf("\nSaved the plan to %s\n", out)
+		fmt.Printf("To perform exactly these actions, run: tblang apply %s\n", out)
+	}
+
 	return nil
 }
 
@@ -40,14 +62,30 @@ func (e *Engine) displayPlan(changes *PlanChanges) {
 		for _, resource := range changes.Create {
 			createColor.Printf("  + %s ", resource.Name)
 			fmt.Printf("(%s)\n", resource.Type)
+			e.displayDiffs(changes.Diffs[resource.Name])
 		}
 	}
 
 	if len(changes.Update) > 0 {
-		updateColor.Printf("\nResources to update (%d):\n", len(changes.Update))
+		updateColor.Printf("\nResources to update in place (%d):\n", len(changes.Update))
 		for _, resource := range changes.Update {
 			updateColor.Printf("  ~ %s ", resource.Name)
 			fmt.Printf("(%s)\n", resource.Type)
+			e.displayDiffs(changes.Diffs[resource.Name])
+		}
+	}
+
+	if len(changes.Replace) > 0 {
+		deleteColor.Printf("\nResources to replace (%d):\n", len(changes.Replace))
+		for _, resource := range changes.Replace {
+			deleteColor.Print("  -/+ ")
+			updateColor.Printf("%s ", resource.Name)
+			fmt.Printf("(%s)", resource.Type)
+			if resource.Lifecycle != nil && resource.Lifecycle.CreateBeforeDestroy {
+				fmt.Print(" [create before destroy]")
+			}
+			fmt.Println()
+			e.displayDiffs(changes.Diffs[resource.Name])
 		}
 	}
 
@@ -59,7 +97,65 @@ func (e *Engine) displayPlan(changes *PlanChanges) {
 		}
 	}
 
-	if len(changes.Create) == 0 && len(changes.Update) == 0 && len(changes.Delete) == 0 {
+	if len(changes.Create) == 0 && len(changes.Update) == 0 && len(changes.Replace) == 0 && len(changes.Delete) == 0 {
 		infoColor.Println("\nNo changes. Infrastructure is up-to-date.")
 	}
 }
+
+// displayDiffs prints one line per changed attribute, such as
+//
+//	~ cidr_block: "10.0.1.0/24" -> "10.0.9.0/24" # forces replacement
+func (e *Engine) displayDiffs(diffs []AttributeDiff) {
+	for _, diff := range diffs {
+		after := formatPlanValue(diff.After)
+		if diff.Unknown {
+			after = "(known after apply)"
+		}
+
+		switch diff.Action {
+		case "create":
+			createColor.Printf("      + %s", diff.Name)
+			fmt.Printf(": %s", after)
+		case "delete":
+			deleteColor.Printf("      - %s", diff.Name)
+			fmt.Printf(": %s", formatPlanValue(diff.Before))
+		default:
+			updateColor.Printf("      ~ %s", diff.Name)
+			fmt.Printf(": %s -> %s", formatPlanValue(diff.Before), after)
+		}
+
+		if diff.ForcesReplacement {
+			deleteColor.Print(" # forces replacement")
+		}
+		fmt.Println()
+	}
+}
+
+func formatPlanValue(value interface{}) string {
+	switch v := value.(type) {
+	case *ast.Reference:
+		return "(known after apply)"
+	case string:
+		return strconv.Quote(v)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = formatPlanValue(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		items := make([]string, len(keys))
+		for i, key := range keys {
+			items[i] = key + " = " + formatPlanValue(v[key])
+		}
+		return "{ " + strings.Join(items, ", ") + " }"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
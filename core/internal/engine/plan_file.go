@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/tblang/core/internal/ast"
+	"github.com/tblang/core/internal/compiler"
+	"github.com/tblang/core/internal/state"
+)
+
+const (
+	planFileFormat  = "tblang-plan"
+	planFileVersion = 3
+)
+
+// savedPlan is the content of a plan file written by plan -out. It holds the
+// configuration the plan was made from, with input variables and expressions
+// already evaluated, so that applying it needs neither the source files nor
+// a new diff.
+type savedPlan struct {
+	// Format marks the file as a plan, whatever its name, and Version is the
+	// version of its layout.
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+
+	// StateSerial is the serial of the state the plan was made against, and
+	// Hash the SHA-256 of Config and Changes, so that a plan edited since it
+	// was written is refused rather than applied.
+	StateSerial int64           `json:"state_serial"`
+	Hash        string          `json:"hash"`
+	Config      json.RawMessage `json:"config"`
+	Changes     json.RawMessage `json:"changes"`
+}
+
+type savedConfig struct {
+	CloudVendors map[string]*ast.CloudVendor `json:"cloud_vendors"`
+	Resources    []*ast.Resource             `json:"resources"`
+}
+
+func writePlanFile(filename string, program *compiler.Program, currentState *state.State, changes *PlanChanges) error {
+	config, err := json.Marshal(savedConfig{
+		CloudVendors: program.CloudVendors,
+		Resources:    program.Resources,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	encodedChanges, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode changes: %w", err)
+	}
+
+	data, err := json.MarshalIndent(&savedPlan{
+		Format:      planFileFormat,
+		Version:     planFileVersion,
+		StateSerial: currentState.Serial,
+		Hash:        planHash(config, encodedChanges),
+		Config:      config,
+		Changes:     encodedChanges,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode plan: %w", err)
+	}
+
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write plan file: %w", err)
+	}
+	return nil
+}
+
+// readPlanFile reads a plan written by writePlanFile and returns it together
+// with the program it was made from and the changes it will make.
+func readPlanFile(filename string) (*savedPlan, *compiler.Program, *PlanChanges, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read plan file: %w", err)
+	}
+
+	var plan savedPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, nil, nil, fmt.Errorf("%s is not a plan file: %w", filename, err)
+	}
+	if plan.Format != planFileFormat {
+		return nil, nil, nil, fmt.Errorf("%s is not a plan file", filename)
+	}
+	if plan.Version != planFileVersion {
+		return nil, nil, nil, fmt.Errorf("%s has unsupported plan file version %d", filename, plan.Version)
+	}
+	if plan.Hash != planHash(plan.Config, plan.Changes) {
+		return nil, nil, nil, fmt.Errorf("%s is corrupt: its content does not match the recorded hash", filename)
+	}
+
+	var config savedConfig
+	if err := json.Unmarshal(plan.Config, &config); err != nil {
+		return nil, nil, nil, fmt.Errorf("%s is corrupt: %w", filename, err)
+	}
+	var changes PlanChanges
+	if err := json.Unmarshal(plan.Changes, &changes); err != nil {
+		return nil, nil, nil, fmt.Errorf("%s is corrupt: %w", filename, err)
+	}
+
+	for _, resource := range config.Resources {
+		resource.Properties = decodeAttributes(resource.Properties)
+	}
+	for _, resources := range [][]*state.ResourceState{changes.Create, changes.Update, changes.Replace, changes.Delete} {
+		for _, resource := range resources {
+			resource.Attributes = decodeAttributes(resource.Attributes)
+		}
+	}
+	for _, diffs := range changes.Diffs {
+		for i := range diffs {
+			diffs[i].Before = ast.DecodeReferences(diffs[i].Before)
+			diffs[i].After = ast.DecodeReferences(diffs[i].After)
+		}
+	}
+
+	program := &compiler.Program{
+		CloudVendors: config.CloudVendors,
+		Resources:    config.Resources,
+	}
+	return &plan, program, &changes, nil
+}
+
+// IsPlanFile reports whether filename holds a plan written by plan -out,
+// judging by its content rather than its name.
+func IsPlanFile(filename string) bool {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return false
+	}
+	var header struct {
+		Format string `json:"format"`
+	}
+	return json.Unmarshal(data, &header) == nil && header.Format == planFileFormat
+}
+
+// planHash hashes the compact form of config and changes, so that
+// reindenting the plan file does not change it.
+func planHash(config, changes []byte) string {
+	hash := sha256.New()
+	for _, part := range [][]byte{config, changes} {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, part); err != nil {
+			return ""
+		}
+		hash.Write(compact.Bytes())
+		hash.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func decodeAttributes(attrs map[string]interface{}) map[string]interface{} {
+	decoded, _ := ast.DecodeReferences(attrs).(map[string]interface{})
+	return decoded
+}
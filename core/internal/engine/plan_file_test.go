@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tblang/core/internal/ast"
+	"github.com/tblang/core/internal/compiler"
+	"github.com/tblang/core/internal/state"
+)
+
+func TestPlanFileRoundTrip(t *testing.T) {
+	subnetID := &ast.Reference{Resource: `net.fe["10.0.1.0/24"]`, Attribute: "subnet_id"}
+	program := &compiler.Program{
+		Resources: []*ast.Resource{{
+			Name:       "web",
+			Type:       "ec2",
+			Properties: map[string]interface{}{"subnet_id": subnetID},
+		}},
+	}
+	changes := &PlanChanges{
+		Create: []*state.ResourceState{{
+			Name:       "web",
+			Type:       "ec2",
+			Attributes: map[string]interface{}{"subnet_id": subnetID},
+		}},
+		Delete: []*state.ResourceState{{
+			Name:       "old",
+			Type:       "ec2",
+			Attributes: map[string]interface{}{"subnet_id": subnetID},
+		}},
+	}
+
+	// The name says nothing about the content.
+	filename := filepath.Join(t.TempDir(), "plan.json")
+	if err := writePlanFile(filename, program, &state.State{Serial: 3}, changes); err != nil {
+		t.Fatal(err)
+	}
+	if !IsPlanFile(filename) {
+		t.Fatalf("%s is not recognized as a plan file", filename)
+	}
+
+	plan, read, readChanges, err := readPlanFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.StateSerial != 3 {
+		t.Errorf("state serial = %d, want 3", plan.StateSerial)
+	}
+	if got := read.Resources[0].Properties["subnet_id"]; !reflect.DeepEqual(got, subnetID) {
+		t.Errorf("config subnet_id = %#v, want %#v", got, subnetID)
+	}
+	if got := readChanges.Create[0].Attributes["subnet_id"]; !reflect.DeepEqual(got, subnetID) {
+		t.Errorf("planned subnet_id = %#v, want %#v", got, subnetID)
+	}
+	if got := readChanges.Delete[0].Attributes["subnet_id"]; !reflect.DeepEqual(got, subnetID) {
+		t.Errorf("deleted subnet_id = %#v, want %#v", got, subnetID)
+	}
+}
+
+func TestPlanFileTampered(t *testing.T) {
+	program := &compiler.Program{
+		Resources: []*ast.Resource{{Name: "main", Type: "vpc", Properties: map[string]interface{}{"cidr_block": "10.0.0.0/16"}}},
+	}
+	changes := &PlanChanges{
+		Create: []*state.ResourceState{{Name: "main", Type: "vpc", Attributes: map[string]interface{}{"cidr_block": "10.0.0.0/16"}}},
+	}
+	filename := filepath.Join(t.TempDir(), "plan.tbplan")
+	if err := writePlanFile(filename, program, &state.State{}, changes); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(t *testing.T, content []byte) string {
+		t.Helper()
+		filename := filepath.Join(t.TempDir(), "plan.tbplan")
+		if err := os.WriteFile(filename, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return filename
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := readPlanFile(write(t, compact.Bytes())); err != nil {
+		t.Errorf("the reindented plan was refused: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		edit func(plan map[string]interface{})
+	}{
+		{
+			name: "configuration",
+			edit: func(plan map[string]interface{}) {
+				resource := plan["config"].(map[string]interface{})["resources"].([]interface{})[0].(map[string]interface{})
+				resource["properties"].(map[string]interface{})["cidr_block"] = "0.0.0.0/0"
+			},
+		},
+		{
+			name: "changes",
+			edit: func(plan map[string]interface{}) {
+				create := plan["changes"].(map[string]interface{})["create"].([]interface{})[0].(map[string]interface{})
+				create["attributes"].(map[string]interface{})["cidr_block"] = "0.0.0.0/0"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var plan map[string]interface{}
+			if err := json.Unmarshal(data, &plan); err != nil {
+				t.Fatal(err)
+			}
+			tt.edit(plan)
+			edited, err := json.Marshal(plan)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, _, _, err = readPlanFile(write(t, edited))
+			if err == nil || !strings.Contains(err.Error(), "does not match the recorded hash") {
+				t.Errorf("error = %v, want a hash mismatch", err)
+			}
+		})
+	}
+}
+
+func TestIsPlanFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.tbplan":  "declare v = vpc(\"main\", {});\n",
+		"other.json":   `{"format": "something-else", "version": 2}`,
+		"empty.tbplan": "",
+	}
+	for name, content := range files {
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if IsPlanFile(filename) {
+			t.Errorf("%s is recognized as a plan file", name)
+		}
+	}
+	if IsPlanFile(filepath.Join(dir, "missing.tbplan")) {
+		t.Error("a missing file is recognized as a plan file")
+	}
+}
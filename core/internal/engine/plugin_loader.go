@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/tblang/core/internal/ast"
 	"github.com/tblang/core/internal/compiler"
+	"github.com/tblang/core/pkg/plugin"
 )
 
 func (e *Engine) loadRequiredPlugins(ctx context.Context, program *compiler.Program) error {
@@ -61,3 +63,95 @@ func (e *Engine) loadAndConfigurePlugins(ctx context.Context, program *compiler.
 
 	return nil
 }
+
+// validateSchemas checks every resource in program against the schemas of
+// the configured providers and returns those schemas. Providers whose plugin
+// cannot be loaded are skipped with a warning, so that plan keeps working
+// without plugins, and the resources of the others are still checked.
+func (e *Engine) validateSchemas(ctx context.Context, program *compiler.Program) (*plugin.GetSchemaResponse, error) {
+	schemas := newSchemaSet()
+	skipped := false
+
+	for providerName := range program.CloudVendors {
+		pluginInstance, err := e.pluginManager.LoadPlugin(ctx, providerName)
+		if err != nil {
+			warningColor.Printf("Skipping schema validation for %s: %v\n", providerName, err)
+			skipped = true
+			continue
+		}
+
+		if err := addSchemas(ctx, schemas, providerName, pluginInstance); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(program.CloudVendors) == 0 {
+		return schemas, nil
+	}
+
+	checked := program
+	if skipped {
+		// A resource of a skipped provider cannot be told apart from one
+		// of a type no provider supports, so only the resources of the
+		// loaded providers are checked.
+		checked = &compiler.Program{Resources: supportedResources(program.Resources, schemas)}
+	}
+	return schemas, e.compiler.CheckResourceSchemas(checked, schemas)
+}
+
+// supportedResources returns the resources whose type is in schemas.
+func supportedResources(resources []*ast.Resource, schemas *plugin.GetSchemaResponse) []*ast.Resource {
+	var supported []*ast.Resource
+	for _, resource := range resources {
+		if resourceSchema(schemas, resource.Type) != nil {
+			supported = append(supported, resource)
+		}
+	}
+	return supported
+}
+
+// Schemas returns the merged resource and data source schemas of every
+// discovered plugin. Plugins that cannot be loaded are left out.
+func (e *Engine) Schemas(ctx context.Context) *plugin.GetSchemaResponse {
+	schemas := newSchemaSet()
+
+	for _, providerName := range e.pluginManager.ListPlugins() {
+		pluginInstance, err := e.pluginManager.LoadPlugin(ctx, providerName)
+		if err != nil {
+			warningColor.Printf("Skipping plugin %s: %v\n", providerName, err)
+			continue
+		}
+		if err := addSchemas(ctx, schemas, providerName, pluginInstance); err != nil {
+			warningColor.Printf("Skipping plugin %s: %v\n", providerName, err)
+		}
+	}
+
+	return schemas
+}
+
+func newSchemaSet() *plugin.GetSchemaResponse {
+	return &plugin.GetSchemaResponse{
+		ResourceSchemas:   make(map[string]*plugin.Schema),
+		DataSourceSchemas: make(map[string]*plugin.Schema),
+	}
+}
+
+func addSchemas(ctx context.Context, schemas *plugin.GetSchemaResponse, providerName string, pluginInstance *Plugin) error {
+	resp, err := pluginInstance.Client.GetSchema(ctx, &plugin.GetSchemaRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get schema from %s: %w", providerName, err)
+	}
+	for _, diag := range resp.Diagnostics {
+		if diag.Severity == "error" {
+			return fmt.Errorf("failed to get schema from %s: %s", providerName, diag.Summary)
+		}
+	}
+
+	for name, schema := range resp.ResourceSchemas {
+		schemas.ResourceSchemas[name] = schema
+	}
+	for name, schema := range resp.DataSourceSchemas {
+		schemas.DataSourceSchemas[name] = schema
+	}
+	return nil
+}
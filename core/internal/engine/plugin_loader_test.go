@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/tblang/core/internal/ast"
+	"github.com/tblang/core/internal/compiler"
+)
+
+func TestValidateSchemas(t *testing.T) {
+	resources := []*ast.Resource{
+		{Name: "main", Type: "vpc", Properties: map[string]interface{}{}},
+		{Name: "assets", Type: "storage_bucket", Properties: map[string]interface{}{}},
+	}
+
+	tests := []struct {
+		name    string
+		vendors []string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "all providers loaded",
+			vendors: []string{"aws"},
+			want:    []string{"vpc main is missing required attribute cidr_block", "no provider supports resource type storage_bucket"},
+		},
+		{
+			// gcp has no plugin, so its bucket cannot be checked, but the
+			// aws resources still are.
+			name:    "provider missing",
+			vendors: []string{"aws", "gcp"},
+			want:    []string{"vpc main is missing required attribute cidr_block"},
+			notWant: []string{"storage_bucket"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := &compiler.Program{
+				CloudVendors: make(map[string]*ast.CloudVendor),
+				Resources:    resources,
+			}
+			for _, name := range tt.vendors {
+				program.CloudVendors[name] = &ast.CloudVendor{Name: name}
+			}
+
+			e := newTestEngine(t, &fakeProvider{})
+			schemas, err := e.validateSchemas(context.Background(), program)
+			if err == nil {
+				t.Fatal("validateSchemas succeeded")
+			}
+			if schemas.ResourceSchemas["vpc"] == nil {
+				t.Error("the aws schemas were not returned")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not report %q", err, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(err.Error(), notWant) {
+					t.Errorf("error %q reports %q", err, notWant)
+				}
+			}
+		})
+	}
+}
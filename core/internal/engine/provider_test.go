@@ -0,0 +1,137 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/tblang/core/internal/compiler"
+	"github.com/tblang/core/internal/state"
+	"github.com/tblang/core/pkg/plugin"
+)
+
+// testSchemas are the resource schemas of fakeProvider. Changing a ForceNew
+// attribute replaces the resource, and id is computed on create.
+var testSchemas = map[string]*plugin.Schema{
+	"vpc": {Block: &plugin.SchemaBlock{Attributes: map[string]*plugin.Attribute{
+		"cidr_block": {Type: "string", Required: true, ForceNew: true},
+		"enable_dns": {Type: "bool", Optional: true},
+		"tags":       {Type: "map", Optional: true},
+		"id":         {Type: "string", Computed: true},
+	}}},
+	"subnet": {Block: &plugin.SchemaBlock{Attributes: map[string]*plugin.Attribute{
+		"vpc_id":     {Type: "string", Required: true, ForceNew: true},
+		"cidr_block": {Type: "string", Required: true, ForceNew: true},
+		"tags":       {Type: "map", Optional: true},
+		"id":         {Type: "string", Computed: true},
+	}}},
+}
+
+// fakeProvider plans and applies changes in memory, the way the AWS provider
+// does, and records the apply requests it receives.
+type fakeProvider struct {
+	mu      sync.Mutex
+	applied []*plugin.ApplyResourceChangeRequest
+	created int
+}
+
+func (p *fakeProvider) GetSchema(ctx context.Context, req *plugin.GetSchemaRequest) (*plugin.GetSchemaResponse, error) {
+	return &plugin.GetSchemaResponse{ResourceSchemas: testSchemas}, nil
+}
+
+func (p *fakeProvider) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	return &plugin.ConfigureResponse{}, nil
+}
+
+func (p *fakeProvider) PlanResourceChange(ctx context.Context, req *plugin.PlanResourceChangeRequest) (*plugin.PlanResourceChangeResponse, error) {
+	proposed := req.ProposedNewState.(map[string]interface{})
+	prior, exists := req.PriorState.(map[string]interface{})
+	if !exists {
+		return &plugin.PlanResourceChangeResponse{PlannedState: proposed}, nil
+	}
+
+	var requiresReplace []string
+	for name, attr := range testSchemas[req.TypeName].Block.Attributes {
+		if attr.ForceNew && !reflect.DeepEqual(prior[name], proposed[name]) {
+			requiresReplace = append(requiresReplace, name)
+		}
+	}
+	if len(requiresReplace) > 0 {
+		sort.Strings(requiresReplace)
+		return &plugin.PlanResourceChangeResponse{PlannedState: proposed, RequiresReplace: requiresReplace}, nil
+	}
+
+	// An update keeps the computed id.
+	planned := make(map[string]interface{}, len(proposed)+1)
+	for name, value := range proposed {
+		planned[name] = value
+	}
+	planned["id"] = prior["id"]
+	return &plugin.PlanResourceChangeResponse{PlannedState: planned}, nil
+}
+
+func (p *fakeProvider) ApplyResourceChange(ctx context.Context, req *plugin.ApplyResourceChangeRequest) (*plugin.ApplyResourceChangeResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.applied = append(p.applied, req)
+
+	planned, ok := req.PlannedState.(map[string]interface{})
+	if !ok {
+		return &plugin.ApplyResourceChangeResponse{}, nil
+	}
+	newState := make(map[string]interface{}, len(planned)+1)
+	for name, value := range planned {
+		newState[name] = value
+	}
+	if _, exists := newState["id"]; !exists {
+		p.created++
+		newState["id"] = fmt.Sprintf("%s-%d", req.TypeName, p.created)
+	}
+	return &plugin.ApplyResourceChangeResponse{NewState: newState}, nil
+}
+
+func (p *fakeProvider) ReadResource(ctx context.Context, req *plugin.ReadResourceRequest) (*plugin.ReadResourceResponse, error) {
+	return &plugin.ReadResourceResponse{NewState: req.CurrentState}, nil
+}
+
+func (p *fakeProvider) ImportResource(ctx context.Context, req *plugin.ImportResourceRequest) (*plugin.ImportResourceResponse, error) {
+	return &plugin.ImportResourceResponse{}, nil
+}
+
+func (p *fakeProvider) ValidateResourceConfig(ctx context.Context, req *plugin.ValidateResourceConfigRequest) (*plugin.ValidateResourceConfigResponse, error) {
+	return &plugin.ValidateResourceConfigResponse{}, nil
+}
+
+// request returns the last apply request the provider received for a
+// resource of typeName.
+func (p *fakeProvider) request(t *testing.T, typeName string) *plugin.ApplyResourceChangeRequest {
+	t.Helper()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := len(p.applied) - 1; i >= 0; i-- {
+		if p.applied[i].TypeName == typeName {
+			return p.applied[i]
+		}
+	}
+	t.Fatalf("no %s was applied", typeName)
+	return nil
+}
+
+// newTestEngine returns an engine whose aws provider is provider, keeping its
+// state in a temporary directory.
+func newTestEngine(t *testing.T, provider plugin.ProviderPlugin) *Engine {
+	t.Helper()
+	pluginManager := NewPluginManager(t.TempDir())
+	if provider != nil {
+		pluginManager.plugins["aws"] = &Plugin{Name: "aws", Client: provider, configured: true}
+	}
+	return &Engine{
+		compiler:      compiler.New(),
+		stateManager:  state.NewManager(t.TempDir()),
+		pluginManager: pluginManager,
+		parallelism:   DefaultParallelism,
+	}
+}
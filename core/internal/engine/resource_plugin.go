@@ -8,20 +8,43 @@ import (
 	"github.com/tblang/core/pkg/plugin"
 )
 
-func (e *Engine) createResourceWithPlugin(ctx context.Context, resource *state.ResourceState) (interface{}, error) {
+func (e *Engine) createResourceWithPlugin(ctx context.Context, resource *state.ResourceState, config map[string]interface{}, currentState *state.State) (interface{}, error) {
+	return e.applyResourceWithPlugin(ctx, resource, config, nil, currentState)
+}
+
+// updateResourceWithPlugin changes prior in place to the planned state of
+// resource.
+func (e *Engine) updateResourceWithPlugin(ctx context.Context, prior, resource *state.ResourceState, config map[string]interface{}, currentState *state.State) (interface{}, error) {
+	return e.applyResourceWithPlugin(ctx, resource, config, prior.Attributes, currentState)
+}
+
+// applyResourceWithPlugin asks the provider to make the resource match its
+// planned attributes, which may differ from config, its configured
+// attributes, in those the provider or ignore_changes decided.
+func (e *Engine) applyResourceWithPlugin(ctx context.Context, resource *state.ResourceState, config map[string]interface{}, priorState interface{}, currentState *state.State) (interface{}, error) {
 
 	pluginInstance, err := e.pluginManager.GetPlugin("aws")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get AWS plugin: %w", err)
 	}
 
-	resolvedAttrs := e.resolveResourceReferences(resource.Attributes)
+	e.stateMu.Lock()
+	resolvedAttrs, err := resolveAttributes(resource.Attributes, currentState)
+	var resolvedConfig map[string]interface{}
+	if err == nil {
+		resolvedConfig, err = resolveAttributes(config, currentState)
+	}
+	e.stateMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	resource.Attributes = resolvedAttrs
 
 	req := &plugin.ApplyResourceChangeRequest{
 		TypeName:     resource.Type,
-		PriorState:   nil,
+		PriorState:   priorState,
 		PlannedState: resolvedAttrs,
-		Config:       resolvedAttrs,
+		Config:       resolvedConfig,
 	}
 
 	resp, err := pluginInstance.Client.ApplyResourceChange(ctx, req)
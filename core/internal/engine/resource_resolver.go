@@ -1,83 +1,65 @@
 package engine
 
-func (e *Engine) resolveResourceReferences(attrs map[string]interface{}) map[string]interface{} {
-	resolved := make(map[string]interface{})
+import (
+	"fmt"
 
-	currentState, err := e.stateManager.LoadState()
-	if err != nil {
-		return attrs
-	}
+	"github.com/tblang/core/internal/ast"
+	"github.com/tblang/core/internal/state"
+)
+
+// resolveAttributes replaces references to other resources with the values
+// recorded for them in currentState.
+func resolveAttributes(attrs map[string]interface{}, currentState *state.State) (map[string]interface{}, error) {
+	resolved := make(map[string]interface{}, len(attrs))
 
 	for key, value := range attrs {
+		value, err := resolveDeferredValue(value, currentState)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", key, err)
+		}
+		resolved[key] = value
+	}
 
-		if strValue, ok := value.(string); ok {
+	return resolved, nil
+}
 
-			if resource, exists := currentState.Resources[strValue]; exists {
+// resolveDeferredValue replaces references such as main_vpc.vpc_id with the
+// attribute recorded in state once the referenced resource has been created.
+func resolveDeferredValue(value interface{}, currentState *state.State) (interface{}, error) {
+	switch v := value.(type) {
+	case *ast.Reference:
+		resource, exists := currentState.Resources[v.Resource]
+		if !exists {
+			return nil, fmt.Errorf("%s has not been created yet", v.Resource)
+		}
+		attr, exists := resource.Attributes[v.Attribute]
+		if !exists {
+			return nil, fmt.Errorf("resource %s has no attribute %s", v.Resource, v.Attribute)
+		}
+		return attr, nil
 
-				switch key {
-				case "vpc_id":
-					if vpcID, ok := resource.Attributes["vpc_id"].(string); ok {
-						resolved[key] = vpcID
-						continue
-					}
-				case "subnet_id":
-					if subnetID, ok := resource.Attributes["subnet_id"].(string); ok {
-						resolved[key] = subnetID
-						continue
-					}
-				case "group_id":
-					if groupID, ok := resource.Attributes["group_id"].(string); ok {
-						resolved[key] = groupID
-						continue
-					}
-				case "allocation_id":
-					if allocID, ok := resource.Attributes["allocation_id"].(string); ok {
-						resolved[key] = allocID
-						continue
-					}
-				case "gateway_id":
-					if gwID, ok := resource.Attributes["gateway_id"].(string); ok {
-						resolved[key] = gwID
-						continue
-					}
-				case "nat_gateway_id":
-					if natGwID, ok := resource.Attributes["nat_gateway_id"].(string); ok {
-						resolved[key] = natGwID
-						continue
-					}
-				}
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolvedItem, err := resolveDeferredValue(item, currentState)
+			if err != nil {
+				return nil, err
 			}
+			result[key] = resolvedItem
 		}
+		return result, nil
 
-		if arrValue, ok := value.([]interface{}); ok {
-			resolvedArr := make([]interface{}, len(arrValue))
-			for i, item := range arrValue {
-				if strItem, ok := item.(string); ok {
-
-					if resource, exists := currentState.Resources[strItem]; exists {
-
-						switch resource.Type {
-						case "security_group":
-							if groupID, ok := resource.Attributes["group_id"].(string); ok {
-								resolvedArr[i] = groupID
-								continue
-							}
-						case "subnet":
-							if subnetID, ok := resource.Attributes["subnet_id"].(string); ok {
-								resolvedArr[i] = subnetID
-								continue
-							}
-						}
-					}
-				}
-				resolvedArr[i] = item
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			resolvedItem, err := resolveDeferredValue(item, currentState)
+			if err != nil {
+				return nil, err
 			}
-			resolved[key] = resolvedArr
-			continue
+			result[i] = resolvedItem
 		}
-
-		resolved[key] = value
+		return result, nil
 	}
 
-	return resolved
+	return value, nil
 }
@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"sync"
+
 	"github.com/fatih/color"
 	"github.com/tblang/core/internal/compiler"
 	"github.com/tblang/core/internal/state"
@@ -11,12 +13,33 @@ type Engine struct {
 	stateManager  *state.Manager
 	pluginManager *PluginManager
 	workingDir    string
+
+	// parallelism bounds how many resources are applied at once. stateMu
+	// guards the state while they are.
+	parallelism int
+	stateMu     sync.Mutex
 }
 
 type PlanChanges struct {
-	Create []*state.ResourceState
-	Update []*state.ResourceState
-	Delete []*state.ResourceState
+	Create  []*state.ResourceState `json:"create"`
+	Update  []*state.ResourceState `json:"update"`
+	Replace []*state.ResourceState `json:"replace"`
+	Delete  []*state.ResourceState `json:"delete"`
+
+	// Diffs lists the attribute changes of each resource in Create, Update
+	// or Replace, sorted by attribute name.
+	Diffs map[string][]AttributeDiff `json:"diffs"`
+}
+
+// AttributeDiff is the planned change of one attribute. Action is "create",
+// "update" or "delete"; After is only known after apply when Unknown is set.
+type AttributeDiff struct {
+	Name              string      `json:"name"`
+	Action            string      `json:"action"`
+	Before            interface{} `json:"before,omitempty"`
+	After             interface{} `json:"after,omitempty"`
+	Unknown           bool        `json:"unknown,omitempty"`
+	ForcesReplacement bool        `json:"forces_replacement,omitempty"`
 }
 
 var (
@@ -0,0 +1,211 @@
+package formatter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/tblang/core/parser"
+)
+
+const indentUnit = "    "
+
+type syntaxErrorListener struct {
+	*antlr.DefaultErrorListener
+	filename string
+	errors   []error
+}
+
+func (l *syntaxErrorListener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
+	l.errors = append(l.errors, fmt.Errorf("%s:%d:%d: %s", l.filename, line, column+1, msg))
+}
+
+// Source reprints a TBLang file in the canonical style: four-space
+// indentation, `:` in object literals, `=` in block properties, statements
+// terminated by `;`, and at most one blank line in a row. Comments are kept
+// where they were written. Object and array literals stay on one line unless
+// they span several lines in the source.
+func Source(filename string, src []byte) ([]byte, error) {
+	listener := &syntaxErrorListener{DefaultErrorListener: antlr.NewDefaultErrorListener(), filename: filename}
+
+	lexer := parser.NewtblangLexer(antlr.NewInputStream(string(src)))
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(listener)
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+	p := parser.NewtblangParser(stream)
+	p.RemoveErrorListeners()
+	p.AddErrorListener(listener)
+
+	tree := p.Program()
+	if len(listener.errors) > 0 {
+		return nil, errors.Join(listener.errors...)
+	}
+
+	stream.Fill()
+	pr := &printer{tokens: stream.GetAllTokens(), atLineStart: true}
+	pr.program(tree.(*parser.ProgramContext))
+
+	out := strings.TrimRight(pr.out.String(), "\n")
+	if out == "" {
+		return []byte{}, nil
+	}
+	return []byte(out + "\n"), nil
+}
+
+type printer struct {
+	tokens []antlr.Token
+	next   int // index of the first token whose comments are not yet printed
+
+	out         strings.Builder
+	depth       int
+	atLineStart bool
+	afterOpen   bool // nothing has been printed since an opening brace
+	lastLine    int  // source line on which the last printed token ends
+}
+
+func (p *printer) write(s string) {
+	if p.atLineStart {
+		p.out.WriteString(strings.Repeat(indentUnit, p.depth))
+		p.atLineStart = false
+	}
+	p.out.WriteString(s)
+	p.afterOpen = false
+}
+
+func (p *printer) newline() {
+	p.out.WriteString("\n")
+	p.atLineStart = true
+}
+
+func (p *printer) blankLine() {
+	if p.out.Len() > 0 && !p.afterOpen && !strings.HasSuffix(p.out.String(), "\n\n") {
+		p.newline()
+	}
+}
+
+func (p *printer) open(s string) {
+	p.write(s)
+	p.afterOpen = true
+	p.depth++
+}
+
+func (p *printer) close(node antlr.TerminalNode, s string) {
+	p.comments(node.GetSymbol().GetTokenIndex())
+	p.depth--
+	if p.afterOpen {
+		p.write(s)
+	} else {
+		if !p.atLineStart {
+			p.newline()
+		}
+		p.write(s)
+	}
+	p.consume(node.GetSymbol())
+}
+
+// tok prints a token from the source, preceded by any comments before it.
+func (p *printer) tok(node antlr.TerminalNode) {
+	p.comments(node.GetSymbol().GetTokenIndex())
+	p.write(node.GetText())
+	p.consume(node.GetSymbol())
+}
+
+// skip drops a token from the source, such as an optional separator that is
+// written canonically by the caller, but still prints the comments before it.
+func (p *printer) skip(node antlr.TerminalNode) {
+	if node == nil {
+		return
+	}
+	p.comments(node.GetSymbol().GetTokenIndex())
+	p.next = node.GetSymbol().GetTokenIndex() + 1
+}
+
+func (p *printer) consume(t antlr.Token) {
+	p.next = t.GetTokenIndex() + 1
+	p.lastLine = endLine(t)
+}
+
+// comments prints the comments between the last printed token and the token
+// at index. A comment on the same source line as the last token stays there;
+// any other comment gets a line of its own.
+func (p *printer) comments(index int) {
+	for ; p.next < index && p.next < len(p.tokens); p.next++ {
+		t := p.tokens[p.next]
+		if t.GetChannel() != antlr.TokenHiddenChannel {
+			continue
+		}
+
+		if t.GetLine() == p.lastLine && !p.atLineStart {
+			p.out.WriteString(" " + t.GetText())
+			p.lastLine = endLine(t)
+			if strings.HasPrefix(t.GetText(), "//") {
+				p.newline()
+			}
+			continue
+		}
+
+		if !p.atLineStart {
+			p.newline()
+		}
+		if p.lastLine > 0 && t.GetLine()-p.lastLine > 1 {
+			p.blankLine()
+		}
+		p.write(t.GetText())
+		p.newline()
+		p.lastLine = endLine(t)
+	}
+}
+
+// trailingComments prints the comments that follow the last printed token on
+// the same source line.
+func (p *printer) trailingComments() {
+	for p.next < len(p.tokens) {
+		t := p.tokens[p.next]
+		if t.GetChannel() != antlr.TokenHiddenChannel || t.GetLine() != p.lastLine {
+			return
+		}
+		p.out.WriteString(" " + t.GetText())
+		p.lastLine = endLine(t)
+		p.next++
+	}
+}
+
+// beginLine starts a new line for the construct whose first token is t,
+// keeping a single blank line where the source had one or more.
+func (p *printer) beginLine(t antlr.Token) {
+	p.comments(t.GetTokenIndex())
+	if !p.atLineStart {
+		p.newline()
+	}
+	if p.lastLine > 0 && t.GetLine()-p.lastLine > 1 {
+		p.blankLine()
+	}
+}
+
+// endLine finishes the current line, keeping comments written after it.
+func (p *printer) endLine() {
+	p.trailingComments()
+	if !p.atLineStart {
+		p.newline()
+	}
+}
+
+func endLine(t antlr.Token) int {
+	return t.GetLine() + strings.Count(t.GetText(), "\n")
+}
+
+// multiline reports whether ctx spans several source lines or contains a
+// comment, in which case it is printed one element per line.
+func (p *printer) multiline(ctx antlr.ParserRuleContext) bool {
+	start, stop := ctx.GetStart(), ctx.GetStop()
+	if start.GetLine() != stop.GetLine() {
+		return true
+	}
+	for i := start.GetTokenIndex(); i <= stop.GetTokenIndex() && i < len(p.tokens); i++ {
+		if p.tokens[i].GetChannel() == antlr.TokenHiddenChannel {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package formatter
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/tblang/core/parser"
+)
+
+var update = flag.Bool("update", false, "rewrite the .golden files in testdata")
+
+// testdataFiles returns the inputs in testdata, each formatted into the
+// .golden file of the same name.
+func testdataFiles(t *testing.T) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join("testdata", "*.tbl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no .tbl files in testdata")
+	}
+	return files
+}
+
+func goldenFile(input string) string {
+	return strings.TrimSuffix(input, ".tbl") + ".golden"
+}
+
+func TestSourceGolden(t *testing.T) {
+	for _, input := range testdataFiles(t) {
+		t.Run(filepath.Base(input), func(t *testing.T) {
+			src, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Source(input, src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := goldenFile(input)
+			if *update {
+				if err := os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := Diff(golden, want, got); diff != "" {
+				t.Errorf("output differs from %s:\n%s", golden, diff)
+			}
+		})
+	}
+}
+
+func TestSourceIdempotent(t *testing.T) {
+	for _, input := range testdataFiles(t) {
+		golden := goldenFile(input)
+		t.Run(filepath.Base(golden), func(t *testing.T) {
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Source(golden, want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := Diff(golden, want, got); diff != "" {
+				t.Errorf("formatting %s again changed it:\n%s", golden, diff)
+			}
+		})
+	}
+}
+
+func TestSourceKeepsComments(t *testing.T) {
+	for _, input := range testdataFiles(t) {
+		t.Run(filepath.Base(input), func(t *testing.T) {
+			src, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Source(input, src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := comments(src)
+			if len(want) == 0 {
+				t.Fatalf("%s has no comments to check", input)
+			}
+			if have := comments(got); strings.Join(have, "\n") != strings.Join(want, "\n") {
+				t.Errorf("comments changed\nwant: %q\ngot:  %q", want, have)
+			}
+		})
+	}
+}
+
+func TestSourceSyntaxError(t *testing.T) {
+	_, err := Source("broken.tbl", []byte("declare x = ;\n"))
+	if err == nil {
+		t.Fatal("expected a syntax error")
+	}
+	if !strings.HasPrefix(err.Error(), "broken.tbl:1:") {
+		t.Errorf("error %q does not start with the position", err)
+	}
+}
+
+// comments returns the text of the comments in src, in order.
+func comments(src []byte) []string {
+	lexer := parser.NewtblangLexer(antlr.NewInputStream(string(src)))
+	lexer.RemoveErrorListeners()
+
+	var texts []string
+	for _, t := range lexer.GetAllTokens() {
+		if t.GetChannel() == antlr.TokenHiddenChannel {
+			texts = append(texts, t.GetText())
+		}
+	}
+	return texts
+}
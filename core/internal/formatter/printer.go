@@ -0,0 +1,349 @@
+package formatter
+
+import (
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/tblang/core/parser"
+)
+
+var binaryOperators = map[string]bool{
+	"*": true, "/": true, "%": true, "+": true, "-": true,
+	"<": true, "<=": true, ">": true, ">=": true, "==": true, "!=": true,
+	"&&": true, "||": true, "?": true, ":": true,
+}
+
+func (p *printer) program(ctx *parser.ProgramContext) {
+	for _, stmt := range ctx.AllStatement() {
+		p.statement(stmt)
+	}
+	p.comments(ctx.EOF().GetSymbol().GetTokenIndex())
+}
+
+func (p *printer) statement(stmt parser.IStatementContext) {
+	ctx := stmt.(*parser.StatementContext)
+	if ctx.SEMICOLON() != nil {
+		p.skip(ctx.SEMICOLON())
+		return
+	}
+
+	p.beginLine(ctx.GetStart())
+
+	switch {
+	case ctx.BlockDeclaration() != nil:
+		p.blockDeclaration(ctx.BlockDeclaration().(*parser.BlockDeclarationContext))
+	case ctx.VariableDeclaration() != nil:
+		decl := ctx.VariableDeclaration().(*parser.VariableDeclarationContext)
+		p.tok(decl.DECLARE())
+		p.write(" ")
+		p.tok(decl.IDENTIFIER())
+		p.write(" ")
+		p.tok(decl.ASSIGN())
+		p.write(" ")
+		p.expression(decl.Expression())
+		p.skip(decl.SEMICOLON())
+		p.write(";")
+	case ctx.ForLoop() != nil:
+		p.forLoop(ctx.ForLoop().(*parser.ForLoopContext))
+	case ctx.IfStatement() != nil:
+		p.ifStatement(ctx.IfStatement().(*parser.IfStatementContext))
+	case ctx.FunctionDeclaration() != nil:
+		p.functionDeclaration(ctx.FunctionDeclaration().(*parser.FunctionDeclarationContext))
+	case ctx.ReturnStatement() != nil:
+		ret := ctx.ReturnStatement().(*parser.ReturnStatementContext)
+		p.tok(ret.RETURN())
+		p.write(" ")
+		p.expression(ret.Expression())
+		p.skip(ret.SEMICOLON())
+		p.write(";")
+	case ctx.ImportStatement() != nil:
+		imp := ctx.ImportStatement().(*parser.ImportStatementContext)
+		p.tok(imp.IMPORT())
+		p.write(" ")
+		p.tok(imp.STRING_LITERAL())
+		p.write(" ")
+		p.tok(imp.AS())
+		p.write(" ")
+		p.tok(imp.IDENTIFIER())
+		if imp.ObjectLiteral() != nil {
+			p.write(" ")
+			p.objectLiteral(imp.ObjectLiteral().(*parser.ObjectLiteralContext))
+		}
+		p.skip(imp.SEMICOLON())
+		p.write(";")
+	case ctx.FunctionCall() != nil:
+		p.functionCall(ctx.FunctionCall().(*parser.FunctionCallContext))
+		p.write(";")
+	}
+
+	p.endLine()
+}
+
+func (p *printer) body(lbrace antlr.TerminalNode, stmts []parser.IStatementContext, rbrace antlr.TerminalNode) {
+	p.write(" ")
+	p.tok(lbrace)
+	p.afterOpen = true
+	p.depth++
+	p.trailingComments()
+	for _, stmt := range stmts {
+		p.statement(stmt)
+	}
+	p.close(rbrace, "}")
+}
+
+func (p *printer) blockDeclaration(ctx *parser.BlockDeclarationContext) {
+	p.tok(ctx.IDENTIFIER())
+	p.write(" ")
+	p.tok(ctx.STRING_LITERAL())
+	p.write(" ")
+	p.tok(ctx.LBRACE())
+	p.afterOpen = true
+	p.depth++
+	p.trailingComments()
+	for _, prop := range ctx.AllProperty() {
+		prop := prop.(*parser.PropertyContext)
+		p.beginLine(prop.GetStart())
+		p.tok(prop.IDENTIFIER())
+		p.write(" ")
+		p.tok(prop.ASSIGN())
+		p.write(" ")
+		p.expression(prop.Expression())
+		p.skip(prop.SEMICOLON())
+		p.skip(prop.COMMA())
+		p.endLine()
+	}
+	p.close(ctx.RBRACE(), "}")
+}
+
+func (p *printer) forLoop(ctx *parser.ForLoopContext) {
+	p.tok(ctx.FOR())
+	p.write(" ")
+	p.loopVariables(ctx.AllIDENTIFIER(), ctx.COMMA())
+	p.write(" ")
+	p.tok(ctx.IN())
+	p.write(" ")
+	p.expression(ctx.Expression())
+	p.body(ctx.LBRACE(), ctx.AllStatement(), ctx.RBRACE())
+}
+
+func (p *printer) loopVariables(idents []antlr.TerminalNode, comma antlr.TerminalNode) {
+	p.tok(idents[0])
+	if len(idents) > 1 {
+		p.skip(comma)
+		p.write(", ")
+		p.tok(idents[1])
+	}
+}
+
+func (p *printer) ifStatement(ctx *parser.IfStatementContext) {
+	p.tok(ctx.IF())
+	p.write(" ")
+	p.expression(ctx.Expression())
+	p.body(ctx.LBRACE(), ctx.AllStatement(), ctx.RBRACE())
+
+	if ctx.ElseClause() == nil {
+		return
+	}
+	elseClause := ctx.ElseClause().(*parser.ElseClauseContext)
+	p.write(" ")
+	p.tok(elseClause.ELSE())
+	if elseClause.IfStatement() != nil {
+		p.write(" ")
+		p.ifStatement(elseClause.IfStatement().(*parser.IfStatementContext))
+		return
+	}
+	p.body(elseClause.LBRACE(), elseClause.AllStatement(), elseClause.RBRACE())
+}
+
+func (p *printer) functionDeclaration(ctx *parser.FunctionDeclarationContext) {
+	p.tok(ctx.FUNC())
+	p.write(" ")
+	p.tok(ctx.IDENTIFIER())
+	p.tok(ctx.LPAREN())
+	if ctx.ParameterList() != nil {
+		params := ctx.ParameterList().(*parser.ParameterListContext)
+		for i, param := range params.AllIDENTIFIER() {
+			if i > 0 {
+				p.skip(params.COMMA(i - 1))
+				p.write(", ")
+			}
+			p.tok(param)
+		}
+	}
+	p.tok(ctx.RPAREN())
+	p.body(ctx.LBRACE(), ctx.AllStatement(), ctx.RBRACE())
+}
+
+// functionCall prints a call without its optional semicolon, which the
+// enclosing statement writes if it needs one.
+func (p *printer) functionCall(ctx *parser.FunctionCallContext) {
+	p.tok(ctx.IDENTIFIER())
+	p.tok(ctx.LPAREN())
+	if ctx.ArgumentList() != nil {
+		args := ctx.ArgumentList().(*parser.ArgumentListContext)
+		for i, arg := range args.AllExpression() {
+			if i > 0 {
+				p.skip(args.COMMA(i - 1))
+				p.write(", ")
+			}
+			p.expression(arg)
+		}
+	}
+	p.tok(ctx.RPAREN())
+	p.skip(ctx.SEMICOLON())
+}
+
+func (p *printer) expression(expr parser.IExpressionContext) {
+	ctx := expr.(*parser.ExpressionContext)
+
+	for i, child := range ctx.GetChildren() {
+		switch c := child.(type) {
+		case antlr.TerminalNode:
+			if i > 0 && binaryOperators[c.GetText()] {
+				p.comments(c.GetSymbol().GetTokenIndex())
+				p.write(" " + c.GetText() + " ")
+				p.consume(c.GetSymbol())
+			} else {
+				p.tok(c)
+			}
+		case *parser.ExpressionContext:
+			p.expression(c)
+		case *parser.ObjectLiteralContext:
+			p.objectLiteral(c)
+		case *parser.ArrayLiteralContext:
+			p.arrayLiteral(c)
+		case *parser.ListComprehensionContext:
+			p.listComprehension(c)
+		case *parser.MapComprehensionContext:
+			p.mapComprehension(c)
+		case *parser.FunctionCallContext:
+			p.functionCall(c)
+		}
+	}
+}
+
+// objectLiteral prints { key: value, ... } on one line, or one property per
+// line when the literal spans several lines in the source.
+func (p *printer) objectLiteral(ctx *parser.ObjectLiteralContext) {
+	props := ctx.AllObjectProperty()
+	if len(props) == 0 {
+		p.tok(ctx.LBRACE())
+		p.afterOpen = true
+		p.depth++
+		p.close(ctx.RBRACE(), "}")
+		return
+	}
+
+	multiline := p.multiline(ctx)
+
+	p.tok(ctx.LBRACE())
+	p.afterOpen = true
+	p.depth++
+	if multiline {
+		p.trailingComments()
+	} else {
+		p.write(" ")
+	}
+
+	for i, prop := range props {
+		prop := prop.(*parser.ObjectPropertyContext)
+		if multiline {
+			p.beginLine(prop.GetStart())
+		} else if i > 0 {
+			p.write(", ")
+		}
+		p.tok(prop.IDENTIFIER())
+		if prop.COLON() != nil {
+			p.skip(prop.COLON())
+		} else {
+			p.skip(prop.ASSIGN())
+		}
+		p.write(": ")
+		p.expression(prop.Expression())
+		p.skip(prop.COMMA())
+		if multiline {
+			p.endLine()
+		}
+	}
+
+	if multiline {
+		p.close(ctx.RBRACE(), "}")
+		return
+	}
+	p.depth--
+	p.write(" ")
+	p.tok(ctx.RBRACE())
+}
+
+// arrayLiteral prints [a, b] on one line, or one element per line when the
+// literal spans several lines in the source.
+func (p *printer) arrayLiteral(ctx *parser.ArrayLiteralContext) {
+	items := ctx.AllExpression()
+	if len(items) == 0 || !p.multiline(ctx) {
+		p.tok(ctx.LBRACKET())
+		for i, item := range items {
+			if i > 0 {
+				p.skip(ctx.COMMA(i - 1))
+				p.write(", ")
+			}
+			p.expression(item)
+		}
+		p.tok(ctx.RBRACKET())
+		return
+	}
+
+	p.tok(ctx.LBRACKET())
+	p.afterOpen = true
+	p.depth++
+	p.trailingComments()
+	for i, item := range items {
+		p.beginLine(item.GetStart())
+		p.expression(item)
+		if i < len(items)-1 {
+			p.write(",")
+			p.skip(ctx.COMMA(i))
+		}
+		p.endLine()
+	}
+	p.close(ctx.RBRACKET(), "]")
+}
+
+func (p *printer) listComprehension(ctx *parser.ListComprehensionContext) {
+	p.tok(ctx.LBRACKET())
+	p.comprehensionHead(ctx.FOR(), ctx.AllIDENTIFIER(), ctx.COMMA(), ctx.IN(), ctx.Expression(0), ctx.COLON())
+	p.expression(ctx.Expression(1))
+	if ctx.IF() != nil {
+		p.write(" ")
+		p.tok(ctx.IF())
+		p.write(" ")
+		p.expression(ctx.Expression(2))
+	}
+	p.tok(ctx.RBRACKET())
+}
+
+func (p *printer) mapComprehension(ctx *parser.MapComprehensionContext) {
+	p.tok(ctx.LBRACE())
+	p.comprehensionHead(ctx.FOR(), ctx.AllIDENTIFIER(), ctx.COMMA(), ctx.IN(), ctx.Expression(0), ctx.COLON())
+	p.expression(ctx.Expression(1))
+	p.write(" ")
+	p.tok(ctx.ARROW())
+	p.write(" ")
+	p.expression(ctx.Expression(2))
+	if ctx.IF() != nil {
+		p.write(" ")
+		p.tok(ctx.IF())
+		p.write(" ")
+		p.expression(ctx.Expression(3))
+	}
+	p.tok(ctx.RBRACE())
+}
+
+func (p *printer) comprehensionHead(forTok antlr.TerminalNode, idents []antlr.TerminalNode, comma, in antlr.TerminalNode, collection parser.IExpressionContext, colon antlr.TerminalNode) {
+	p.tok(forTok)
+	p.write(" ")
+	p.loopVariables(idents, comma)
+	p.write(" ")
+	p.tok(in)
+	p.write(" ")
+	p.expression(collection)
+	p.tok(colon)
+	p.write(" ")
+}
@@ -56,6 +56,8 @@ func (dg *DependencyGraph) extractDependencies(resource *ast.Resource) []string
 		dependencies = append(dependencies, deps...)
 	}
 
+	dependencies = append(dependencies, resource.DependsOn...)
+
 	return dependencies
 }
 
@@ -63,10 +65,9 @@ func (dg *DependencyGraph) findResourceReferences(value interface{}) []string {
 	var refs []string
 
 	switch v := value.(type) {
-	case string:
-
-		if _, exists := dg.nodes[v]; exists {
-			refs = append(refs, v)
+	case *ast.Reference:
+		if _, exists := dg.nodes[v.Resource]; exists {
+			refs = append(refs, v.Resource)
 		}
 
 	case map[string]interface{}:
@@ -93,8 +94,7 @@ func (dg *DependencyGraph) AddDependency(resource, dependency string) error {
 		return fmt.Errorf("resource %s not found", resource)
 	}
 	if _, exists := dg.nodes[dependency]; !exists {
-
-		return nil
+		return fmt.Errorf("resource %s depends on unknown resource %s", resource, dependency)
 	}
 
 	for _, existingDep := range dg.edges[resource] {
@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DependencyError is the error of a resource that was not visited by Walk
+// because one of its dependencies failed.
+type DependencyError struct {
+	Resource   string
+	Dependency string
+}
+
+func (e *DependencyError) Error() string {
+	return fmt.Sprintf("%s was skipped because %s failed", e.Resource, e.Dependency)
+}
+
+// Walk calls fn for every resource in the graph, for up to parallelism
+// resources at a time. A resource is started as soon as all of its
+// dependencies have finished, and is skipped with a *DependencyError if one
+// of them failed or was skipped, so a failure stops only what depends on it.
+// Walk returns the error of every resource that failed or was skipped.
+func (dg *DependencyGraph) Walk(parallelism int, fn func(name string) error) map[string]error {
+	return dg.walk(parallelism, fn, func(node *Node) []string { return node.Dependencies })
+}
+
+// WalkReverse is Walk in reverse dependency order: a resource is started
+// once all the resources that depend on it have finished, as when destroying
+// them, and is skipped if one of those failed.
+func (dg *DependencyGraph) WalkReverse(parallelism int, fn func(name string) error) map[string]error {
+	return dg.walk(parallelism, fn, func(node *Node) []string { return node.Dependents })
+}
+
+func (dg *DependencyGraph) walk(parallelism int, fn func(name string) error, waitFor func(*Node) []string) map[string]error {
+	errs := make(map[string]error)
+
+	if dg.hasCycle() {
+		for name := range dg.nodes {
+			errs[name] = fmt.Errorf("circular dependency detected")
+		}
+		return errs
+	}
+
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, parallelism)
+	done := make(map[string]chan struct{}, len(dg.nodes))
+	for name := range dg.nodes {
+		done[name] = make(chan struct{})
+	}
+
+	visit := func(name string, node *Node) {
+		defer wg.Done()
+		defer close(done[name])
+
+		for _, dep := range waitFor(node) {
+			<-done[dep]
+
+			mu.Lock()
+			_, failed := errs[dep]
+			if failed {
+				errs[name] = &DependencyError{Resource: name, Dependency: dep}
+			}
+			mu.Unlock()
+
+			if failed {
+				return
+			}
+		}
+
+		slots <- struct{}{}
+		err := fn(name)
+		<-slots
+
+		if err != nil {
+			mu.Lock()
+			errs[name] = err
+			mu.Unlock()
+		}
+	}
+
+	for name, node := range dg.nodes {
+		wg.Add(1)
+		go visit(name, node)
+	}
+	wg.Wait()
+
+	return errs
+}
@@ -0,0 +1,244 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tblang/core/internal/ast"
+)
+
+// newGraph returns a graph of the resources in deps, each depending on the
+// resources listed for it.
+func newGraph(t *testing.T, deps map[string][]string) *DependencyGraph {
+	t.Helper()
+	dg := NewDependencyGraph()
+	for name := range deps {
+		dg.AddResource(&ast.Resource{Name: name})
+	}
+	for name, list := range deps {
+		for _, dep := range list {
+			if err := dg.AddDependency(name, dep); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return dg
+}
+
+// recorder records the order in which a walk finishes resources.
+type recorder struct {
+	mu       sync.Mutex
+	finished []string
+}
+
+func (r *recorder) finish(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finished = append(r.finished, name)
+}
+
+func (r *recorder) visited() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := append([]string{}, r.finished...)
+	sort.Strings(names)
+	return names
+}
+
+// index returns where name is in the finishing order, or -1.
+func (r *recorder) index(name string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, finished := range r.finished {
+		if finished == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestWalkParallelism(t *testing.T) {
+	tests := []struct {
+		parallelism int
+		want        int64
+	}{
+		{parallelism: 0, want: 1},
+		{parallelism: 1, want: 1},
+		{parallelism: 3, want: 3},
+		{parallelism: 50, want: 12},
+	}
+
+	deps := make(map[string][]string)
+	for i := 0; i < 12; i++ {
+		deps[fmt.Sprintf("r%d", i)] = nil
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.parallelism), func(t *testing.T) {
+			var running, peak int64
+			errs := newGraph(t, deps).Walk(tt.parallelism, func(name string) error {
+				now := atomic.AddInt64(&running, 1)
+				for {
+					max := atomic.LoadInt64(&peak)
+					if now <= max || atomic.CompareAndSwapInt64(&peak, max, now) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				atomic.AddInt64(&running, -1)
+				return nil
+			})
+			if len(errs) != 0 {
+				t.Fatalf("errors: %v", errs)
+			}
+			if peak > tt.want {
+				t.Errorf("%d resources ran at once, want at most %d", peak, tt.want)
+			}
+			if peak < tt.want {
+				t.Errorf("only %d resources ran at once, want %d", peak, tt.want)
+			}
+		})
+	}
+}
+
+func TestWalkOrder(t *testing.T) {
+	deps := map[string][]string{
+		"vpc":    nil,
+		"igw":    {"vpc"},
+		"subnet": {"vpc"},
+		"route":  {"igw", "subnet"},
+		"ec2":    {"subnet"},
+	}
+
+	t.Run("forward", func(t *testing.T) {
+		var r recorder
+		errs := newGraph(t, deps).Walk(4, func(name string) error {
+			time.Sleep(time.Millisecond)
+			r.finish(name)
+			return nil
+		})
+		if len(errs) != 0 {
+			t.Fatalf("errors: %v", errs)
+		}
+		for name, list := range deps {
+			for _, dep := range list {
+				if r.index(dep) > r.index(name) {
+					t.Errorf("%s finished before its dependency %s", name, dep)
+				}
+			}
+		}
+	})
+
+	t.Run("reverse", func(t *testing.T) {
+		var r recorder
+		errs := newGraph(t, deps).WalkReverse(4, func(name string) error {
+			time.Sleep(time.Millisecond)
+			r.finish(name)
+			return nil
+		})
+		if len(errs) != 0 {
+			t.Fatalf("errors: %v", errs)
+		}
+		for name, list := range deps {
+			for _, dep := range list {
+				if r.index(dep) < r.index(name) {
+					t.Errorf("%s finished before its dependent %s", dep, name)
+				}
+			}
+		}
+	})
+}
+
+func TestWalkFailure(t *testing.T) {
+	// vpc fails, so subnet and ec2 are skipped; the other branch, from db
+	// to app, does not depend on it and still runs, as does the slow cache
+	// which is still running when vpc fails.
+	deps := map[string][]string{
+		"vpc":    nil,
+		"subnet": {"vpc"},
+		"ec2":    {"subnet"},
+		"db":     nil,
+		"app":    {"db"},
+		"cache":  nil,
+	}
+	failure := errors.New("boom")
+
+	var r recorder
+	errs := newGraph(t, deps).Walk(3, func(name string) error {
+		switch name {
+		case "vpc":
+			return failure
+		case "cache", "db":
+			time.Sleep(20 * time.Millisecond)
+		}
+		r.finish(name)
+		return nil
+	})
+
+	if got, want := r.visited(), []string{"app", "cache", "db"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("visited %v, want %v", got, want)
+	}
+
+	want := map[string]error{
+		"vpc":    failure,
+		"subnet": &DependencyError{Resource: "subnet", Dependency: "vpc"},
+		"ec2":    &DependencyError{Resource: "ec2", Dependency: "subnet"},
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("errors = %v, want %v", errs, want)
+	}
+}
+
+func TestWalkReverseFailure(t *testing.T) {
+	// Destroying ec2 fails, so the subnet and vpc it is in are kept.
+	deps := map[string][]string{
+		"vpc":    nil,
+		"subnet": {"vpc"},
+		"ec2":    {"subnet"},
+		"bucket": nil,
+	}
+	failure := errors.New("boom")
+
+	var r recorder
+	errs := newGraph(t, deps).WalkReverse(2, func(name string) error {
+		if name == "ec2" {
+			return failure
+		}
+		r.finish(name)
+		return nil
+	})
+
+	if got, want := r.visited(), []string{"bucket"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("visited %v, want %v", got, want)
+	}
+	want := map[string]error{
+		"ec2":    failure,
+		"subnet": &DependencyError{Resource: "subnet", Dependency: "ec2"},
+		"vpc":    &DependencyError{Resource: "vpc", Dependency: "subnet"},
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("errors = %v, want %v", errs, want)
+	}
+}
+
+func TestWalkCycle(t *testing.T) {
+	dg := newGraph(t, map[string][]string{"a": {"b"}, "b": {"a"}, "c": nil})
+
+	called := false
+	errs := dg.Walk(2, func(name string) error {
+		called = true
+		return nil
+	})
+	if called {
+		t.Error("a graph with a cycle was walked")
+	}
+	if len(errs) != 3 {
+		t.Errorf("errors = %v, want one for every resource", errs)
+	}
+}
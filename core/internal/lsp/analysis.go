@@ -0,0 +1,391 @@
+package lsp
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/tblang/core/parser"
+)
+
+// parsed is a document parsed with error recovery, so that half-written
+// files still yield a tree.
+type parsed struct {
+	tree      parser.IProgramContext
+	tokens    []antlr.Token // default channel only, ending with EOF
+	terminals []antlr.TerminalNode
+
+	// parents holds the rule each terminal belongs to. The runtime sets the
+	// parent of a terminal to the embedded BaseParserRuleContext, which
+	// cannot be told apart by type.
+	parents map[antlr.TerminalNode]antlr.ParserRuleContext
+}
+
+type terminalCollector struct {
+	parser.BasetblangListener
+	rules   []antlr.ParserRuleContext
+	nodes   []antlr.TerminalNode
+	parents map[antlr.TerminalNode]antlr.ParserRuleContext
+}
+
+func (c *terminalCollector) EnterEveryRule(ctx antlr.ParserRuleContext) {
+	c.rules = append(c.rules, ctx)
+}
+
+func (c *terminalCollector) ExitEveryRule(ctx antlr.ParserRuleContext) {
+	c.rules = c.rules[:len(c.rules)-1]
+}
+
+func (c *terminalCollector) VisitTerminal(node antlr.TerminalNode) {
+	c.nodes = append(c.nodes, node)
+	if len(c.rules) > 0 {
+		c.parents[node] = c.rules[len(c.rules)-1]
+	}
+}
+
+func parse(text string) *parsed {
+	lexer := parser.NewtblangLexer(antlr.NewInputStream(text))
+	lexer.RemoveErrorListeners()
+	stream := antlr.NewCommonTokenStream(lexer, antlr.TokenDefaultChannel)
+	p := parser.NewtblangParser(stream)
+	p.RemoveErrorListeners()
+
+	result := &parsed{tree: p.Program()}
+
+	stream.Fill()
+	for _, t := range stream.GetAllTokens() {
+		if t.GetChannel() == antlr.TokenDefaultChannel {
+			result.tokens = append(result.tokens, t)
+		}
+	}
+
+	collector := &terminalCollector{parents: make(map[antlr.TerminalNode]antlr.ParserRuleContext)}
+	antlr.ParseTreeWalkerDefault.Walk(collector, result.tree)
+	result.terminals = collector.nodes
+	result.parents = collector.parents
+
+	return result
+}
+
+// terminalAt returns the token of the tree under pos, preferring the one
+// ending at pos when the cursor sits right after a word.
+func (p *parsed) terminalAt(pos Position) antlr.TerminalNode {
+	var found antlr.TerminalNode
+	for _, node := range p.terminals {
+		t := node.GetSymbol()
+		if t.GetTokenType() == antlr.TokenEOF || t.GetLine() != pos.Line+1 {
+			continue
+		}
+		start := t.GetColumn()
+		end := start + utf8.RuneCountInString(t.GetText())
+		if pos.Character >= start && pos.Character < end {
+			return node
+		}
+		if pos.Character == end {
+			found = node
+		}
+	}
+	return found
+}
+
+// tokenIndexAt returns the index in p.tokens of the token under pos, or -1.
+func (p *parsed) tokenIndexAt(pos Position) int {
+	node := p.terminalAt(pos)
+	if node == nil {
+		return -1
+	}
+	for i, t := range p.tokens {
+		if t == node.GetSymbol() {
+			return i
+		}
+	}
+	return -1
+}
+
+func tokenRange(t antlr.Token) Range {
+	start := Position{Line: t.GetLine() - 1, Character: t.GetColumn()}
+	return Range{Start: start, End: Position{Line: start.Line, Character: start.Character + utf8.RuneCountInString(t.GetText())}}
+}
+
+func contextRange(ctx antlr.ParserRuleContext) Range {
+	start, stop := ctx.GetStart(), ctx.GetStop()
+	if stop == nil || stop.GetTokenIndex() < start.GetTokenIndex() {
+		stop = start
+	}
+	return Range{Start: tokenRange(start).Start, End: tokenRange(stop).End}
+}
+
+func isIdentifier(text string) bool {
+	if text == "" {
+		return false
+	}
+	for i, r := range text {
+		if !isWordRune(r) || i == 0 && r >= '0' && r <= '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func unquote(text string) string {
+	if len(text) >= 2 && (text[0] == '"' || text[0] == '\'') {
+		return text[1 : len(text)-1]
+	}
+	return text
+}
+
+// enclosingResource returns the resource type when tokens[index] lies in the
+// configuration object of a resource call, i.e. type("name", { ... }), with
+// no other object literal in between.
+func enclosingResource(tokens []antlr.Token, index int) string {
+	depth := 0
+	for i := index - 1; i >= 0; i-- {
+		switch tokens[i].GetText() {
+		case "}":
+			depth++
+		case "{":
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if i >= 4 && tokens[i-1].GetText() == "," && tokens[i-3].GetText() == "(" && isIdentifier(tokens[i-4].GetText()) {
+				return tokens[i-4].GetText()
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// isPropertyKey reports whether tokens[index] is written where an object
+// property name goes: first on its line or after { or a comma.
+func isPropertyKey(tokens []antlr.Token, index int, line int) bool {
+	if index == 0 {
+		return false
+	}
+	prev := tokens[index-1]
+	return prev.GetText() == "{" || prev.GetText() == "," || prev.GetLine() < line
+}
+
+// statementsOf returns the statements directly in the body of ctx.
+func statementsOf(ctx antlr.Tree) []parser.IStatementContext {
+	switch c := ctx.(type) {
+	case *parser.ProgramContext:
+		return c.AllStatement()
+	case *parser.ForLoopContext:
+		return c.AllStatement()
+	case *parser.IfStatementContext:
+		stmts := c.AllStatement()
+		if c.ElseClause() != nil {
+			stmts = append(stmts, statementsOf(c.ElseClause())...)
+		}
+		return stmts
+	case *parser.ElseClauseContext:
+		if c.IfStatement() != nil {
+			return statementsOf(c.IfStatement())
+		}
+		return c.AllStatement()
+	case *parser.FunctionDeclarationContext:
+		return c.AllStatement()
+	}
+	return nil
+}
+
+// resourceCall returns the call in expr if it is a call of a resource type
+// with a name as its first argument.
+func resourceCall(expr parser.IExpressionContext, isResourceType func(string) bool) (*parser.FunctionCallContext, string) {
+	if expr == nil || expr.(*parser.ExpressionContext).FunctionCall() == nil {
+		return nil, ""
+	}
+	call := expr.(*parser.ExpressionContext).FunctionCall().(*parser.FunctionCallContext)
+	return callName(call, isResourceType)
+}
+
+func callName(call *parser.FunctionCallContext, isResourceType func(string) bool) (*parser.FunctionCallContext, string) {
+	if call.IDENTIFIER() == nil || !isResourceType(call.IDENTIFIER().GetText()) || call.ArgumentList() == nil {
+		return nil, ""
+	}
+	first := call.ArgumentList().(*parser.ArgumentListContext).Expression(0)
+	if first == nil {
+		return call, ""
+	}
+	if str := first.(*parser.ExpressionContext).STRING_LITERAL(); str != nil {
+		return call, unquote(str.GetText())
+	}
+	return call, ""
+}
+
+func documentSymbols(p *parsed, isResourceType func(string) bool) []DocumentSymbol {
+	return statementSymbols(p.tree.AllStatement(), isResourceType)
+}
+
+func statementSymbols(stmts []parser.IStatementContext, isResourceType func(string) bool) []DocumentSymbol {
+	symbols := []DocumentSymbol{}
+
+	for _, stmt := range stmts {
+		ctx := stmt.(*parser.StatementContext)
+
+		switch {
+		case ctx.BlockDeclaration() != nil:
+			block := ctx.BlockDeclaration().(*parser.BlockDeclarationContext)
+			if block.IDENTIFIER() == nil || block.STRING_LITERAL() == nil {
+				continue
+			}
+			kind := SymbolKindNamespace
+			if block.IDENTIFIER().GetText() == "variable" {
+				kind = SymbolKindVariable
+			}
+			symbols = append(symbols, DocumentSymbol{
+				Name:           unquote(block.STRING_LITERAL().GetText()),
+				Detail:         block.IDENTIFIER().GetText(),
+				Kind:           kind,
+				Range:          contextRange(block),
+				SelectionRange: tokenRange(block.STRING_LITERAL().GetSymbol()),
+			})
+
+		case ctx.VariableDeclaration() != nil:
+			decl := ctx.VariableDeclaration().(*parser.VariableDeclarationContext)
+			if decl.IDENTIFIER() == nil {
+				continue
+			}
+			symbol := DocumentSymbol{
+				Name:           decl.IDENTIFIER().GetText(),
+				Kind:           SymbolKindVariable,
+				Range:          contextRange(decl),
+				SelectionRange: tokenRange(decl.IDENTIFIER().GetSymbol()),
+			}
+			if call, name := resourceCall(decl.Expression(), isResourceType); call != nil {
+				symbol.Kind = SymbolKindClass
+				symbol.Detail = strings.TrimSpace(call.IDENTIFIER().GetText() + " " + name)
+			}
+			symbols = append(symbols, symbol)
+
+		case ctx.FunctionDeclaration() != nil:
+			fn := ctx.FunctionDeclaration().(*parser.FunctionDeclarationContext)
+			if fn.IDENTIFIER() == nil {
+				continue
+			}
+			var params []string
+			if fn.ParameterList() != nil {
+				for _, param := range fn.ParameterList().(*parser.ParameterListContext).AllIDENTIFIER() {
+					params = append(params, param.GetText())
+				}
+			}
+			symbols = append(symbols, DocumentSymbol{
+				Name:           fn.IDENTIFIER().GetText(),
+				Detail:         "func(" + strings.Join(params, ", ") + ")",
+				Kind:           SymbolKindFunction,
+				Range:          contextRange(fn),
+				SelectionRange: tokenRange(fn.IDENTIFIER().GetSymbol()),
+				Children:       statementSymbols(fn.AllStatement(), isResourceType),
+			})
+
+		case ctx.ImportStatement() != nil:
+			imp := ctx.ImportStatement().(*parser.ImportStatementContext)
+			if imp.IDENTIFIER() == nil || imp.STRING_LITERAL() == nil {
+				continue
+			}
+			symbols = append(symbols, DocumentSymbol{
+				Name:           imp.IDENTIFIER().GetText(),
+				Detail:         unquote(imp.STRING_LITERAL().GetText()),
+				Kind:           SymbolKindModule,
+				Range:          contextRange(imp),
+				SelectionRange: tokenRange(imp.IDENTIFIER().GetSymbol()),
+			})
+
+		case ctx.FunctionCall() != nil:
+			call, name := callName(ctx.FunctionCall().(*parser.FunctionCallContext), isResourceType)
+			if call == nil || name == "" {
+				continue
+			}
+			symbols = append(symbols, DocumentSymbol{
+				Name:           name,
+				Detail:         call.IDENTIFIER().GetText(),
+				Kind:           SymbolKindClass,
+				Range:          contextRange(call),
+				SelectionRange: tokenRange(call.IDENTIFIER().GetSymbol()),
+			})
+
+		case ctx.ForLoop() != nil:
+			symbols = append(symbols, statementSymbols(statementsOf(ctx.ForLoop()), isResourceType)...)
+		case ctx.IfStatement() != nil:
+			symbols = append(symbols, statementSymbols(statementsOf(ctx.IfStatement()), isResourceType)...)
+		}
+	}
+
+	return symbols
+}
+
+// declaration finds where the identifier node refers to: a loop or
+// comprehension variable, a function parameter, or else a declare, variable
+// block, function or import at any level of the file. Among several declares
+// of the same name the last one before node wins.
+func (p *parsed) declaration(node antlr.TerminalNode) antlr.Token {
+	name := node.GetText()
+	offset := node.GetSymbol().GetStart()
+
+	for parent := antlr.Tree(p.parents[node]); parent != nil; parent = parent.GetParent() {
+		var idents []antlr.TerminalNode
+		var scopeStart antlr.TerminalNode
+
+		switch c := parent.(type) {
+		case *parser.ForLoopContext:
+			idents, scopeStart = c.AllIDENTIFIER(), c.LBRACE()
+		case *parser.ListComprehensionContext:
+			idents, scopeStart = c.AllIDENTIFIER(), c.COLON()
+		case *parser.MapComprehensionContext:
+			idents, scopeStart = c.AllIDENTIFIER(), c.COLON()
+		case *parser.FunctionDeclarationContext:
+			if c.ParameterList() != nil {
+				idents = c.ParameterList().(*parser.ParameterListContext).AllIDENTIFIER()
+			}
+			scopeStart = c.LPAREN()
+		}
+
+		if scopeStart == nil || offset < scopeStart.GetSymbol().GetStart() {
+			continue
+		}
+		for _, ident := range idents {
+			if ident.GetText() == name {
+				return ident.GetSymbol()
+			}
+		}
+	}
+
+	var found antlr.Token
+	var visit func(stmts []parser.IStatementContext)
+	visit = func(stmts []parser.IStatementContext) {
+		for _, stmt := range stmts {
+			ctx := stmt.(*parser.StatementContext)
+
+			var decl antlr.Token
+			switch {
+			case ctx.VariableDeclaration() != nil:
+				if ident := ctx.VariableDeclaration().(*parser.VariableDeclarationContext).IDENTIFIER(); ident != nil && ident.GetText() == name {
+					decl = ident.GetSymbol()
+				}
+			case ctx.BlockDeclaration() != nil:
+				block := ctx.BlockDeclaration().(*parser.BlockDeclarationContext)
+				if block.IDENTIFIER() != nil && block.IDENTIFIER().GetText() == "variable" && block.STRING_LITERAL() != nil && unquote(block.STRING_LITERAL().GetText()) == name {
+					decl = block.STRING_LITERAL().GetSymbol()
+				}
+			case ctx.FunctionDeclaration() != nil:
+				if ident := ctx.FunctionDeclaration().(*parser.FunctionDeclarationContext).IDENTIFIER(); ident != nil && ident.GetText() == name {
+					decl = ident.GetSymbol()
+				}
+			case ctx.ImportStatement() != nil:
+				if ident := ctx.ImportStatement().(*parser.ImportStatementContext).IDENTIFIER(); ident != nil && ident.GetText() == name {
+					decl = ident.GetSymbol()
+				}
+			}
+
+			if decl != nil && (found == nil || decl.GetStart() < offset) {
+				found = decl
+			}
+			visit(statementsOf(ctx.GetChild(0)))
+		}
+	}
+	visit(p.tree.AllStatement())
+
+	return found
+}
@@ -0,0 +1,294 @@
+package lsp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/tblang/core/internal/compiler"
+	"github.com/tblang/core/parser"
+	"github.com/tblang/core/pkg/plugin"
+)
+
+var keywords = []string{"declare", "for", "in", "if", "else", "func", "return", "import", "as", "true", "false"}
+
+// metaArguments are accepted by every resource besides its schema attributes.
+var metaArguments = []string{"count", "for_each", "depends_on", "lifecycle"}
+
+// cursorIndex returns the index in p.tokens of the word being typed at pos,
+// or of the first token after pos if there is none.
+func (p *parsed) cursorIndex(pos Position) int {
+	for i, t := range p.tokens {
+		r := tokenRange(t)
+		if r.Start.Line > pos.Line || r.Start.Line == pos.Line && r.Start.Character >= pos.Character {
+			if i > 0 {
+				prev := tokenRange(p.tokens[i-1])
+				if prev.End.Line == pos.Line && prev.End.Character >= pos.Character && isIdentifier(p.tokens[i-1].GetText()) {
+					return i - 1
+				}
+			}
+			return i
+		}
+	}
+	return len(p.tokens) - 1
+}
+
+func (s *Server) completion(doc *document, pos Position) []CompletionItem {
+	p := parse(doc.text)
+	index := p.cursorIndex(pos)
+
+	if resourceType := enclosingResource(p.tokens, index); resourceType != "" && isPropertyKey(p.tokens, index, pos.Line+1) {
+		return s.attributeCompletions(resourceType)
+	}
+
+	items := []CompletionItem{}
+	seen := make(map[string]bool)
+	add := func(item CompletionItem) {
+		if !seen[item.Label] {
+			seen[item.Label] = true
+			items = append(items, item)
+		}
+	}
+
+	for _, name := range declaredNames(p.tree.AllStatement()) {
+		add(CompletionItem{Label: name, Kind: CompletionKindVariable})
+	}
+	for _, name := range s.resourceTypes() {
+		detail := "resource"
+		if _, exists := s.schemas.DataSourceSchemas[name]; exists || strings.HasPrefix(name, "data_") {
+			detail = "data source"
+		}
+		add(CompletionItem{Label: name, Kind: CompletionKindClass, Detail: detail})
+	}
+	for _, name := range compiler.Builtins() {
+		add(CompletionItem{Label: name, Kind: CompletionKindFunction, Detail: "built-in function"})
+	}
+	for _, keyword := range keywords {
+		add(CompletionItem{Label: keyword, Kind: CompletionKindKeyword})
+	}
+
+	return items
+}
+
+func (s *Server) attributeCompletions(resourceType string) []CompletionItem {
+	items := []CompletionItem{}
+
+	if block := s.schema(resourceType); block != nil {
+		for _, name := range sortedAttributes(block) {
+			attr := block.Attributes[name]
+			if attr.Computed && !attr.Required && !attr.Optional {
+				continue
+			}
+			items = append(items, CompletionItem{
+				Label:         name,
+				Kind:          CompletionKindProperty,
+				Detail:        attributeSummary(attr),
+				Documentation: markdown(attr.Description),
+			})
+		}
+	}
+	for _, name := range metaArguments {
+		items = append(items, CompletionItem{Label: name, Kind: CompletionKindKeyword, Detail: "meta-argument"})
+	}
+
+	return items
+}
+
+func (s *Server) hover(doc *document, pos Position) *Hover {
+	p := parse(doc.text)
+	index := p.tokenIndexAt(pos)
+	if index < 0 || index+1 >= len(p.tokens) {
+		return nil
+	}
+	t, next := p.tokens[index], p.tokens[index+1].GetText()
+	r := tokenRange(t)
+
+	if next == "(" {
+		block := s.schema(t.GetText())
+		if block == nil {
+			return nil
+		}
+		var required []string
+		for _, name := range sortedAttributes(block) {
+			if block.Attributes[name].Required {
+				required = append(required, "`"+name+"`")
+			}
+		}
+		kind := "resource"
+		if _, exists := s.schemas.DataSourceSchemas[t.GetText()]; exists {
+			kind = "data source"
+		}
+		text := fmt.Sprintf("**%s** %s", t.GetText(), kind)
+		if len(required) > 0 {
+			text += "\n\nRequired attributes: " + strings.Join(required, ", ")
+		}
+		return &Hover{Contents: *markdown(text), Range: &r}
+	}
+
+	if (next == ":" || next == "=") && isPropertyKey(p.tokens, index, t.GetLine()) {
+		block := s.schema(enclosingResource(p.tokens, index))
+		if block == nil {
+			return nil
+		}
+		attr, exists := block.Attributes[t.GetText()]
+		if !exists {
+			return nil
+		}
+		text := fmt.Sprintf("**%s** %s", t.GetText(), attributeSummary(attr))
+		if attr.Description != "" {
+			text += "\n\n" + attr.Description
+		}
+		return &Hover{Contents: *markdown(text), Range: &r}
+	}
+
+	return nil
+}
+
+func (s *Server) definition(doc *document, pos Position) []Location {
+	p := parse(doc.text)
+	node := p.terminalAt(pos)
+	if node == nil {
+		return nil
+	}
+
+	switch parent := p.parents[node].(type) {
+	case *parser.ExpressionContext:
+		if parent.STRING_LITERAL() != nil {
+			return resourceLocation(doc, unquote(node.GetText()))
+		}
+		if parent.DOT() != nil {
+			// The attribute of object.attribute: go to the object instead.
+			base, ok := parent.Expression(0).(*parser.ExpressionContext)
+			if !ok || base.IDENTIFIER() == nil {
+				return nil
+			}
+			node = base.IDENTIFIER()
+		}
+	case *parser.FunctionCallContext:
+		if parent.IDENTIFIER() != node {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	decl := p.declaration(node)
+	if decl == nil {
+		return nil
+	}
+	return []Location{{URI: doc.uri, Range: tokenRange(decl)}}
+}
+
+// resourceLocation finds the resource called name, or the instances of a
+// resource with count or for_each, in the last successful compile of doc.
+func resourceLocation(doc *document, name string) []Location {
+	var locations []Location
+	for resourceName, pos := range doc.resources {
+		if resourceName != name && !strings.HasPrefix(resourceName, name+"[") {
+			continue
+		}
+		start := Position{Line: pos.Line - 1, Character: pos.Column - 1}
+		location := Location{URI: filenameToURI(pos.Filename), Range: Range{Start: start, End: start}}
+		if resourceName == name {
+			return []Location{location}
+		}
+		if len(locations) == 0 || locations[0] != location {
+			locations = append(locations, location)
+		}
+	}
+	return locations
+}
+
+// declaredNames returns the names declared by stmts and the statements
+// nested in them, in order of appearance.
+func declaredNames(stmts []parser.IStatementContext) []string {
+	var names []string
+	for _, stmt := range stmts {
+		ctx := stmt.(*parser.StatementContext)
+
+		var ident antlr.TerminalNode
+		switch {
+		case ctx.VariableDeclaration() != nil:
+			ident = ctx.VariableDeclaration().(*parser.VariableDeclarationContext).IDENTIFIER()
+		case ctx.FunctionDeclaration() != nil:
+			ident = ctx.FunctionDeclaration().(*parser.FunctionDeclarationContext).IDENTIFIER()
+		case ctx.ImportStatement() != nil:
+			ident = ctx.ImportStatement().(*parser.ImportStatementContext).IDENTIFIER()
+		case ctx.BlockDeclaration() != nil:
+			block := ctx.BlockDeclaration().(*parser.BlockDeclarationContext)
+			if block.IDENTIFIER() != nil && block.IDENTIFIER().GetText() == "variable" && block.STRING_LITERAL() != nil {
+				names = append(names, unquote(block.STRING_LITERAL().GetText()))
+			}
+		}
+		if ident != nil {
+			names = append(names, ident.GetText())
+		}
+
+		names = append(names, declaredNames(statementsOf(ctx.GetChild(0)))...)
+	}
+	return names
+}
+
+// resourceTypes returns the resource and data source types known from the
+// provider schemas and the compiler, sorted.
+func (s *Server) resourceTypes() []string {
+	set := make(map[string]bool)
+	for _, name := range compiler.ResourceTypes() {
+		set[name] = true
+	}
+	for name := range s.schemas.ResourceSchemas {
+		set[name] = true
+	}
+	for name := range s.schemas.DataSourceSchemas {
+		set[name] = true
+	}
+
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedAttributes(block *plugin.SchemaBlock) []string {
+	names := make([]string, 0, len(block.Attributes))
+	for name := range block.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func attributeSummary(attr *plugin.Attribute) string {
+	var flags []string
+	switch {
+	case attr.Required:
+		flags = append(flags, "required")
+	case attr.Optional:
+		flags = append(flags, "optional")
+	}
+	if attr.Computed {
+		flags = append(flags, "computed")
+	}
+	if attr.Sensitive {
+		flags = append(flags, "sensitive")
+	}
+
+	summary := attr.Type
+	if summary == "" {
+		summary = "any"
+	}
+	if len(flags) > 0 {
+		summary += " (" + strings.Join(flags, ", ") + ")"
+	}
+	return summary
+}
+
+func markdown(text string) *MarkupContent {
+	if text == "" {
+		return nil
+	}
+	return &MarkupContent{Kind: "markdown", Value: text}
+}
@@ -0,0 +1,352 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tblang/core/internal/ast"
+	"github.com/tblang/core/internal/compiler"
+	"github.com/tblang/core/pkg/plugin"
+)
+
+// Server is a language server for .tbl files speaking LSP over a pair of
+// streams, usually stdin and stdout.
+type Server struct {
+	in      *bufio.Reader
+	out     io.Writer
+	schemas *plugin.GetSchemaResponse
+
+	docs      map[string]*document
+	diagnosed map[string]bool // URIs for which diagnostics are shown
+	shutdown  bool            // whether the client asked to shut down
+}
+
+type document struct {
+	uri      string
+	filename string
+	text     string
+
+	// resources maps resource names to where they were declared, as of the
+	// last compile that got that far.
+	resources map[string]ast.Position
+}
+
+// NewServer returns a server reading requests from in and writing responses
+// to out. schemas, which may be empty, drive completion, hover and schema
+// diagnostics.
+func NewServer(in io.Reader, out io.Writer, schemas *plugin.GetSchemaResponse) *Server {
+	if schemas == nil {
+		schemas = &plugin.GetSchemaResponse{}
+	}
+	return &Server{
+		in:        bufio.NewReader(in),
+		out:       out,
+		schemas:   schemas,
+		docs:      make(map[string]*document),
+		diagnosed: make(map[string]bool),
+	}
+}
+
+// ErrExitWithoutShutdown is returned by Run when the client sends exit
+// without asking the server to shut down first, in which case the protocol
+// has the process exit with code 1.
+var ErrExitWithoutShutdown = errors.New("exit received before shutdown")
+
+// Run serves requests until the client sends exit or closes the stream.
+func (s *Server) Run() error {
+	for {
+		msg, err := readMessage(s.in)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return ErrExitWithoutShutdown
+			}
+			return nil
+		}
+
+		result, respErr := s.dispatch(msg)
+		if msg.ID == nil {
+			continue
+		}
+
+		resp := &message{ID: msg.ID, Error: respErr}
+		if respErr == nil {
+			if resp.Result, err = json.Marshal(result); err != nil {
+				return err
+			}
+		}
+		if err := writeMessage(s.out, resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) dispatch(msg *message) (interface{}, *responseError) {
+	switch msg.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":       1, // full document on every change
+				"completionProvider":     map[string]interface{}{},
+				"hoverProvider":          true,
+				"definitionProvider":     true,
+				"documentSymbolProvider": true,
+			},
+			"serverInfo": map[string]string{"name": "tblang"},
+		}, nil
+
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		s.update(params.TextDocument.URI, params.TextDocument.Text)
+
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		if n := len(params.ContentChanges); n > 0 {
+			s.update(params.TextDocument.URI, params.ContentChanges[n-1].Text)
+		}
+
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		delete(s.docs, params.TextDocument.URI)
+		s.publish(params.TextDocument.URI, nil)
+
+	case "textDocument/completion":
+		doc, pos, err := s.position(msg.Params)
+		if err != nil {
+			return nil, err
+		}
+		return s.completion(doc, pos), nil
+
+	case "textDocument/hover":
+		doc, pos, err := s.position(msg.Params)
+		if err != nil {
+			return nil, err
+		}
+		return s.hover(doc, pos), nil
+
+	case "textDocument/definition":
+		doc, pos, err := s.position(msg.Params)
+		if err != nil {
+			return nil, err
+		}
+		return s.definition(doc, pos), nil
+
+	case "textDocument/documentSymbol":
+		var params DocumentSymbolParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		doc, exists := s.docs[params.TextDocument.URI]
+		if !exists {
+			return nil, nil
+		}
+		return documentSymbols(parse(doc.text), s.isResourceType), nil
+
+	default:
+		if msg.ID != nil {
+			return nil, &responseError{Code: codeMethodNotFound, Message: "method not supported: " + msg.Method}
+		}
+	}
+
+	return nil, nil
+}
+
+func invalidParams(err error) *responseError {
+	return &responseError{Code: codeInvalidParams, Message: err.Error()}
+}
+
+func (s *Server) position(raw json.RawMessage) (*document, Position, *responseError) {
+	var params TextDocumentPositionParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, Position{}, invalidParams(err)
+	}
+	doc, exists := s.docs[params.TextDocument.URI]
+	if !exists {
+		return nil, Position{}, &responseError{Code: codeInvalidParams, Message: "document not open: " + params.TextDocument.URI}
+	}
+	return doc, params.Position, nil
+}
+
+func (s *Server) update(uri, text string) {
+	doc, exists := s.docs[uri]
+	if !exists {
+		doc = &document{uri: uri, filename: uriToFilename(uri)}
+		s.docs[uri] = doc
+	}
+	doc.text = text
+	s.diagnose(doc)
+}
+
+// diagnose compiles doc, with the contents of all open documents taking the
+// place of the files on disk, and publishes the errors found for each file.
+func (s *Server) diagnose(doc *document) {
+	c := compiler.New()
+	c.AllowUnsetVariables()
+	for _, open := range s.docs {
+		c.SetFileContents(open.filename, []byte(open.text))
+	}
+
+	program, err := compile(c, doc.filename)
+	if err == nil && len(program.CloudVendors) > 0 && len(s.schemas.ResourceSchemas)+len(s.schemas.DataSourceSchemas) > 0 {
+		err = c.CheckResourceSchemas(program, s.schemas)
+	}
+	if program != nil {
+		doc.resources = make(map[string]ast.Position)
+		for _, resource := range program.Resources {
+			doc.resources[resource.Name] = resource.Pos
+		}
+	}
+
+	byURI := map[string][]Diagnostic{doc.uri: nil}
+	for _, e := range flatten(err) {
+		uri, diag := doc.uri, Diagnostic{Severity: SeverityError, Source: "tblang", Message: e.Error()}
+
+		var d *compiler.Diagnostic
+		if errors.As(e, &d) && d.Pos.Line > 0 {
+			uri = filenameToURI(d.Pos.Filename)
+			diag.Message = d.Message
+			diag.Range = wordRange(d.Pos, d.Source)
+			for _, note := range d.Notes {
+				diag.RelatedInformation = append(diag.RelatedInformation, DiagnosticRelatedInformation{
+					Location: Location{URI: filenameToURI(note.Pos.Filename), Range: wordRange(note.Pos, note.Source)},
+					Message:  strings.TrimPrefix(note.Message, "note: "),
+				})
+			}
+		}
+		byURI[uri] = append(byURI[uri], diag)
+	}
+
+	for uri := range s.diagnosed {
+		if _, exists := byURI[uri]; !exists {
+			s.publish(uri, nil)
+		}
+	}
+	for uri, diags := range byURI {
+		s.publish(uri, diags)
+	}
+}
+
+// compile runs the compiler, turning a panic on a half-written file into an
+// error so that the server keeps running.
+func compile(c *compiler.Compiler, filename string) (program *compiler.Program, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			program, err = nil, fmt.Errorf("internal compiler error: %v", r)
+		}
+	}()
+	return c.CompileFile(filename)
+}
+
+// flatten expands errors combined with errors.Join.
+func flatten(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, e := range joined.Unwrap() {
+			errs = append(errs, flatten(e)...)
+		}
+		return errs
+	}
+	return []error{err}
+}
+
+// wordRange covers the word starting at pos on line, or a single character
+// if there is none.
+func wordRange(pos ast.Position, line string) Range {
+	start := Position{Line: pos.Line - 1, Character: pos.Column - 1}
+	end := start
+	runes := []rune(line)
+	for end.Character < len(runes) && isWordRune(runes[end.Character]) {
+		end.Character++
+	}
+	if end == start {
+		end.Character++
+	}
+	return Range{Start: start, End: end}
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
+
+func (s *Server) publish(uri string, diags []Diagnostic) {
+	if diags == nil {
+		diags = []Diagnostic{}
+	}
+	sort.SliceStable(diags, func(i, j int) bool {
+		a, b := diags[i].Range.Start, diags[j].Range.Start
+		return a.Line < b.Line || a.Line == b.Line && a.Character < b.Character
+	})
+	s.diagnosed[uri] = len(diags) > 0
+
+	params, _ := json.Marshal(PublishDiagnosticsParams{URI: uri, Diagnostics: diags})
+	writeMessage(s.out, &message{Method: "textDocument/publishDiagnostics", Params: params})
+}
+
+func (s *Server) isResourceType(name string) bool {
+	if _, exists := s.schemas.ResourceSchemas[name]; exists {
+		return true
+	}
+	if _, exists := s.schemas.DataSourceSchemas[name]; exists {
+		return true
+	}
+	for _, t := range compiler.ResourceTypes() {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) schema(resourceType string) *plugin.SchemaBlock {
+	schema, exists := s.schemas.ResourceSchemas[resourceType]
+	if !exists {
+		schema = s.schemas.DataSourceSchemas[resourceType]
+	}
+	if schema == nil {
+		return nil
+	}
+	return schema.Block
+}
+
+func uriToFilename(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+func filenameToURI(filename string) string {
+	if abs, err := filepath.Abs(filename); err == nil {
+		filename = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(filename)}).String()
+}
@@ -0,0 +1,251 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/tblang/core/pkg/plugin"
+)
+
+var testSchemas = &plugin.GetSchemaResponse{
+	ResourceSchemas: map[string]*plugin.Schema{
+		"vpc": {Block: &plugin.SchemaBlock{Attributes: map[string]*plugin.Attribute{
+			"cidr_block": {Type: "string", Required: true, Description: "The IPv4 CIDR block."},
+			"vpc_id":     {Type: "string", Computed: true},
+		}}},
+		"subnet": {Block: &plugin.SchemaBlock{Attributes: map[string]*plugin.Attribute{
+			"vpc_id":     {Type: "string", Required: true},
+			"cidr_block": {Type: "string", Required: true},
+			"subnet_id":  {Type: "string", Computed: true},
+		}}},
+	},
+}
+
+const mainURI = "file:///work/main.tbl"
+
+const mainText = `cloud_vendor "aws" {
+    region = "us-east-1"
+}
+declare v = vpc("main", {cidr_block: "10.0.0.0/16"});
+declare s = subnet("web", {vpc_id: v, cidr_block: 24});
+`
+
+// session is a client talking to a server over an in-memory stream. The
+// requests are queued up front and served by run.
+type session struct {
+	t      *testing.T
+	in     bytes.Buffer
+	nextID int
+}
+
+func (c *session) send(id *json.RawMessage, method string, params interface{}) {
+	c.t.Helper()
+	raw, err := json.Marshal(params)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if err := writeMessage(&c.in, &message{ID: id, Method: method, Params: raw}); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// request queues a request and returns its ID.
+func (c *session) request(method string, params interface{}) int {
+	c.nextID++
+	id := json.RawMessage(strconv.Itoa(c.nextID))
+	c.send(&id, method, params)
+	return c.nextID
+}
+
+func (c *session) notify(method string, params interface{}) {
+	c.send(nil, method, params)
+}
+
+func (c *session) open(uri, text string) {
+	c.notify("textDocument/didOpen", DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{URI: uri, LanguageID: "tblang", Version: 1, Text: text},
+	})
+}
+
+func (c *session) at(method, uri string, line, character int) int {
+	return c.request(method, TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     Position{Line: line, Character: character},
+	})
+}
+
+// replies holds what the server wrote: the result of each request by ID,
+// and the diagnostics last published for each URI.
+type replies struct {
+	results     map[int]json.RawMessage
+	diagnostics map[string][]Diagnostic
+}
+
+// run serves the queued requests and returns the replies and the error Run
+// returned.
+func (c *session) run() (*replies, error) {
+	c.t.Helper()
+	var out bytes.Buffer
+	runErr := NewServer(&c.in, &out, testSchemas).Run()
+
+	r := &replies{results: make(map[int]json.RawMessage), diagnostics: make(map[string][]Diagnostic)}
+	reader := bufio.NewReader(&out)
+	for {
+		msg, err := readMessage(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			c.t.Fatal(err)
+		}
+
+		switch {
+		case msg.Method == "textDocument/publishDiagnostics":
+			var params PublishDiagnosticsParams
+			if err := json.Unmarshal(msg.Params, &params); err != nil {
+				c.t.Fatal(err)
+			}
+			r.diagnostics[params.URI] = params.Diagnostics
+		case msg.Error != nil:
+			c.t.Errorf("request %s failed: %s", *msg.ID, msg.Error.Message)
+		default:
+			id, err := strconv.Atoi(string(*msg.ID))
+			if err != nil {
+				c.t.Fatal(err)
+			}
+			r.results[id] = msg.Result
+		}
+	}
+	return r, runErr
+}
+
+func (r *replies) decode(t *testing.T, id int, v interface{}) {
+	t.Helper()
+	result, exists := r.results[id]
+	if !exists {
+		t.Fatalf("no reply to request %d", id)
+	}
+	if err := json.Unmarshal(result, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer(t *testing.T) {
+	c := &session{t: t}
+	initialize := c.request("initialize", map[string]interface{}{})
+	c.open(mainURI, mainText)
+	completion := c.at("textDocument/completion", mainURI, 4, 39)
+	typeHover := c.at("textDocument/hover", mainURI, 3, 13)
+	attributeHover := c.at("textDocument/hover", mainURI, 3, 26)
+	definition := c.at("textDocument/definition", mainURI, 4, 35)
+	c.request("shutdown", nil)
+	c.notify("exit", nil)
+
+	r, err := c.run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	t.Run("initialize", func(t *testing.T) {
+		var result struct {
+			Capabilities map[string]interface{} `json:"capabilities"`
+		}
+		r.decode(t, initialize, &result)
+		for _, capability := range []string{"completionProvider", "hoverProvider", "definitionProvider", "documentSymbolProvider"} {
+			if _, exists := result.Capabilities[capability]; !exists {
+				t.Errorf("capabilities %v lack %s", result.Capabilities, capability)
+			}
+		}
+	})
+
+	t.Run("diagnostics", func(t *testing.T) {
+		want := []Diagnostic{{
+			Range:    Range{Start: Position{Line: 4, Character: 38}, End: Position{Line: 4, Character: 48}},
+			Severity: SeverityError,
+			Source:   "tblang",
+			Message:  "attribute cidr_block of subnet web must be a string, got number",
+		}}
+		if got := r.diagnostics[mainURI]; !reflect.DeepEqual(got, want) {
+			t.Errorf("diagnostics = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("completion", func(t *testing.T) {
+		var items []CompletionItem
+		r.decode(t, completion, &items)
+		var labels []string
+		for _, item := range items {
+			labels = append(labels, item.Label)
+		}
+		// The computed subnet_id cannot be set, so it is not offered.
+		want := []string{"cidr_block", "vpc_id", "count", "for_each", "depends_on", "lifecycle"}
+		if !reflect.DeepEqual(labels, want) {
+			t.Errorf("completions = %v, want %v", labels, want)
+		}
+	})
+
+	t.Run("hover", func(t *testing.T) {
+		tests := []struct {
+			id   int
+			want string
+		}{
+			{typeHover, "**vpc** resource\n\nRequired attributes: `cidr_block`"},
+			{attributeHover, "**cidr_block** string (required)\n\nThe IPv4 CIDR block."},
+		}
+		for _, tt := range tests {
+			var hover Hover
+			r.decode(t, tt.id, &hover)
+			if hover.Contents.Value != tt.want {
+				t.Errorf("hover = %q, want %q", hover.Contents.Value, tt.want)
+			}
+		}
+	})
+
+	t.Run("definition", func(t *testing.T) {
+		var locations []Location
+		r.decode(t, definition, &locations)
+		want := []Location{{URI: mainURI, Range: Range{Start: Position{Line: 3, Character: 8}, End: Position{Line: 3, Character: 9}}}}
+		if !reflect.DeepEqual(locations, want) {
+			t.Errorf("definition = %+v, want %+v", locations, want)
+		}
+	})
+}
+
+func TestServerExit(t *testing.T) {
+	tests := []struct {
+		name     string
+		shutdown bool
+		want     error
+		answered int
+	}{
+		{name: "after shutdown", shutdown: true, answered: 2},
+		{name: "without shutdown", want: ErrExitWithoutShutdown, answered: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &session{t: t}
+			c.request("initialize", map[string]interface{}{})
+			if tt.shutdown {
+				c.request("shutdown", nil)
+			}
+			c.notify("exit", nil)
+			// Nothing after exit is read.
+			c.request("textDocument/hover", nil)
+
+			r, err := c.run()
+			if err != tt.want {
+				t.Errorf("Run = %v, want %v", err, tt.want)
+			}
+			if len(r.results) != tt.answered {
+				t.Errorf("%d requests were answered, want %d", len(r.results), tt.answered)
+			}
+		})
+	}
+}
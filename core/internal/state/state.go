@@ -5,10 +5,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/tblang/core/internal/ast"
 )
 
 type State struct {
-	Version   string                    `json:"version"`
+	Version string `json:"version"`
+
+	// Serial is incremented every time the state is saved, so that a saved
+	// plan can tell whether the state has changed since it was made.
+	Serial    int64                     `json:"serial"`
 	Resources map[string]*ResourceState `json:"resources"`
 }
 
@@ -17,6 +23,11 @@ type ResourceState struct {
 	Type       string                 `json:"type"`
 	Status     string                 `json:"status"`
 	Attributes map[string]interface{} `json:"attributes"`
+	Lifecycle  *ast.Lifecycle         `json:"lifecycle,omitempty"`
+
+	// Dependencies are the resources this one depended on when it was last
+	// applied, which must outlive it when destroying.
+	Dependencies []string `json:"dependencies,omitempty"`
 }
 
 type Manager struct {
@@ -59,6 +70,7 @@ func (m *Manager) SaveState(state *State) error {
 	}
 
 	state.Version = "1.0"
+	state.Serial++
 
 	data, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
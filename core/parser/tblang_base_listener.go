@@ -19,6 +19,10 @@ func (s *BasetblangListener) EnterProgram(ctx *ProgramContext) {}
 
 func (s *BasetblangListener) ExitProgram(ctx *ProgramContext) {}
 
+func (s *BasetblangListener) EnterVarFile(ctx *VarFileContext) {}
+
+func (s *BasetblangListener) ExitVarFile(ctx *VarFileContext) {}
+
 func (s *BasetblangListener) EnterStatement(ctx *StatementContext) {}
 
 func (s *BasetblangListener) ExitStatement(ctx *StatementContext) {}
@@ -35,6 +39,30 @@ func (s *BasetblangListener) EnterForLoop(ctx *ForLoopContext) {}
 
 func (s *BasetblangListener) ExitForLoop(ctx *ForLoopContext) {}
 
+func (s *BasetblangListener) EnterIfStatement(ctx *IfStatementContext) {}
+
+func (s *BasetblangListener) ExitIfStatement(ctx *IfStatementContext) {}
+
+func (s *BasetblangListener) EnterElseClause(ctx *ElseClauseContext) {}
+
+func (s *BasetblangListener) ExitElseClause(ctx *ElseClauseContext) {}
+
+func (s *BasetblangListener) EnterFunctionDeclaration(ctx *FunctionDeclarationContext) {}
+
+func (s *BasetblangListener) ExitFunctionDeclaration(ctx *FunctionDeclarationContext) {}
+
+func (s *BasetblangListener) EnterParameterList(ctx *ParameterListContext) {}
+
+func (s *BasetblangListener) ExitParameterList(ctx *ParameterListContext) {}
+
+func (s *BasetblangListener) EnterReturnStatement(ctx *ReturnStatementContext) {}
+
+func (s *BasetblangListener) ExitReturnStatement(ctx *ReturnStatementContext) {}
+
+func (s *BasetblangListener) EnterImportStatement(ctx *ImportStatementContext) {}
+
+func (s *BasetblangListener) ExitImportStatement(ctx *ImportStatementContext) {}
+
 func (s *BasetblangListener) EnterProperty(ctx *PropertyContext) {}
 
 func (s *BasetblangListener) ExitProperty(ctx *PropertyContext) {}
@@ -62,3 +90,11 @@ func (s *BasetblangListener) ExitObjectProperty(ctx *ObjectPropertyContext) {}
 func (s *BasetblangListener) EnterArrayLiteral(ctx *ArrayLiteralContext) {}
 
 func (s *BasetblangListener) ExitArrayLiteral(ctx *ArrayLiteralContext) {}
+
+func (s *BasetblangListener) EnterListComprehension(ctx *ListComprehensionContext) {}
+
+func (s *BasetblangListener) ExitListComprehension(ctx *ListComprehensionContext) {}
+
+func (s *BasetblangListener) EnterMapComprehension(ctx *MapComprehensionContext) {}
+
+func (s *BasetblangListener) ExitMapComprehension(ctx *MapComprehensionContext) {}
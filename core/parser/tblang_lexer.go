@@ -41,99 +41,154 @@ func tblanglexerLexerInit() {
 		"DEFAULT_MODE",
 	}
 	staticData.LiteralNames = []string{
-		"", "'declare'", "'for'", "'in'", "", "", "", "", "'='", "':'", "';'",
-		"','", "'.'", "'('", "')'", "'{'", "'}'", "'['", "']'",
+		"", "'declare'", "'for'", "'in'", "'if'", "'else'", "'func'", "'return'",
+		"'import'", "'as'", "", "", "", "", "'='", "':'", "';'", "','", "'.'",
+		"'=='", "'!='", "'<='", "'>='", "'<'", "'>'", "'&&'", "'||'", "'!'",
+		"'?'", "'+'", "'-'", "'*'", "'/'", "'%'", "'=>'", "'('", "')'", "'{'",
+		"'}'", "'['", "']'",
 	}
 	staticData.SymbolicNames = []string{
-		"", "DECLARE", "FOR", "IN", "STRING_LITERAL", "NUMBER", "BOOLEAN", "IDENTIFIER",
-		"ASSIGN", "COLON", "SEMICOLON", "COMMA", "DOT", "LPAREN", "RPAREN",
-		"LBRACE", "RBRACE", "LBRACKET", "RBRACKET", "LINE_COMMENT", "BLOCK_COMMENT",
-		"WS",
+		"", "DECLARE", "FOR", "IN", "IF", "ELSE", "FUNC", "RETURN", "IMPORT",
+		"AS", "STRING_LITERAL", "NUMBER", "BOOLEAN", "IDENTIFIER", "ASSIGN",
+		"COLON", "SEMICOLON", "COMMA", "DOT", "EQ", "NEQ", "LE", "GE", "LT",
+		"GT", "AND", "OR", "NOT", "QUESTION", "PLUS", "MINUS", "STAR", "SLASH",
+		"PERCENT", "ARROW", "LPAREN", "RPAREN", "LBRACE", "RBRACE", "LBRACKET",
+		"RBRACKET", "LINE_COMMENT", "BLOCK_COMMENT", "WS",
 	}
 	staticData.RuleNames = []string{
-		"DECLARE", "FOR", "IN", "STRING_LITERAL", "NUMBER", "BOOLEAN", "IDENTIFIER",
-		"ASSIGN", "COLON", "SEMICOLON", "COMMA", "DOT", "LPAREN", "RPAREN",
-		"LBRACE", "RBRACE", "LBRACKET", "RBRACKET", "LINE_COMMENT", "BLOCK_COMMENT",
-		"WS",
+		"DECLARE", "FOR", "IN", "IF", "ELSE", "FUNC", "RETURN", "IMPORT", "AS",
+		"STRING_LITERAL", "NUMBER", "BOOLEAN", "IDENTIFIER", "ASSIGN", "COLON",
+		"SEMICOLON", "COMMA", "DOT", "EQ", "NEQ", "LE", "GE", "LT", "GT", "AND",
+		"OR", "NOT", "QUESTION", "PLUS", "MINUS", "STAR", "SLASH", "PERCENT",
+		"ARROW", "LPAREN", "RPAREN", "LBRACE", "RBRACE", "LBRACKET", "RBRACKET",
+		"LINE_COMMENT", "BLOCK_COMMENT", "WS",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 0, 21, 168, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
+		4, 0, 43, 278, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
 		4, 7, 4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2,
 		10, 7, 10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15,
 		7, 15, 2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7,
-		20, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1,
-		1, 1, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 1, 3, 5, 3, 63, 8, 3, 10, 3, 12,
-		3, 66, 9, 3, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 5, 3, 73, 8, 3, 10, 3, 12, 3,
-		76, 9, 3, 1, 3, 3, 3, 79, 8, 3, 1, 4, 3, 4, 82, 8, 4, 1, 4, 4, 4, 85, 8,
-		4, 11, 4, 12, 4, 86, 1, 4, 1, 4, 4, 4, 91, 8, 4, 11, 4, 12, 4, 92, 3, 4,
-		95, 8, 4, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 3, 5, 106,
-		8, 5, 1, 6, 1, 6, 5, 6, 110, 8, 6, 10, 6, 12, 6, 113, 9, 6, 1, 7, 1, 7,
-		1, 8, 1, 8, 1, 9, 1, 9, 1, 10, 1, 10, 1, 11, 1, 11, 1, 12, 1, 12, 1, 13,
+		20, 2, 21, 7, 21, 2, 22, 7, 22, 2, 23, 7, 23, 2, 24, 7, 24, 2, 25, 7, 25,
+		2, 26, 7, 26, 2, 27, 7, 27, 2, 28, 7, 28, 2, 29, 7, 29, 2, 30, 7, 30, 2,
+		31, 7, 31, 2, 32, 7, 32, 2, 33, 7, 33, 2, 34, 7, 34, 2, 35, 7, 35, 2, 36,
+		7, 36, 2, 37, 7, 37, 2, 38, 7, 38, 2, 39, 7, 39, 2, 40, 7, 40, 2, 41, 7,
+		41, 2, 42, 7, 42, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 1, 4, 1, 4, 1, 4,
+		1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 1, 5, 1, 5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 6,
+		1, 6, 1, 6, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 1, 8, 1, 8, 1, 8,
+		1, 9, 1, 9, 1, 9, 1, 9, 5, 9, 137, 8, 9, 10, 9, 12, 9, 140, 9, 9, 1, 9,
+		1, 9, 1, 9, 1, 9, 1, 9, 5, 9, 147, 8, 9, 10, 9, 12, 9, 150, 9, 9, 1, 9,
+		3, 9, 153, 8, 9, 1, 10, 4, 10, 156, 8, 10, 11, 10, 12, 10, 157, 1, 10,
+		1, 10, 4, 10, 162, 8, 10, 11, 10, 12, 10, 163, 3, 10, 166, 8, 10, 1, 11,
+		1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 3, 11, 177, 8,
+		11, 1, 12, 1, 12, 5, 12, 181, 8, 12, 10, 12, 12, 12, 184, 9, 12, 1, 13,
 		1, 13, 1, 14, 1, 14, 1, 15, 1, 15, 1, 16, 1, 16, 1, 17, 1, 17, 1, 18, 1,
-		18, 1, 18, 1, 18, 5, 18, 141, 8, 18, 10, 18, 12, 18, 144, 9, 18, 1, 18,
-		1, 18, 1, 19, 1, 19, 1, 19, 1, 19, 5, 19, 152, 8, 19, 10, 19, 12, 19, 155,
-		9, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 19, 1, 20, 4, 20, 163, 8, 20, 11,
-		20, 12, 20, 164, 1, 20, 1, 20, 1, 153, 0, 21, 1, 1, 3, 2, 5, 3, 7, 4, 9,
-		5, 11, 6, 13, 7, 15, 8, 17, 9, 19, 10, 21, 11, 23, 12, 25, 13, 27, 14,
-		29, 15, 31, 16, 33, 17, 35, 18, 37, 19, 39, 20, 41, 21, 1, 0, 7, 4, 0,
-		10, 10, 13, 13, 34, 34, 92, 92, 4, 0, 10, 10, 13, 13, 39, 39, 92, 92, 1,
-		0, 48, 57, 3, 0, 65, 90, 95, 95, 97, 122, 4, 0, 48, 57, 65, 90, 95, 95,
-		97, 122, 2, 0, 10, 10, 13, 13, 3, 0, 9, 10, 13, 13, 32, 32, 181, 0, 1,
-		1, 0, 0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0, 7, 1, 0, 0, 0, 0, 9,
-		1, 0, 0, 0, 0, 11, 1, 0, 0, 0, 0, 13, 1, 0, 0, 0, 0, 15, 1, 0, 0, 0, 0,
-		17, 1, 0, 0, 0, 0, 19, 1, 0, 0, 0, 0, 21, 1, 0, 0, 0, 0, 23, 1, 0, 0, 0,
-		0, 25, 1, 0, 0, 0, 0, 27, 1, 0, 0, 0, 0, 29, 1, 0, 0, 0, 0, 31, 1, 0, 0,
-		0, 0, 33, 1, 0, 0, 0, 0, 35, 1, 0, 0, 0, 0, 37, 1, 0, 0, 0, 0, 39, 1, 0,
-		0, 0, 0, 41, 1, 0, 0, 0, 1, 43, 1, 0, 0, 0, 3, 51, 1, 0, 0, 0, 5, 55, 1,
-		0, 0, 0, 7, 78, 1, 0, 0, 0, 9, 81, 1, 0, 0, 0, 11, 105, 1, 0, 0, 0, 13,
-		107, 1, 0, 0, 0, 15, 114, 1, 0, 0, 0, 17, 116, 1, 0, 0, 0, 19, 118, 1,
-		0, 0, 0, 21, 120, 1, 0, 0, 0, 23, 122, 1, 0, 0, 0, 25, 124, 1, 0, 0, 0,
-		27, 126, 1, 0, 0, 0, 29, 128, 1, 0, 0, 0, 31, 130, 1, 0, 0, 0, 33, 132,
-		1, 0, 0, 0, 35, 134, 1, 0, 0, 0, 37, 136, 1, 0, 0, 0, 39, 147, 1, 0, 0,
-		0, 41, 162, 1, 0, 0, 0, 43, 44, 5, 100, 0, 0, 44, 45, 5, 101, 0, 0, 45,
-		46, 5, 99, 0, 0, 46, 47, 5, 108, 0, 0, 47, 48, 5, 97, 0, 0, 48, 49, 5,
-		114, 0, 0, 49, 50, 5, 101, 0, 0, 50, 2, 1, 0, 0, 0, 51, 52, 5, 102, 0,
-		0, 52, 53, 5, 111, 0, 0, 53, 54, 5, 114, 0, 0, 54, 4, 1, 0, 0, 0, 55, 56,
-		5, 105, 0, 0, 56, 57, 5, 110, 0, 0, 57, 6, 1, 0, 0, 0, 58, 64, 5, 34, 0,
-		0, 59, 63, 8, 0, 0, 0, 60, 61, 5, 92, 0, 0, 61, 63, 9, 0, 0, 0, 62, 59,
-		1, 0, 0, 0, 62, 60, 1, 0, 0, 0, 63, 66, 1, 0, 0, 0, 64, 62, 1, 0, 0, 0,
-		64, 65, 1, 0, 0, 0, 65, 67, 1, 0, 0, 0, 66, 64, 1, 0, 0, 0, 67, 79, 5,
-		34, 0, 0, 68, 74, 5, 39, 0, 0, 69, 73, 8, 1, 0, 0, 70, 71, 5, 92, 0, 0,
-		71, 73, 9, 0, 0, 0, 72, 69, 1, 0, 0, 0, 72, 70, 1, 0, 0, 0, 73, 76, 1,
-		0, 0, 0, 74, 72, 1, 0, 0, 0, 74, 75, 1, 0, 0, 0, 75, 77, 1, 0, 0, 0, 76,
-		74, 1, 0, 0, 0, 77, 79, 5, 39, 0, 0, 78, 58, 1, 0, 0, 0, 78, 68, 1, 0,
-		0, 0, 79, 8, 1, 0, 0, 0, 80, 82, 5, 45, 0, 0, 81, 80, 1, 0, 0, 0, 81, 82,
-		1, 0, 0, 0, 82, 84, 1, 0, 0, 0, 83, 85, 7, 2, 0, 0, 84, 83, 1, 0, 0, 0,
-		85, 86, 1, 0, 0, 0, 86, 84, 1, 0, 0, 0, 86, 87, 1, 0, 0, 0, 87, 94, 1,
-		0, 0, 0, 88, 90, 5, 46, 0, 0, 89, 91, 7, 2, 0, 0, 90, 89, 1, 0, 0, 0, 91,
-		92, 1, 0, 0, 0, 92, 90, 1, 0, 0, 0, 92, 93, 1, 0, 0, 0, 93, 95, 1, 0, 0,
-		0, 94, 88, 1, 0, 0, 0, 94, 95, 1, 0, 0, 0, 95, 10, 1, 0, 0, 0, 96, 97,
-		5, 116, 0, 0, 97, 98, 5, 114, 0, 0, 98, 99, 5, 117, 0, 0, 99, 106, 5, 101,
-		0, 0, 100, 101, 5, 102, 0, 0, 101, 102, 5, 97, 0, 0, 102, 103, 5, 108,
-		0, 0, 103, 104, 5, 115, 0, 0, 104, 106, 5, 101, 0, 0, 105, 96, 1, 0, 0,
-		0, 105, 100, 1, 0, 0, 0, 106, 12, 1, 0, 0, 0, 107, 111, 7, 3, 0, 0, 108,
-		110, 7, 4, 0, 0, 109, 108, 1, 0, 0, 0, 110, 113, 1, 0, 0, 0, 111, 109,
-		1, 0, 0, 0, 111, 112, 1, 0, 0, 0, 112, 14, 1, 0, 0, 0, 113, 111, 1, 0,
-		0, 0, 114, 115, 5, 61, 0, 0, 115, 16, 1, 0, 0, 0, 116, 117, 5, 58, 0, 0,
-		117, 18, 1, 0, 0, 0, 118, 119, 5, 59, 0, 0, 119, 20, 1, 0, 0, 0, 120, 121,
-		5, 44, 0, 0, 121, 22, 1, 0, 0, 0, 122, 123, 5, 46, 0, 0, 123, 24, 1, 0,
-		0, 0, 124, 125, 5, 40, 0, 0, 125, 26, 1, 0, 0, 0, 126, 127, 5, 41, 0, 0,
-		127, 28, 1, 0, 0, 0, 128, 129, 5, 123, 0, 0, 129, 30, 1, 0, 0, 0, 130,
-		131, 5, 125, 0, 0, 131, 32, 1, 0, 0, 0, 132, 133, 5, 91, 0, 0, 133, 34,
-		1, 0, 0, 0, 134, 135, 5, 93, 0, 0, 135, 36, 1, 0, 0, 0, 136, 137, 5, 47,
-		0, 0, 137, 138, 5, 47, 0, 0, 138, 142, 1, 0, 0, 0, 139, 141, 8, 5, 0, 0,
-		140, 139, 1, 0, 0, 0, 141, 144, 1, 0, 0, 0, 142, 140, 1, 0, 0, 0, 142,
-		143, 1, 0, 0, 0, 143, 145, 1, 0, 0, 0, 144, 142, 1, 0, 0, 0, 145, 146,
-		6, 18, 0, 0, 146, 38, 1, 0, 0, 0, 147, 148, 5, 47, 0, 0, 148, 149, 5, 42,
-		0, 0, 149, 153, 1, 0, 0, 0, 150, 152, 9, 0, 0, 0, 151, 150, 1, 0, 0, 0,
-		152, 155, 1, 0, 0, 0, 153, 154, 1, 0, 0, 0, 153, 151, 1, 0, 0, 0, 154,
-		156, 1, 0, 0, 0, 155, 153, 1, 0, 0, 0, 156, 157, 5, 42, 0, 0, 157, 158,
-		5, 47, 0, 0, 158, 159, 1, 0, 0, 0, 159, 160, 6, 19, 0, 0, 160, 40, 1, 0,
-		0, 0, 161, 163, 7, 6, 0, 0, 162, 161, 1, 0, 0, 0, 163, 164, 1, 0, 0, 0,
-		164, 162, 1, 0, 0, 0, 164, 165, 1, 0, 0, 0, 165, 166, 1, 0, 0, 0, 166,
-		167, 6, 20, 0, 0, 167, 42, 1, 0, 0, 0, 15, 0, 62, 64, 72, 74, 78, 81, 86,
-		92, 94, 105, 111, 142, 153, 164, 1, 6, 0, 0,
+		18, 1, 18, 1, 19, 1, 19, 1, 19, 1, 20, 1, 20, 1, 20, 1, 21, 1, 21, 1, 21,
+		1, 22, 1, 22, 1, 23, 1, 23, 1, 24, 1, 24, 1, 24, 1, 25, 1, 25, 1, 25, 1,
+		26, 1, 26, 1, 27, 1, 27, 1, 28, 1, 28, 1, 29, 1, 29, 1, 30, 1, 30, 1, 31,
+		1, 31, 1, 32, 1, 32, 1, 33, 1, 33, 1, 33, 1, 34, 1, 34, 1, 35, 1, 35, 1,
+		36, 1, 36, 1, 37, 1, 37, 1, 38, 1, 38, 1, 39, 1, 39, 1, 40, 1, 40, 1, 40,
+		1, 40, 5, 40, 251, 8, 40, 10, 40, 12, 40, 254, 9, 40, 1, 40, 1, 40, 1,
+		41, 1, 41, 1, 41, 1, 41, 5, 41, 262, 8, 41, 10, 41, 12, 41, 265, 9, 41,
+		1, 41, 1, 41, 1, 41, 1, 41, 1, 41, 1, 42, 4, 42, 273, 8, 42, 11, 42, 12,
+		42, 274, 1, 42, 1, 42, 1, 263, 0, 43, 1, 1, 3, 2, 5, 3, 7, 4, 9, 5, 11,
+		6, 13, 7, 15, 8, 17, 9, 19, 10, 21, 11, 23, 12, 25, 13, 27, 14, 29, 15,
+		31, 16, 33, 17, 35, 18, 37, 19, 39, 20, 41, 21, 43, 22, 45, 23, 47, 24,
+		49, 25, 51, 26, 53, 27, 55, 28, 57, 29, 59, 30, 61, 31, 63, 32, 65, 33,
+		67, 34, 69, 35, 71, 36, 73, 37, 75, 38, 77, 39, 79, 40, 81, 41, 83, 42,
+		85, 43, 1, 0, 7, 4, 0, 10, 10, 13, 13, 34, 34, 92, 92, 4, 0, 10, 10, 13,
+		13, 39, 39, 92, 92, 1, 0, 48, 57, 3, 0, 65, 90, 95, 95, 97, 122, 4, 0,
+		48, 57, 65, 90, 95, 95, 97, 122, 2, 0, 10, 10, 13, 13, 3, 0, 9, 10, 13,
+		13, 32, 32, 290, 0, 1, 1, 0, 0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1, 0, 0, 0,
+		0, 7, 1, 0, 0, 0, 0, 9, 1, 0, 0, 0, 0, 11, 1, 0, 0, 0, 0, 13, 1, 0, 0,
+		0, 0, 15, 1, 0, 0, 0, 0, 17, 1, 0, 0, 0, 0, 19, 1, 0, 0, 0, 0, 21, 1, 0,
+		0, 0, 0, 23, 1, 0, 0, 0, 0, 25, 1, 0, 0, 0, 0, 27, 1, 0, 0, 0, 0, 29, 1,
+		0, 0, 0, 0, 31, 1, 0, 0, 0, 0, 33, 1, 0, 0, 0, 0, 35, 1, 0, 0, 0, 0, 37,
+		1, 0, 0, 0, 0, 39, 1, 0, 0, 0, 0, 41, 1, 0, 0, 0, 0, 43, 1, 0, 0, 0, 0,
+		45, 1, 0, 0, 0, 0, 47, 1, 0, 0, 0, 0, 49, 1, 0, 0, 0, 0, 51, 1, 0, 0, 0,
+		0, 53, 1, 0, 0, 0, 0, 55, 1, 0, 0, 0, 0, 57, 1, 0, 0, 0, 0, 59, 1, 0, 0,
+		0, 0, 61, 1, 0, 0, 0, 0, 63, 1, 0, 0, 0, 0, 65, 1, 0, 0, 0, 0, 67, 1, 0,
+		0, 0, 0, 69, 1, 0, 0, 0, 0, 71, 1, 0, 0, 0, 0, 73, 1, 0, 0, 0, 0, 75, 1,
+		0, 0, 0, 0, 77, 1, 0, 0, 0, 0, 79, 1, 0, 0, 0, 0, 81, 1, 0, 0, 0, 0, 83,
+		1, 0, 0, 0, 0, 85, 1, 0, 0, 0, 1, 87, 1, 0, 0, 0, 3, 95, 1, 0, 0, 0, 5,
+		99, 1, 0, 0, 0, 7, 102, 1, 0, 0, 0, 9, 105, 1, 0, 0, 0, 11, 110, 1, 0,
+		0, 0, 13, 115, 1, 0, 0, 0, 15, 122, 1, 0, 0, 0, 17, 129, 1, 0, 0, 0, 19,
+		152, 1, 0, 0, 0, 21, 155, 1, 0, 0, 0, 23, 176, 1, 0, 0, 0, 25, 178, 1,
+		0, 0, 0, 27, 185, 1, 0, 0, 0, 29, 187, 1, 0, 0, 0, 31, 189, 1, 0, 0, 0,
+		33, 191, 1, 0, 0, 0, 35, 193, 1, 0, 0, 0, 37, 195, 1, 0, 0, 0, 39, 198,
+		1, 0, 0, 0, 41, 201, 1, 0, 0, 0, 43, 204, 1, 0, 0, 0, 45, 207, 1, 0, 0,
+		0, 47, 209, 1, 0, 0, 0, 49, 211, 1, 0, 0, 0, 51, 214, 1, 0, 0, 0, 53, 217,
+		1, 0, 0, 0, 55, 219, 1, 0, 0, 0, 57, 221, 1, 0, 0, 0, 59, 223, 1, 0, 0,
+		0, 61, 225, 1, 0, 0, 0, 63, 227, 1, 0, 0, 0, 65, 229, 1, 0, 0, 0, 67, 231,
+		1, 0, 0, 0, 69, 234, 1, 0, 0, 0, 71, 236, 1, 0, 0, 0, 73, 238, 1, 0, 0,
+		0, 75, 240, 1, 0, 0, 0, 77, 242, 1, 0, 0, 0, 79, 244, 1, 0, 0, 0, 81, 246,
+		1, 0, 0, 0, 83, 257, 1, 0, 0, 0, 85, 272, 1, 0, 0, 0, 87, 88, 5, 100, 0,
+		0, 88, 89, 5, 101, 0, 0, 89, 90, 5, 99, 0, 0, 90, 91, 5, 108, 0, 0, 91,
+		92, 5, 97, 0, 0, 92, 93, 5, 114, 0, 0, 93, 94, 5, 101, 0, 0, 94, 2, 1,
+		0, 0, 0, 95, 96, 5, 102, 0, 0, 96, 97, 5, 111, 0, 0, 97, 98, 5, 114, 0,
+		0, 98, 4, 1, 0, 0, 0, 99, 100, 5, 105, 0, 0, 100, 101, 5, 110, 0, 0, 101,
+		6, 1, 0, 0, 0, 102, 103, 5, 105, 0, 0, 103, 104, 5, 102, 0, 0, 104, 8,
+		1, 0, 0, 0, 105, 106, 5, 101, 0, 0, 106, 107, 5, 108, 0, 0, 107, 108, 5,
+		115, 0, 0, 108, 109, 5, 101, 0, 0, 109, 10, 1, 0, 0, 0, 110, 111, 5, 102,
+		0, 0, 111, 112, 5, 117, 0, 0, 112, 113, 5, 110, 0, 0, 113, 114, 5, 99,
+		0, 0, 114, 12, 1, 0, 0, 0, 115, 116, 5, 114, 0, 0, 116, 117, 5, 101, 0,
+		0, 117, 118, 5, 116, 0, 0, 118, 119, 5, 117, 0, 0, 119, 120, 5, 114, 0,
+		0, 120, 121, 5, 110, 0, 0, 121, 14, 1, 0, 0, 0, 122, 123, 5, 105, 0, 0,
+		123, 124, 5, 109, 0, 0, 124, 125, 5, 112, 0, 0, 125, 126, 5, 111, 0, 0,
+		126, 127, 5, 114, 0, 0, 127, 128, 5, 116, 0, 0, 128, 16, 1, 0, 0, 0, 129,
+		130, 5, 97, 0, 0, 130, 131, 5, 115, 0, 0, 131, 18, 1, 0, 0, 0, 132, 138,
+		5, 34, 0, 0, 133, 137, 8, 0, 0, 0, 134, 135, 5, 92, 0, 0, 135, 137, 9,
+		0, 0, 0, 136, 133, 1, 0, 0, 0, 136, 134, 1, 0, 0, 0, 137, 140, 1, 0, 0,
+		0, 138, 136, 1, 0, 0, 0, 138, 139, 1, 0, 0, 0, 139, 141, 1, 0, 0, 0, 140,
+		138, 1, 0, 0, 0, 141, 153, 5, 34, 0, 0, 142, 148, 5, 39, 0, 0, 143, 147,
+		8, 1, 0, 0, 144, 145, 5, 92, 0, 0, 145, 147, 9, 0, 0, 0, 146, 143, 1, 0,
+		0, 0, 146, 144, 1, 0, 0, 0, 147, 150, 1, 0, 0, 0, 148, 146, 1, 0, 0, 0,
+		148, 149, 1, 0, 0, 0, 149, 151, 1, 0, 0, 0, 150, 148, 1, 0, 0, 0, 151,
+		153, 5, 39, 0, 0, 152, 132, 1, 0, 0, 0, 152, 142, 1, 0, 0, 0, 153, 20,
+		1, 0, 0, 0, 154, 156, 7, 2, 0, 0, 155, 154, 1, 0, 0, 0, 156, 157, 1, 0,
+		0, 0, 157, 155, 1, 0, 0, 0, 157, 158, 1, 0, 0, 0, 158, 165, 1, 0, 0, 0,
+		159, 161, 5, 46, 0, 0, 160, 162, 7, 2, 0, 0, 161, 160, 1, 0, 0, 0, 162,
+		163, 1, 0, 0, 0, 163, 161, 1, 0, 0, 0, 163, 164, 1, 0, 0, 0, 164, 166,
+		1, 0, 0, 0, 165, 159, 1, 0, 0, 0, 165, 166, 1, 0, 0, 0, 166, 22, 1, 0,
+		0, 0, 167, 168, 5, 116, 0, 0, 168, 169, 5, 114, 0, 0, 169, 170, 5, 117,
+		0, 0, 170, 177, 5, 101, 0, 0, 171, 172, 5, 102, 0, 0, 172, 173, 5, 97,
+		0, 0, 173, 174, 5, 108, 0, 0, 174, 175, 5, 115, 0, 0, 175, 177, 5, 101,
+		0, 0, 176, 167, 1, 0, 0, 0, 176, 171, 1, 0, 0, 0, 177, 24, 1, 0, 0, 0,
+		178, 182, 7, 3, 0, 0, 179, 181, 7, 4, 0, 0, 180, 179, 1, 0, 0, 0, 181,
+		184, 1, 0, 0, 0, 182, 180, 1, 0, 0, 0, 182, 183, 1, 0, 0, 0, 183, 26, 1,
+		0, 0, 0, 184, 182, 1, 0, 0, 0, 185, 186, 5, 61, 0, 0, 186, 28, 1, 0, 0,
+		0, 187, 188, 5, 58, 0, 0, 188, 30, 1, 0, 0, 0, 189, 190, 5, 59, 0, 0, 190,
+		32, 1, 0, 0, 0, 191, 192, 5, 44, 0, 0, 192, 34, 1, 0, 0, 0, 193, 194, 5,
+		46, 0, 0, 194, 36, 1, 0, 0, 0, 195, 196, 5, 61, 0, 0, 196, 197, 5, 61,
+		0, 0, 197, 38, 1, 0, 0, 0, 198, 199, 5, 33, 0, 0, 199, 200, 5, 61, 0, 0,
+		200, 40, 1, 0, 0, 0, 201, 202, 5, 60, 0, 0, 202, 203, 5, 61, 0, 0, 203,
+		42, 1, 0, 0, 0, 204, 205, 5, 62, 0, 0, 205, 206, 5, 61, 0, 0, 206, 44,
+		1, 0, 0, 0, 207, 208, 5, 60, 0, 0, 208, 46, 1, 0, 0, 0, 209, 210, 5, 62,
+		0, 0, 210, 48, 1, 0, 0, 0, 211, 212, 5, 38, 0, 0, 212, 213, 5, 38, 0, 0,
+		213, 50, 1, 0, 0, 0, 214, 215, 5, 124, 0, 0, 215, 216, 5, 124, 0, 0, 216,
+		52, 1, 0, 0, 0, 217, 218, 5, 33, 0, 0, 218, 54, 1, 0, 0, 0, 219, 220, 5,
+		63, 0, 0, 220, 56, 1, 0, 0, 0, 221, 222, 5, 43, 0, 0, 222, 58, 1, 0, 0,
+		0, 223, 224, 5, 45, 0, 0, 224, 60, 1, 0, 0, 0, 225, 226, 5, 42, 0, 0, 226,
+		62, 1, 0, 0, 0, 227, 228, 5, 47, 0, 0, 228, 64, 1, 0, 0, 0, 229, 230, 5,
+		37, 0, 0, 230, 66, 1, 0, 0, 0, 231, 232, 5, 61, 0, 0, 232, 233, 5, 62,
+		0, 0, 233, 68, 1, 0, 0, 0, 234, 235, 5, 40, 0, 0, 235, 70, 1, 0, 0, 0,
+		236, 237, 5, 41, 0, 0, 237, 72, 1, 0, 0, 0, 238, 239, 5, 123, 0, 0, 239,
+		74, 1, 0, 0, 0, 240, 241, 5, 125, 0, 0, 241, 76, 1, 0, 0, 0, 242, 243,
+		5, 91, 0, 0, 243, 78, 1, 0, 0, 0, 244, 245, 5, 93, 0, 0, 245, 80, 1, 0,
+		0, 0, 246, 247, 5, 47, 0, 0, 247, 248, 5, 47, 0, 0, 248, 252, 1, 0, 0,
+		0, 249, 251, 8, 5, 0, 0, 250, 249, 1, 0, 0, 0, 251, 254, 1, 0, 0, 0, 252,
+		250, 1, 0, 0, 0, 252, 253, 1, 0, 0, 0, 253, 255, 1, 0, 0, 0, 254, 252,
+		1, 0, 0, 0, 255, 256, 6, 40, 0, 0, 256, 82, 1, 0, 0, 0, 257, 258, 5, 47,
+		0, 0, 258, 259, 5, 42, 0, 0, 259, 263, 1, 0, 0, 0, 260, 262, 9, 0, 0, 0,
+		261, 260, 1, 0, 0, 0, 262, 265, 1, 0, 0, 0, 263, 264, 1, 0, 0, 0, 263,
+		261, 1, 0, 0, 0, 264, 266, 1, 0, 0, 0, 265, 263, 1, 0, 0, 0, 266, 267,
+		5, 42, 0, 0, 267, 268, 5, 47, 0, 0, 268, 269, 1, 0, 0, 0, 269, 270, 6,
+		41, 0, 0, 270, 84, 1, 0, 0, 0, 271, 273, 7, 6, 0, 0, 272, 271, 1, 0, 0,
+		0, 273, 274, 1, 0, 0, 0, 274, 272, 1, 0, 0, 0, 274, 275, 1, 0, 0, 0, 275,
+		276, 1, 0, 0, 0, 276, 277, 6, 42, 1, 0, 277, 86, 1, 0, 0, 0, 14, 0, 136,
+		138, 146, 148, 152, 157, 163, 165, 176, 182, 252, 263, 274, 2, 0, 1, 0,
+		6, 0, 0,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -170,22 +225,44 @@ const (
 	tblangLexerDECLARE        = 1
 	tblangLexerFOR            = 2
 	tblangLexerIN             = 3
-	tblangLexerSTRING_LITERAL = 4
-	tblangLexerNUMBER         = 5
-	tblangLexerBOOLEAN        = 6
-	tblangLexerIDENTIFIER     = 7
-	tblangLexerASSIGN         = 8
-	tblangLexerCOLON          = 9
-	tblangLexerSEMICOLON      = 10
-	tblangLexerCOMMA          = 11
-	tblangLexerDOT            = 12
-	tblangLexerLPAREN         = 13
-	tblangLexerRPAREN         = 14
-	tblangLexerLBRACE         = 15
-	tblangLexerRBRACE         = 16
-	tblangLexerLBRACKET       = 17
-	tblangLexerRBRACKET       = 18
-	tblangLexerLINE_COMMENT   = 19
-	tblangLexerBLOCK_COMMENT  = 20
-	tblangLexerWS             = 21
+	tblangLexerIF             = 4
+	tblangLexerELSE           = 5
+	tblangLexerFUNC           = 6
+	tblangLexerRETURN         = 7
+	tblangLexerIMPORT         = 8
+	tblangLexerAS             = 9
+	tblangLexerSTRING_LITERAL = 10
+	tblangLexerNUMBER         = 11
+	tblangLexerBOOLEAN        = 12
+	tblangLexerIDENTIFIER     = 13
+	tblangLexerASSIGN         = 14
+	tblangLexerCOLON          = 15
+	tblangLexerSEMICOLON      = 16
+	tblangLexerCOMMA          = 17
+	tblangLexerDOT            = 18
+	tblangLexerEQ             = 19
+	tblangLexerNEQ            = 20
+	tblangLexerLE             = 21
+	tblangLexerGE             = 22
+	tblangLexerLT             = 23
+	tblangLexerGT             = 24
+	tblangLexerAND            = 25
+	tblangLexerOR             = 26
+	tblangLexerNOT            = 27
+	tblangLexerQUESTION       = 28
+	tblangLexerPLUS           = 29
+	tblangLexerMINUS          = 30
+	tblangLexerSTAR           = 31
+	tblangLexerSLASH          = 32
+	tblangLexerPERCENT        = 33
+	tblangLexerARROW          = 34
+	tblangLexerLPAREN         = 35
+	tblangLexerRPAREN         = 36
+	tblangLexerLBRACE         = 37
+	tblangLexerRBRACE         = 38
+	tblangLexerLBRACKET       = 39
+	tblangLexerRBRACKET       = 40
+	tblangLexerLINE_COMMENT   = 41
+	tblangLexerBLOCK_COMMENT  = 42
+	tblangLexerWS             = 43
 )
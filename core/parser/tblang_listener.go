@@ -8,6 +8,8 @@ type tblangListener interface {
 
 	EnterProgram(c *ProgramContext)
 
+	EnterVarFile(c *VarFileContext)
+
 	EnterStatement(c *StatementContext)
 
 	EnterBlockDeclaration(c *BlockDeclarationContext)
@@ -16,6 +18,18 @@ type tblangListener interface {
 
 	EnterForLoop(c *ForLoopContext)
 
+	EnterIfStatement(c *IfStatementContext)
+
+	EnterElseClause(c *ElseClauseContext)
+
+	EnterFunctionDeclaration(c *FunctionDeclarationContext)
+
+	EnterParameterList(c *ParameterListContext)
+
+	EnterReturnStatement(c *ReturnStatementContext)
+
+	EnterImportStatement(c *ImportStatementContext)
+
 	EnterProperty(c *PropertyContext)
 
 	EnterFunctionCall(c *FunctionCallContext)
@@ -30,8 +44,14 @@ type tblangListener interface {
 
 	EnterArrayLiteral(c *ArrayLiteralContext)
 
+	EnterListComprehension(c *ListComprehensionContext)
+
+	EnterMapComprehension(c *MapComprehensionContext)
+
 	ExitProgram(c *ProgramContext)
 
+	ExitVarFile(c *VarFileContext)
+
 	ExitStatement(c *StatementContext)
 
 	ExitBlockDeclaration(c *BlockDeclarationContext)
@@ -40,6 +60,18 @@ type tblangListener interface {
 
 	ExitForLoop(c *ForLoopContext)
 
+	ExitIfStatement(c *IfStatementContext)
+
+	ExitElseClause(c *ElseClauseContext)
+
+	ExitFunctionDeclaration(c *FunctionDeclarationContext)
+
+	ExitParameterList(c *ParameterListContext)
+
+	ExitReturnStatement(c *ReturnStatementContext)
+
+	ExitImportStatement(c *ImportStatementContext)
+
 	ExitProperty(c *PropertyContext)
 
 	ExitFunctionCall(c *FunctionCallContext)
@@ -53,4 +85,8 @@ type tblangListener interface {
 	ExitObjectProperty(c *ObjectPropertyContext)
 
 	ExitArrayLiteral(c *ArrayLiteralContext)
+
+	ExitListComprehension(c *ListComprehensionContext)
+
+	ExitMapComprehension(c *MapComprehensionContext)
 }
@@ -31,83 +31,171 @@ var TblangParserStaticData struct {
 func tblangParserInit() {
 	staticData := &TblangParserStaticData
 	staticData.LiteralNames = []string{
-		"", "'declare'", "'for'", "'in'", "", "", "", "", "'='", "':'", "';'",
-		"','", "'.'", "'('", "')'", "'{'", "'}'", "'['", "']'",
+		"", "'declare'", "'for'", "'in'", "'if'", "'else'", "'func'", "'return'",
+		"'import'", "'as'", "", "", "", "", "'='", "':'", "';'", "','", "'.'",
+		"'=='", "'!='", "'<='", "'>='", "'<'", "'>'", "'&&'", "'||'", "'!'",
+		"'?'", "'+'", "'-'", "'*'", "'/'", "'%'", "'=>'", "'('", "')'", "'{'",
+		"'}'", "'['", "']'",
 	}
 	staticData.SymbolicNames = []string{
-		"", "DECLARE", "FOR", "IN", "STRING_LITERAL", "NUMBER", "BOOLEAN", "IDENTIFIER",
-		"ASSIGN", "COLON", "SEMICOLON", "COMMA", "DOT", "LPAREN", "RPAREN",
-		"LBRACE", "RBRACE", "LBRACKET", "RBRACKET", "LINE_COMMENT", "BLOCK_COMMENT",
-		"WS",
+		"", "DECLARE", "FOR", "IN", "IF", "ELSE", "FUNC", "RETURN", "IMPORT",
+		"AS", "STRING_LITERAL", "NUMBER", "BOOLEAN", "IDENTIFIER", "ASSIGN",
+		"COLON", "SEMICOLON", "COMMA", "DOT", "EQ", "NEQ", "LE", "GE", "LT",
+		"GT", "AND", "OR", "NOT", "QUESTION", "PLUS", "MINUS", "STAR", "SLASH",
+		"PERCENT", "ARROW", "LPAREN", "RPAREN", "LBRACE", "RBRACE", "LBRACKET",
+		"RBRACKET", "LINE_COMMENT", "BLOCK_COMMENT", "WS",
 	}
 	staticData.RuleNames = []string{
-		"program", "statement", "blockDeclaration", "variableDeclaration", "forLoop",
-		"property", "functionCall", "argumentList", "expression", "objectLiteral",
-		"objectProperty", "arrayLiteral",
+		"program", "varFile", "statement", "blockDeclaration", "variableDeclaration",
+		"forLoop", "ifStatement", "elseClause", "functionDeclaration", "parameterList",
+		"returnStatement", "importStatement", "property", "functionCall", "argumentList",
+		"expression", "objectLiteral", "objectProperty", "arrayLiteral", "listComprehension",
+		"mapComprehension",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 21, 144, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 43, 312, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
-		10, 2, 11, 7, 11, 1, 0, 5, 0, 26, 8, 0, 10, 0, 12, 0, 29, 9, 0, 1, 0, 1,
-		0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 38, 8, 1, 1, 2, 1, 2, 1, 2, 1, 2,
-		5, 2, 44, 8, 2, 10, 2, 12, 2, 47, 9, 2, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 1,
-		3, 1, 3, 3, 3, 56, 8, 3, 1, 4, 1, 4, 1, 4, 1, 4, 1, 4, 1, 4, 5, 4, 64,
-		8, 4, 10, 4, 12, 4, 67, 9, 4, 1, 4, 1, 4, 1, 5, 1, 5, 1, 5, 1, 5, 3, 5,
-		75, 8, 5, 1, 6, 1, 6, 1, 6, 3, 6, 80, 8, 6, 1, 6, 1, 6, 3, 6, 84, 8, 6,
-		1, 7, 1, 7, 1, 7, 5, 7, 89, 8, 7, 10, 7, 12, 7, 92, 9, 7, 1, 8, 1, 8, 1,
-		8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 3, 8, 106, 8,
-		8, 1, 8, 1, 8, 1, 8, 5, 8, 111, 8, 8, 10, 8, 12, 8, 114, 9, 8, 1, 9, 1,
-		9, 5, 9, 118, 8, 9, 10, 9, 12, 9, 121, 9, 9, 1, 9, 1, 9, 1, 10, 1, 10,
-		1, 10, 1, 10, 3, 10, 129, 8, 10, 1, 11, 1, 11, 1, 11, 1, 11, 5, 11, 135,
-		8, 11, 10, 11, 12, 11, 138, 9, 11, 3, 11, 140, 8, 11, 1, 11, 1, 11, 1,
-		11, 0, 1, 16, 12, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 0, 1, 1, 0,
-		8, 9, 155, 0, 27, 1, 0, 0, 0, 2, 37, 1, 0, 0, 0, 4, 39, 1, 0, 0, 0, 6,
-		50, 1, 0, 0, 0, 8, 57, 1, 0, 0, 0, 10, 70, 1, 0, 0, 0, 12, 76, 1, 0, 0,
-		0, 14, 85, 1, 0, 0, 0, 16, 105, 1, 0, 0, 0, 18, 115, 1, 0, 0, 0, 20, 124,
-		1, 0, 0, 0, 22, 130, 1, 0, 0, 0, 24, 26, 3, 2, 1, 0, 25, 24, 1, 0, 0, 0,
-		26, 29, 1, 0, 0, 0, 27, 25, 1, 0, 0, 0, 27, 28, 1, 0, 0, 0, 28, 30, 1,
-		0, 0, 0, 29, 27, 1, 0, 0, 0, 30, 31, 5, 0, 0, 1, 31, 1, 1, 0, 0, 0, 32,
-		38, 3, 4, 2, 0, 33, 38, 3, 6, 3, 0, 34, 38, 3, 8, 4, 0, 35, 38, 3, 12,
-		6, 0, 36, 38, 5, 10, 0, 0, 37, 32, 1, 0, 0, 0, 37, 33, 1, 0, 0, 0, 37,
-		34, 1, 0, 0, 0, 37, 35, 1, 0, 0, 0, 37, 36, 1, 0, 0, 0, 38, 3, 1, 0, 0,
-		0, 39, 40, 5, 7, 0, 0, 40, 41, 5, 4, 0, 0, 41, 45, 5, 15, 0, 0, 42, 44,
-		3, 10, 5, 0, 43, 42, 1, 0, 0, 0, 44, 47, 1, 0, 0, 0, 45, 43, 1, 0, 0, 0,
-		45, 46, 1, 0, 0, 0, 46, 48, 1, 0, 0, 0, 47, 45, 1, 0, 0, 0, 48, 49, 5,
-		16, 0, 0, 49, 5, 1, 0, 0, 0, 50, 51, 5, 1, 0, 0, 51, 52, 5, 7, 0, 0, 52,
-		53, 5, 8, 0, 0, 53, 55, 3, 16, 8, 0, 54, 56, 5, 10, 0, 0, 55, 54, 1, 0,
-		0, 0, 55, 56, 1, 0, 0, 0, 56, 7, 1, 0, 0, 0, 57, 58, 5, 2, 0, 0, 58, 59,
-		5, 7, 0, 0, 59, 60, 5, 3, 0, 0, 60, 61, 3, 16, 8, 0, 61, 65, 5, 15, 0,
-		0, 62, 64, 3, 2, 1, 0, 63, 62, 1, 0, 0, 0, 64, 67, 1, 0, 0, 0, 65, 63,
-		1, 0, 0, 0, 65, 66, 1, 0, 0, 0, 66, 68, 1, 0, 0, 0, 67, 65, 1, 0, 0, 0,
-		68, 69, 5, 16, 0, 0, 69, 9, 1, 0, 0, 0, 70, 71, 5, 7, 0, 0, 71, 72, 5,
-		8, 0, 0, 72, 74, 3, 16, 8, 0, 73, 75, 5, 10, 0, 0, 74, 73, 1, 0, 0, 0,
-		74, 75, 1, 0, 0, 0, 75, 11, 1, 0, 0, 0, 76, 77, 5, 7, 0, 0, 77, 79, 5,
-		13, 0, 0, 78, 80, 3, 14, 7, 0, 79, 78, 1, 0, 0, 0, 79, 80, 1, 0, 0, 0,
-		80, 81, 1, 0, 0, 0, 81, 83, 5, 14, 0, 0, 82, 84, 5, 10, 0, 0, 83, 82, 1,
-		0, 0, 0, 83, 84, 1, 0, 0, 0, 84, 13, 1, 0, 0, 0, 85, 90, 3, 16, 8, 0, 86,
-		87, 5, 11, 0, 0, 87, 89, 3, 16, 8, 0, 88, 86, 1, 0, 0, 0, 89, 92, 1, 0,
-		0, 0, 90, 88, 1, 0, 0, 0, 90, 91, 1, 0, 0, 0, 91, 15, 1, 0, 0, 0, 92, 90,
-		1, 0, 0, 0, 93, 94, 6, 8, -1, 0, 94, 106, 5, 4, 0, 0, 95, 106, 5, 5, 0,
-		0, 96, 106, 5, 6, 0, 0, 97, 106, 5, 7, 0, 0, 98, 106, 3, 18, 9, 0, 99,
-		106, 3, 22, 11, 0, 100, 106, 3, 12, 6, 0, 101, 102, 5, 13, 0, 0, 102, 103,
-		3, 16, 8, 0, 103, 104, 5, 14, 0, 0, 104, 106, 1, 0, 0, 0, 105, 93, 1, 0,
-		0, 0, 105, 95, 1, 0, 0, 0, 105, 96, 1, 0, 0, 0, 105, 97, 1, 0, 0, 0, 105,
-		98, 1, 0, 0, 0, 105, 99, 1, 0, 0, 0, 105, 100, 1, 0, 0, 0, 105, 101, 1,
-		0, 0, 0, 106, 112, 1, 0, 0, 0, 107, 108, 10, 2, 0, 0, 108, 109, 5, 12,
-		0, 0, 109, 111, 5, 7, 0, 0, 110, 107, 1, 0, 0, 0, 111, 114, 1, 0, 0, 0,
-		112, 110, 1, 0, 0, 0, 112, 113, 1, 0, 0, 0, 113, 17, 1, 0, 0, 0, 114, 112,
-		1, 0, 0, 0, 115, 119, 5, 15, 0, 0, 116, 118, 3, 20, 10, 0, 117, 116, 1,
-		0, 0, 0, 118, 121, 1, 0, 0, 0, 119, 117, 1, 0, 0, 0, 119, 120, 1, 0, 0,
-		0, 120, 122, 1, 0, 0, 0, 121, 119, 1, 0, 0, 0, 122, 123, 5, 16, 0, 0, 123,
-		19, 1, 0, 0, 0, 124, 125, 5, 7, 0, 0, 125, 126, 7, 0, 0, 0, 126, 128, 3,
-		16, 8, 0, 127, 129, 5, 11, 0, 0, 128, 127, 1, 0, 0, 0, 128, 129, 1, 0,
-		0, 0, 129, 21, 1, 0, 0, 0, 130, 139, 5, 17, 0, 0, 131, 136, 3, 16, 8, 0,
-		132, 133, 5, 11, 0, 0, 133, 135, 3, 16, 8, 0, 134, 132, 1, 0, 0, 0, 135,
-		138, 1, 0, 0, 0, 136, 134, 1, 0, 0, 0, 136, 137, 1, 0, 0, 0, 137, 140,
-		1, 0, 0, 0, 138, 136, 1, 0, 0, 0, 139, 131, 1, 0, 0, 0, 139, 140, 1, 0,
-		0, 0, 140, 141, 1, 0, 0, 0, 141, 142, 5, 18, 0, 0, 142, 23, 1, 0, 0, 0,
-		15, 27, 37, 45, 55, 65, 74, 79, 83, 90, 105, 112, 119, 128, 136, 139,
+		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15, 7, 15,
+		2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7, 20, 1,
+		0, 5, 0, 44, 8, 0, 10, 0, 12, 0, 47, 9, 0, 1, 0, 1, 0, 1, 1, 5, 1, 52,
+		8, 1, 10, 1, 12, 1, 55, 9, 1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2,
+		1, 2, 1, 2, 1, 2, 1, 2, 3, 2, 68, 8, 2, 1, 3, 1, 3, 1, 3, 1, 3, 5, 3, 74,
+		8, 3, 10, 3, 12, 3, 77, 9, 3, 1, 3, 1, 3, 1, 4, 1, 4, 1, 4, 1, 4, 1, 4,
+		3, 4, 86, 8, 4, 1, 5, 1, 5, 1, 5, 1, 5, 3, 5, 92, 8, 5, 1, 5, 1, 5, 1,
+		5, 1, 5, 5, 5, 98, 8, 5, 10, 5, 12, 5, 101, 9, 5, 1, 5, 1, 5, 1, 6, 1,
+		6, 1, 6, 1, 6, 5, 6, 109, 8, 6, 10, 6, 12, 6, 112, 9, 6, 1, 6, 1, 6, 3,
+		6, 116, 8, 6, 1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 5, 7, 123, 8, 7, 10, 7, 12,
+		7, 126, 9, 7, 1, 7, 3, 7, 129, 8, 7, 1, 8, 1, 8, 1, 8, 1, 8, 3, 8, 135,
+		8, 8, 1, 8, 1, 8, 1, 8, 5, 8, 140, 8, 8, 10, 8, 12, 8, 143, 9, 8, 1, 8,
+		1, 8, 1, 9, 1, 9, 1, 9, 5, 9, 150, 8, 9, 10, 9, 12, 9, 153, 9, 9, 1, 10,
+		1, 10, 1, 10, 3, 10, 158, 8, 10, 1, 11, 1, 11, 1, 11, 1, 11, 1, 11, 3,
+		11, 165, 8, 11, 1, 11, 3, 11, 168, 8, 11, 1, 12, 1, 12, 1, 12, 1, 12, 3,
+		12, 174, 8, 12, 1, 13, 1, 13, 1, 13, 3, 13, 179, 8, 13, 1, 13, 1, 13, 3,
+		13, 183, 8, 13, 1, 14, 1, 14, 1, 14, 5, 14, 188, 8, 14, 10, 14, 12, 14,
+		191, 9, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1,
+		15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 3, 15, 209, 8, 15,
+		1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1,
+		15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15,
+		1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 1,
+		15, 5, 15, 243, 8, 15, 10, 15, 12, 15, 246, 9, 15, 1, 16, 1, 16, 5, 16,
+		250, 8, 16, 10, 16, 12, 16, 253, 9, 16, 1, 16, 1, 16, 1, 17, 1, 17, 1,
+		17, 1, 17, 3, 17, 261, 8, 17, 1, 18, 1, 18, 1, 18, 1, 18, 5, 18, 267, 8,
+		18, 10, 18, 12, 18, 270, 9, 18, 3, 18, 272, 8, 18, 1, 18, 1, 18, 1, 19,
+		1, 19, 1, 19, 1, 19, 1, 19, 3, 19, 281, 8, 19, 1, 19, 1, 19, 1, 19, 1,
+		19, 1, 19, 1, 19, 3, 19, 289, 8, 19, 1, 19, 1, 19, 1, 20, 1, 20, 1, 20,
+		1, 20, 1, 20, 3, 20, 298, 8, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1, 20, 1,
+		20, 1, 20, 1, 20, 3, 20, 308, 8, 20, 1, 20, 1, 20, 1, 20, 0, 1, 30, 21,
+		0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36,
+		38, 40, 0, 7, 1, 0, 16, 17, 2, 0, 27, 27, 30, 30, 1, 0, 31, 33, 1, 0, 29,
+		30, 1, 0, 21, 24, 1, 0, 19, 20, 1, 0, 14, 15, 345, 0, 45, 1, 0, 0, 0, 2,
+		53, 1, 0, 0, 0, 4, 67, 1, 0, 0, 0, 6, 69, 1, 0, 0, 0, 8, 80, 1, 0, 0, 0,
+		10, 87, 1, 0, 0, 0, 12, 104, 1, 0, 0, 0, 14, 128, 1, 0, 0, 0, 16, 130,
+		1, 0, 0, 0, 18, 146, 1, 0, 0, 0, 20, 154, 1, 0, 0, 0, 22, 159, 1, 0, 0,
+		0, 24, 169, 1, 0, 0, 0, 26, 175, 1, 0, 0, 0, 28, 184, 1, 0, 0, 0, 30, 208,
+		1, 0, 0, 0, 32, 247, 1, 0, 0, 0, 34, 256, 1, 0, 0, 0, 36, 262, 1, 0, 0,
+		0, 38, 275, 1, 0, 0, 0, 40, 292, 1, 0, 0, 0, 42, 44, 3, 4, 2, 0, 43, 42,
+		1, 0, 0, 0, 44, 47, 1, 0, 0, 0, 45, 43, 1, 0, 0, 0, 45, 46, 1, 0, 0, 0,
+		46, 48, 1, 0, 0, 0, 47, 45, 1, 0, 0, 0, 48, 49, 5, 0, 0, 1, 49, 1, 1, 0,
+		0, 0, 50, 52, 3, 24, 12, 0, 51, 50, 1, 0, 0, 0, 52, 55, 1, 0, 0, 0, 53,
+		51, 1, 0, 0, 0, 53, 54, 1, 0, 0, 0, 54, 56, 1, 0, 0, 0, 55, 53, 1, 0, 0,
+		0, 56, 57, 5, 0, 0, 1, 57, 3, 1, 0, 0, 0, 58, 68, 3, 6, 3, 0, 59, 68, 3,
+		8, 4, 0, 60, 68, 3, 10, 5, 0, 61, 68, 3, 12, 6, 0, 62, 68, 3, 16, 8, 0,
+		63, 68, 3, 20, 10, 0, 64, 68, 3, 22, 11, 0, 65, 68, 3, 26, 13, 0, 66, 68,
+		5, 16, 0, 0, 67, 58, 1, 0, 0, 0, 67, 59, 1, 0, 0, 0, 67, 60, 1, 0, 0, 0,
+		67, 61, 1, 0, 0, 0, 67, 62, 1, 0, 0, 0, 67, 63, 1, 0, 0, 0, 67, 64, 1,
+		0, 0, 0, 67, 65, 1, 0, 0, 0, 67, 66, 1, 0, 0, 0, 68, 5, 1, 0, 0, 0, 69,
+		70, 5, 13, 0, 0, 70, 71, 5, 10, 0, 0, 71, 75, 5, 37, 0, 0, 72, 74, 3, 24,
+		12, 0, 73, 72, 1, 0, 0, 0, 74, 77, 1, 0, 0, 0, 75, 73, 1, 0, 0, 0, 75,
+		76, 1, 0, 0, 0, 76, 78, 1, 0, 0, 0, 77, 75, 1, 0, 0, 0, 78, 79, 5, 38,
+		0, 0, 79, 7, 1, 0, 0, 0, 80, 81, 5, 1, 0, 0, 81, 82, 5, 13, 0, 0, 82, 83,
+		5, 14, 0, 0, 83, 85, 3, 30, 15, 0, 84, 86, 5, 16, 0, 0, 85, 84, 1, 0, 0,
+		0, 85, 86, 1, 0, 0, 0, 86, 9, 1, 0, 0, 0, 87, 88, 5, 2, 0, 0, 88, 91, 5,
+		13, 0, 0, 89, 90, 5, 17, 0, 0, 90, 92, 5, 13, 0, 0, 91, 89, 1, 0, 0, 0,
+		91, 92, 1, 0, 0, 0, 92, 93, 1, 0, 0, 0, 93, 94, 5, 3, 0, 0, 94, 95, 3,
+		30, 15, 0, 95, 99, 5, 37, 0, 0, 96, 98, 3, 4, 2, 0, 97, 96, 1, 0, 0, 0,
+		98, 101, 1, 0, 0, 0, 99, 97, 1, 0, 0, 0, 99, 100, 1, 0, 0, 0, 100, 102,
+		1, 0, 0, 0, 101, 99, 1, 0, 0, 0, 102, 103, 5, 38, 0, 0, 103, 11, 1, 0,
+		0, 0, 104, 105, 5, 4, 0, 0, 105, 106, 3, 30, 15, 0, 106, 110, 5, 37, 0,
+		0, 107, 109, 3, 4, 2, 0, 108, 107, 1, 0, 0, 0, 109, 112, 1, 0, 0, 0, 110,
+		108, 1, 0, 0, 0, 110, 111, 1, 0, 0, 0, 111, 113, 1, 0, 0, 0, 112, 110,
+		1, 0, 0, 0, 113, 115, 5, 38, 0, 0, 114, 116, 3, 14, 7, 0, 115, 114, 1,
+		0, 0, 0, 115, 116, 1, 0, 0, 0, 116, 13, 1, 0, 0, 0, 117, 118, 5, 5, 0,
+		0, 118, 129, 3, 12, 6, 0, 119, 120, 5, 5, 0, 0, 120, 124, 5, 37, 0, 0,
+		121, 123, 3, 4, 2, 0, 122, 121, 1, 0, 0, 0, 123, 126, 1, 0, 0, 0, 124,
+		122, 1, 0, 0, 0, 124, 125, 1, 0, 0, 0, 125, 127, 1, 0, 0, 0, 126, 124,
+		1, 0, 0, 0, 127, 129, 5, 38, 0, 0, 128, 117, 1, 0, 0, 0, 128, 119, 1, 0,
+		0, 0, 129, 15, 1, 0, 0, 0, 130, 131, 5, 6, 0, 0, 131, 132, 5, 13, 0, 0,
+		132, 134, 5, 35, 0, 0, 133, 135, 3, 18, 9, 0, 134, 133, 1, 0, 0, 0, 134,
+		135, 1, 0, 0, 0, 135, 136, 1, 0, 0, 0, 136, 137, 5, 36, 0, 0, 137, 141,
+		5, 37, 0, 0, 138, 140, 3, 4, 2, 0, 139, 138, 1, 0, 0, 0, 140, 143, 1, 0,
+		0, 0, 141, 139, 1, 0, 0, 0, 141, 142, 1, 0, 0, 0, 142, 144, 1, 0, 0, 0,
+		143, 141, 1, 0, 0, 0, 144, 145, 5, 38, 0, 0, 145, 17, 1, 0, 0, 0, 146,
+		151, 5, 13, 0, 0, 147, 148, 5, 17, 0, 0, 148, 150, 5, 13, 0, 0, 149, 147,
+		1, 0, 0, 0, 150, 153, 1, 0, 0, 0, 151, 149, 1, 0, 0, 0, 151, 152, 1, 0,
+		0, 0, 152, 19, 1, 0, 0, 0, 153, 151, 1, 0, 0, 0, 154, 155, 5, 7, 0, 0,
+		155, 157, 3, 30, 15, 0, 156, 158, 5, 16, 0, 0, 157, 156, 1, 0, 0, 0, 157,
+		158, 1, 0, 0, 0, 158, 21, 1, 0, 0, 0, 159, 160, 5, 8, 0, 0, 160, 161, 5,
+		10, 0, 0, 161, 162, 5, 9, 0, 0, 162, 164, 5, 13, 0, 0, 163, 165, 3, 32,
+		16, 0, 164, 163, 1, 0, 0, 0, 164, 165, 1, 0, 0, 0, 165, 167, 1, 0, 0, 0,
+		166, 168, 5, 16, 0, 0, 167, 166, 1, 0, 0, 0, 167, 168, 1, 0, 0, 0, 168,
+		23, 1, 0, 0, 0, 169, 170, 5, 13, 0, 0, 170, 171, 5, 14, 0, 0, 171, 173,
+		3, 30, 15, 0, 172, 174, 7, 0, 0, 0, 173, 172, 1, 0, 0, 0, 173, 174, 1,
+		0, 0, 0, 174, 25, 1, 0, 0, 0, 175, 176, 5, 13, 0, 0, 176, 178, 5, 35, 0,
+		0, 177, 179, 3, 28, 14, 0, 178, 177, 1, 0, 0, 0, 178, 179, 1, 0, 0, 0,
+		179, 180, 1, 0, 0, 0, 180, 182, 5, 36, 0, 0, 181, 183, 5, 16, 0, 0, 182,
+		181, 1, 0, 0, 0, 182, 183, 1, 0, 0, 0, 183, 27, 1, 0, 0, 0, 184, 189, 3,
+		30, 15, 0, 185, 186, 5, 17, 0, 0, 186, 188, 3, 30, 15, 0, 187, 185, 1,
+		0, 0, 0, 188, 191, 1, 0, 0, 0, 189, 187, 1, 0, 0, 0, 189, 190, 1, 0, 0,
+		0, 190, 29, 1, 0, 0, 0, 191, 189, 1, 0, 0, 0, 192, 193, 6, 15, -1, 0, 193,
+		209, 5, 10, 0, 0, 194, 209, 5, 11, 0, 0, 195, 209, 5, 12, 0, 0, 196, 209,
+		5, 13, 0, 0, 197, 209, 3, 32, 16, 0, 198, 209, 3, 36, 18, 0, 199, 209,
+		3, 38, 19, 0, 200, 209, 3, 40, 20, 0, 201, 209, 3, 26, 13, 0, 202, 203,
+		5, 35, 0, 0, 203, 204, 3, 30, 15, 0, 204, 205, 5, 36, 0, 0, 205, 209, 1,
+		0, 0, 0, 206, 207, 7, 1, 0, 0, 207, 209, 3, 30, 15, 8, 208, 192, 1, 0,
+		0, 0, 208, 194, 1, 0, 0, 0, 208, 195, 1, 0, 0, 0, 208, 196, 1, 0, 0, 0,
+		208, 197, 1, 0, 0, 0, 208, 198, 1, 0, 0, 0, 208, 199, 1, 0, 0, 0, 208,
+		200, 1, 0, 0, 0, 208, 201, 1, 0, 0, 0, 208, 202, 1, 0, 0, 0, 208, 206,
+		1, 0, 0, 0, 209, 244, 1, 0, 0, 0, 210, 211, 10, 7, 0, 0, 211, 212, 7, 2,
+		0, 0, 212, 243, 3, 30, 15, 8, 213, 214, 10, 6, 0, 0, 214, 215, 7, 3, 0,
+		0, 215, 243, 3, 30, 15, 7, 216, 217, 10, 5, 0, 0, 217, 218, 7, 4, 0, 0,
+		218, 243, 3, 30, 15, 6, 219, 220, 10, 4, 0, 0, 220, 221, 7, 5, 0, 0, 221,
+		243, 3, 30, 15, 5, 222, 223, 10, 3, 0, 0, 223, 224, 5, 25, 0, 0, 224, 243,
+		3, 30, 15, 4, 225, 226, 10, 2, 0, 0, 226, 227, 5, 26, 0, 0, 227, 243, 3,
+		30, 15, 3, 228, 229, 10, 1, 0, 0, 229, 230, 5, 28, 0, 0, 230, 231, 3, 30,
+		15, 0, 231, 232, 5, 15, 0, 0, 232, 233, 3, 30, 15, 1, 233, 243, 1, 0, 0,
+		0, 234, 235, 10, 11, 0, 0, 235, 236, 5, 18, 0, 0, 236, 243, 5, 13, 0, 0,
+		237, 238, 10, 10, 0, 0, 238, 239, 5, 39, 0, 0, 239, 240, 3, 30, 15, 0,
+		240, 241, 5, 40, 0, 0, 241, 243, 1, 0, 0, 0, 242, 210, 1, 0, 0, 0, 242,
+		213, 1, 0, 0, 0, 242, 216, 1, 0, 0, 0, 242, 219, 1, 0, 0, 0, 242, 222,
+		1, 0, 0, 0, 242, 225, 1, 0, 0, 0, 242, 228, 1, 0, 0, 0, 242, 234, 1, 0,
+		0, 0, 242, 237, 1, 0, 0, 0, 243, 246, 1, 0, 0, 0, 244, 242, 1, 0, 0, 0,
+		244, 245, 1, 0, 0, 0, 245, 31, 1, 0, 0, 0, 246, 244, 1, 0, 0, 0, 247, 251,
+		5, 37, 0, 0, 248, 250, 3, 34, 17, 0, 249, 248, 1, 0, 0, 0, 250, 253, 1,
+		0, 0, 0, 251, 249, 1, 0, 0, 0, 251, 252, 1, 0, 0, 0, 252, 254, 1, 0, 0,
+		0, 253, 251, 1, 0, 0, 0, 254, 255, 5, 38, 0, 0, 255, 33, 1, 0, 0, 0, 256,
+		257, 5, 13, 0, 0, 257, 258, 7, 6, 0, 0, 258, 260, 3, 30, 15, 0, 259, 261,
+		5, 17, 0, 0, 260, 259, 1, 0, 0, 0, 260, 261, 1, 0, 0, 0, 261, 35, 1, 0,
+		0, 0, 262, 271, 5, 39, 0, 0, 263, 268, 3, 30, 15, 0, 264, 265, 5, 17, 0,
+		0, 265, 267, 3, 30, 15, 0, 266, 264, 1, 0, 0, 0, 267, 270, 1, 0, 0, 0,
+		268, 266, 1, 0, 0, 0, 268, 269, 1, 0, 0, 0, 269, 272, 1, 0, 0, 0, 270,
+		268, 1, 0, 0, 0, 271, 263, 1, 0, 0, 0, 271, 272, 1, 0, 0, 0, 272, 273,
+		1, 0, 0, 0, 273, 274, 5, 40, 0, 0, 274, 37, 1, 0, 0, 0, 275, 276, 5, 39,
+		0, 0, 276, 277, 5, 2, 0, 0, 277, 280, 5, 13, 0, 0, 278, 279, 5, 17, 0,
+		0, 279, 281, 5, 13, 0, 0, 280, 278, 1, 0, 0, 0, 280, 281, 1, 0, 0, 0, 281,
+		282, 1, 0, 0, 0, 282, 283, 5, 3, 0, 0, 283, 284, 3, 30, 15, 0, 284, 285,
+		5, 15, 0, 0, 285, 288, 3, 30, 15, 0, 286, 287, 5, 4, 0, 0, 287, 289, 3,
+		30, 15, 0, 288, 286, 1, 0, 0, 0, 288, 289, 1, 0, 0, 0, 289, 290, 1, 0,
+		0, 0, 290, 291, 5, 40, 0, 0, 291, 39, 1, 0, 0, 0, 292, 293, 5, 37, 0, 0,
+		293, 294, 5, 2, 0, 0, 294, 297, 5, 13, 0, 0, 295, 296, 5, 17, 0, 0, 296,
+		298, 5, 13, 0, 0, 297, 295, 1, 0, 0, 0, 297, 298, 1, 0, 0, 0, 298, 299,
+		1, 0, 0, 0, 299, 300, 5, 3, 0, 0, 300, 301, 3, 30, 15, 0, 301, 302, 5,
+		15, 0, 0, 302, 303, 3, 30, 15, 0, 303, 304, 5, 34, 0, 0, 304, 307, 3, 30,
+		15, 0, 305, 306, 5, 4, 0, 0, 306, 308, 3, 30, 15, 0, 307, 305, 1, 0, 0,
+		0, 307, 308, 1, 0, 0, 0, 308, 309, 1, 0, 0, 0, 309, 310, 5, 38, 0, 0, 310,
+		41, 1, 0, 0, 0, 32, 45, 53, 67, 75, 85, 91, 99, 110, 115, 124, 128, 134,
+		141, 151, 157, 164, 167, 173, 178, 182, 189, 208, 242, 244, 251, 260, 268,
+		271, 280, 288, 297, 307,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -143,39 +231,70 @@ const (
 	tblangParserDECLARE        = 1
 	tblangParserFOR            = 2
 	tblangParserIN             = 3
-	tblangParserSTRING_LITERAL = 4
-	tblangParserNUMBER         = 5
-	tblangParserBOOLEAN        = 6
-	tblangParserIDENTIFIER     = 7
-	tblangParserASSIGN         = 8
-	tblangParserCOLON          = 9
-	tblangParserSEMICOLON      = 10
-	tblangParserCOMMA          = 11
-	tblangParserDOT            = 12
-	tblangParserLPAREN         = 13
-	tblangParserRPAREN         = 14
-	tblangParserLBRACE         = 15
-	tblangParserRBRACE         = 16
-	tblangParserLBRACKET       = 17
-	tblangParserRBRACKET       = 18
-	tblangParserLINE_COMMENT   = 19
-	tblangParserBLOCK_COMMENT  = 20
-	tblangParserWS             = 21
+	tblangParserIF             = 4
+	tblangParserELSE           = 5
+	tblangParserFUNC           = 6
+	tblangParserRETURN         = 7
+	tblangParserIMPORT         = 8
+	tblangParserAS             = 9
+	tblangParserSTRING_LITERAL = 10
+	tblangParserNUMBER         = 11
+	tblangParserBOOLEAN        = 12
+	tblangParserIDENTIFIER     = 13
+	tblangParserASSIGN         = 14
+	tblangParserCOLON          = 15
+	tblangParserSEMICOLON      = 16
+	tblangParserCOMMA          = 17
+	tblangParserDOT            = 18
+	tblangParserEQ             = 19
+	tblangParserNEQ            = 20
+	tblangParserLE             = 21
+	tblangParserGE             = 22
+	tblangParserLT             = 23
+	tblangParserGT             = 24
+	tblangParserAND            = 25
+	tblangParserOR             = 26
+	tblangParserNOT            = 27
+	tblangParserQUESTION       = 28
+	tblangParserPLUS           = 29
+	tblangParserMINUS          = 30
+	tblangParserSTAR           = 31
+	tblangParserSLASH          = 32
+	tblangParserPERCENT        = 33
+	tblangParserARROW          = 34
+	tblangParserLPAREN         = 35
+	tblangParserRPAREN         = 36
+	tblangParserLBRACE         = 37
+	tblangParserRBRACE         = 38
+	tblangParserLBRACKET       = 39
+	tblangParserRBRACKET       = 40
+	tblangParserLINE_COMMENT   = 41
+	tblangParserBLOCK_COMMENT  = 42
+	tblangParserWS             = 43
 )
 
 const (
 	tblangParserRULE_program             = 0
-	tblangParserRULE_statement           = 1
-	tblangParserRULE_blockDeclaration    = 2
-	tblangParserRULE_variableDeclaration = 3
-	tblangParserRULE_forLoop             = 4
-	tblangParserRULE_property            = 5
-	tblangParserRULE_functionCall        = 6
-	tblangParserRULE_argumentList        = 7
-	tblangParserRULE_expression          = 8
-	tblangParserRULE_objectLiteral       = 9
-	tblangParserRULE_objectProperty      = 10
-	tblangParserRULE_arrayLiteral        = 11
+	tblangParserRULE_varFile             = 1
+	tblangParserRULE_statement           = 2
+	tblangParserRULE_blockDeclaration    = 3
+	tblangParserRULE_variableDeclaration = 4
+	tblangParserRULE_forLoop             = 5
+	tblangParserRULE_ifStatement         = 6
+	tblangParserRULE_elseClause          = 7
+	tblangParserRULE_functionDeclaration = 8
+	tblangParserRULE_parameterList       = 9
+	tblangParserRULE_returnStatement     = 10
+	tblangParserRULE_importStatement     = 11
+	tblangParserRULE_property            = 12
+	tblangParserRULE_functionCall        = 13
+	tblangParserRULE_argumentList        = 14
+	tblangParserRULE_expression          = 15
+	tblangParserRULE_objectLiteral       = 16
+	tblangParserRULE_objectProperty      = 17
+	tblangParserRULE_arrayLiteral        = 18
+	tblangParserRULE_listComprehension   = 19
+	tblangParserRULE_mapComprehension    = 20
 )
 
 type IProgramContext interface {
@@ -293,20 +412,177 @@ func (p *tblangParser) Program() (localctx IProgramContext) {
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(27)
+	p.SetState(45)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 	_la = p.GetTokenStream().LA(1)
 
-	for (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&1158) != 0 {
+	for (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&74198) != 0 {
 		{
-			p.SetState(24)
+			p.SetState(42)
 			p.Statement()
 		}
 
-		p.SetState(29)
+		p.SetState(47)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(48)
+		p.Match(tblangParserEOF)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit
+}
+
+type IVarFileContext interface {
+	antlr.ParserRuleContext
+
+	GetParser() antlr.Parser
+
+	EOF() antlr.TerminalNode
+	AllProperty() []IPropertyContext
+	Property(i int) IPropertyContext
+
+	IsVarFileContext()
+}
+
+type VarFileContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyVarFileContext() *VarFileContext {
+	var p = new(VarFileContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_varFile
+	return p
+}
+
+func InitEmptyVarFileContext(p *VarFileContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_varFile
+}
+
+func (*VarFileContext) IsVarFileContext() {}
+
+func NewVarFileContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *VarFileContext {
+	var p = new(VarFileContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = tblangParserRULE_varFile
+
+	return p
+}
+
+func (s *VarFileContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *VarFileContext) EOF() antlr.TerminalNode {
+	return s.GetToken(tblangParserEOF, 0)
+}
+
+func (s *VarFileContext) AllProperty() []IPropertyContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IPropertyContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IPropertyContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IPropertyContext); ok {
+			tst[i] = t.(IPropertyContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *VarFileContext) Property(i int) IPropertyContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IPropertyContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IPropertyContext)
+}
+
+func (s *VarFileContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *VarFileContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *VarFileContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.EnterVarFile(s)
+	}
+}
+
+func (s *VarFileContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.ExitVarFile(s)
+	}
+}
+
+func (p *tblangParser) VarFile() (localctx IVarFileContext) {
+	localctx = NewVarFileContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 2, tblangParserRULE_varFile)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(53)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == tblangParserIDENTIFIER {
+		{
+			p.SetState(50)
+			p.Property()
+		}
+
+		p.SetState(55)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -314,7 +590,7 @@ func (p *tblangParser) Program() (localctx IProgramContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(30)
+		p.SetState(56)
 		p.Match(tblangParserEOF)
 		if p.HasError() {
 
@@ -343,6 +619,10 @@ type IStatementContext interface {
 	BlockDeclaration() IBlockDeclarationContext
 	VariableDeclaration() IVariableDeclarationContext
 	ForLoop() IForLoopContext
+	IfStatement() IIfStatementContext
+	FunctionDeclaration() IFunctionDeclarationContext
+	ReturnStatement() IReturnStatementContext
+	ImportStatement() IImportStatementContext
 	FunctionCall() IFunctionCallContext
 	SEMICOLON() antlr.TerminalNode
 
@@ -429,6 +709,70 @@ func (s *StatementContext) ForLoop() IForLoopContext {
 	return t.(IForLoopContext)
 }
 
+func (s *StatementContext) IfStatement() IIfStatementContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IIfStatementContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIfStatementContext)
+}
+
+func (s *StatementContext) FunctionDeclaration() IFunctionDeclarationContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IFunctionDeclarationContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFunctionDeclarationContext)
+}
+
+func (s *StatementContext) ReturnStatement() IReturnStatementContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IReturnStatementContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IReturnStatementContext)
+}
+
+func (s *StatementContext) ImportStatement() IImportStatementContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IImportStatementContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IImportStatementContext)
+}
+
 func (s *StatementContext) FunctionCall() IFunctionCallContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
@@ -471,46 +815,74 @@ func (s *StatementContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *tblangParser) Statement() (localctx IStatementContext) {
 	localctx = NewStatementContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 2, tblangParserRULE_statement)
-	p.SetState(37)
+	p.EnterRule(localctx, 4, tblangParserRULE_statement)
+	p.SetState(67)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 1, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 2, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(32)
+			p.SetState(58)
 			p.BlockDeclaration()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(33)
+			p.SetState(59)
 			p.VariableDeclaration()
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(34)
+			p.SetState(60)
 			p.ForLoop()
 		}
 
 	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(35)
-			p.FunctionCall()
+			p.SetState(61)
+			p.IfStatement()
 		}
 
 	case 5:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(36)
+			p.SetState(62)
+			p.FunctionDeclaration()
+		}
+
+	case 6:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(63)
+			p.ReturnStatement()
+		}
+
+	case 7:
+		p.EnterOuterAlt(localctx, 7)
+		{
+			p.SetState(64)
+			p.ImportStatement()
+		}
+
+	case 8:
+		p.EnterOuterAlt(localctx, 8)
+		{
+			p.SetState(65)
+			p.FunctionCall()
+		}
+
+	case 9:
+		p.EnterOuterAlt(localctx, 9)
+		{
+			p.SetState(66)
 			p.Match(tblangParserSEMICOLON)
 			if p.HasError() {
 
@@ -661,12 +1033,12 @@ func (s *BlockDeclarationContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *tblangParser) BlockDeclaration() (localctx IBlockDeclarationContext) {
 	localctx = NewBlockDeclarationContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 4, tblangParserRULE_blockDeclaration)
+	p.EnterRule(localctx, 6, tblangParserRULE_blockDeclaration)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(39)
+		p.SetState(69)
 		p.Match(tblangParserIDENTIFIER)
 		if p.HasError() {
 
@@ -674,7 +1046,7 @@ func (p *tblangParser) BlockDeclaration() (localctx IBlockDeclarationContext) {
 		}
 	}
 	{
-		p.SetState(40)
+		p.SetState(70)
 		p.Match(tblangParserSTRING_LITERAL)
 		if p.HasError() {
 
@@ -682,14 +1054,14 @@ func (p *tblangParser) BlockDeclaration() (localctx IBlockDeclarationContext) {
 		}
 	}
 	{
-		p.SetState(41)
+		p.SetState(71)
 		p.Match(tblangParserLBRACE)
 		if p.HasError() {
 
 			goto errorExit
 		}
 	}
-	p.SetState(45)
+	p.SetState(75)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -698,11 +1070,11 @@ func (p *tblangParser) BlockDeclaration() (localctx IBlockDeclarationContext) {
 
 	for _la == tblangParserIDENTIFIER {
 		{
-			p.SetState(42)
+			p.SetState(72)
 			p.Property()
 		}
 
-		p.SetState(47)
+		p.SetState(77)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -710,7 +1082,7 @@ func (p *tblangParser) BlockDeclaration() (localctx IBlockDeclarationContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(48)
+		p.SetState(78)
 		p.Match(tblangParserRBRACE)
 		if p.HasError() {
 
@@ -831,10 +1203,10 @@ func (s *VariableDeclarationContext) ExitRule(listener antlr.ParseTreeListener)
 
 func (p *tblangParser) VariableDeclaration() (localctx IVariableDeclarationContext) {
 	localctx = NewVariableDeclarationContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 6, tblangParserRULE_variableDeclaration)
+	p.EnterRule(localctx, 8, tblangParserRULE_variableDeclaration)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(50)
+		p.SetState(80)
 		p.Match(tblangParserDECLARE)
 		if p.HasError() {
 
@@ -842,7 +1214,7 @@ func (p *tblangParser) VariableDeclaration() (localctx IVariableDeclarationConte
 		}
 	}
 	{
-		p.SetState(51)
+		p.SetState(81)
 		p.Match(tblangParserIDENTIFIER)
 		if p.HasError() {
 
@@ -850,7 +1222,7 @@ func (p *tblangParser) VariableDeclaration() (localctx IVariableDeclarationConte
 		}
 	}
 	{
-		p.SetState(52)
+		p.SetState(82)
 		p.Match(tblangParserASSIGN)
 		if p.HasError() {
 
@@ -858,15 +1230,15 @@ func (p *tblangParser) VariableDeclaration() (localctx IVariableDeclarationConte
 		}
 	}
 	{
-		p.SetState(53)
+		p.SetState(83)
 		p.expression(0)
 	}
-	p.SetState(55)
+	p.SetState(85)
 	p.GetErrorHandler().Sync(p)
 
-	if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 3, p.GetParserRuleContext()) == 1 {
+	if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 4, p.GetParserRuleContext()) == 1 {
 		{
-			p.SetState(54)
+			p.SetState(84)
 			p.Match(tblangParserSEMICOLON)
 			if p.HasError() {
 
@@ -897,11 +1269,13 @@ type IForLoopContext interface {
 	GetParser() antlr.Parser
 
 	FOR() antlr.TerminalNode
-	IDENTIFIER() antlr.TerminalNode
+	AllIDENTIFIER() []antlr.TerminalNode
+	IDENTIFIER(i int) antlr.TerminalNode
 	IN() antlr.TerminalNode
 	Expression() IExpressionContext
 	LBRACE() antlr.TerminalNode
 	RBRACE() antlr.TerminalNode
+	COMMA() antlr.TerminalNode
 	AllStatement() []IStatementContext
 	Statement(i int) IStatementContext
 
@@ -944,8 +1318,12 @@ func (s *ForLoopContext) FOR() antlr.TerminalNode {
 	return s.GetToken(tblangParserFOR, 0)
 }
 
-func (s *ForLoopContext) IDENTIFIER() antlr.TerminalNode {
-	return s.GetToken(tblangParserIDENTIFIER, 0)
+func (s *ForLoopContext) AllIDENTIFIER() []antlr.TerminalNode {
+	return s.GetTokens(tblangParserIDENTIFIER)
+}
+
+func (s *ForLoopContext) IDENTIFIER(i int) antlr.TerminalNode {
+	return s.GetToken(tblangParserIDENTIFIER, i)
 }
 
 func (s *ForLoopContext) IN() antlr.TerminalNode {
@@ -976,6 +1354,10 @@ func (s *ForLoopContext) RBRACE() antlr.TerminalNode {
 	return s.GetToken(tblangParserRBRACE, 0)
 }
 
+func (s *ForLoopContext) COMMA() antlr.TerminalNode {
+	return s.GetToken(tblangParserCOMMA, 0)
+}
+
 func (s *ForLoopContext) AllStatement() []IStatementContext {
 	children := s.GetChildren()
 	len := 0
@@ -1039,12 +1421,12 @@ func (s *ForLoopContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *tblangParser) ForLoop() (localctx IForLoopContext) {
 	localctx = NewForLoopContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 8, tblangParserRULE_forLoop)
+	p.EnterRule(localctx, 10, tblangParserRULE_forLoop)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(57)
+		p.SetState(87)
 		p.Match(tblangParserFOR)
 		if p.HasError() {
 
@@ -1052,15 +1434,41 @@ func (p *tblangParser) ForLoop() (localctx IForLoopContext) {
 		}
 	}
 	{
-		p.SetState(58)
+		p.SetState(88)
 		p.Match(tblangParserIDENTIFIER)
 		if p.HasError() {
 
 			goto errorExit
 		}
 	}
+	p.SetState(91)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == tblangParserCOMMA {
+		{
+			p.SetState(89)
+			p.Match(tblangParserCOMMA)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(90)
+			p.Match(tblangParserIDENTIFIER)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+
+	}
 	{
-		p.SetState(59)
+		p.SetState(93)
 		p.Match(tblangParserIN)
 		if p.HasError() {
 
@@ -1068,31 +1476,31 @@ func (p *tblangParser) ForLoop() (localctx IForLoopContext) {
 		}
 	}
 	{
-		p.SetState(60)
+		p.SetState(94)
 		p.expression(0)
 	}
 	{
-		p.SetState(61)
+		p.SetState(95)
 		p.Match(tblangParserLBRACE)
 		if p.HasError() {
 
 			goto errorExit
 		}
 	}
-	p.SetState(65)
+	p.SetState(99)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 	_la = p.GetTokenStream().LA(1)
 
-	for (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&1158) != 0 {
+	for (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&74198) != 0 {
 		{
-			p.SetState(62)
+			p.SetState(96)
 			p.Statement()
 		}
 
-		p.SetState(67)
+		p.SetState(101)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -1100,7 +1508,7 @@ func (p *tblangParser) ForLoop() (localctx IForLoopContext) {
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(68)
+		p.SetState(102)
 		p.Match(tblangParserRBRACE)
 		if p.HasError() {
 
@@ -1121,60 +1529,59 @@ errorExit:
 	goto errorExit
 }
 
-type IPropertyContext interface {
+type IIfStatementContext interface {
 	antlr.ParserRuleContext
 
 	GetParser() antlr.Parser
 
-	IDENTIFIER() antlr.TerminalNode
-	ASSIGN() antlr.TerminalNode
+	IF() antlr.TerminalNode
 	Expression() IExpressionContext
-	SEMICOLON() antlr.TerminalNode
+	LBRACE() antlr.TerminalNode
+	RBRACE() antlr.TerminalNode
+	AllStatement() []IStatementContext
+	Statement(i int) IStatementContext
+	ElseClause() IElseClauseContext
 
-	IsPropertyContext()
+	IsIfStatementContext()
 }
 
-type PropertyContext struct {
+type IfStatementContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyPropertyContext() *PropertyContext {
-	var p = new(PropertyContext)
+func NewEmptyIfStatementContext() *IfStatementContext {
+	var p = new(IfStatementContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_property
+	p.RuleIndex = tblangParserRULE_ifStatement
 	return p
 }
 
-func InitEmptyPropertyContext(p *PropertyContext) {
+func InitEmptyIfStatementContext(p *IfStatementContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_property
+	p.RuleIndex = tblangParserRULE_ifStatement
 }
 
-func (*PropertyContext) IsPropertyContext() {}
+func (*IfStatementContext) IsIfStatementContext() {}
 
-func NewPropertyContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PropertyContext {
-	var p = new(PropertyContext)
+func NewIfStatementContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *IfStatementContext {
+	var p = new(IfStatementContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = tblangParserRULE_property
+	p.RuleIndex = tblangParserRULE_ifStatement
 
 	return p
 }
 
-func (s *PropertyContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *PropertyContext) IDENTIFIER() antlr.TerminalNode {
-	return s.GetToken(tblangParserIDENTIFIER, 0)
-}
+func (s *IfStatementContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *PropertyContext) ASSIGN() antlr.TerminalNode {
-	return s.GetToken(tblangParserASSIGN, 0)
+func (s *IfStatementContext) IF() antlr.TerminalNode {
+	return s.GetToken(tblangParserIF, 0)
 }
 
-func (s *PropertyContext) Expression() IExpressionContext {
+func (s *IfStatementContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
@@ -1190,150 +1597,59 @@ func (s *PropertyContext) Expression() IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-func (s *PropertyContext) SEMICOLON() antlr.TerminalNode {
-	return s.GetToken(tblangParserSEMICOLON, 0)
-}
-
-func (s *PropertyContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *PropertyContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
-	return antlr.TreesStringTree(s, ruleNames, recog)
-}
-
-func (s *PropertyContext) EnterRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.EnterProperty(s)
-	}
+func (s *IfStatementContext) LBRACE() antlr.TerminalNode {
+	return s.GetToken(tblangParserLBRACE, 0)
 }
 
-func (s *PropertyContext) ExitRule(listener antlr.ParseTreeListener) {
-	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.ExitProperty(s)
-	}
+func (s *IfStatementContext) RBRACE() antlr.TerminalNode {
+	return s.GetToken(tblangParserRBRACE, 0)
 }
 
-func (p *tblangParser) Property() (localctx IPropertyContext) {
-	localctx = NewPropertyContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, tblangParserRULE_property)
-	var _la int
-
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(70)
-		p.Match(tblangParserIDENTIFIER)
-		if p.HasError() {
-
-			goto errorExit
+func (s *IfStatementContext) AllStatement() []IStatementContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IStatementContext); ok {
+			len++
 		}
 	}
-	{
-		p.SetState(71)
-		p.Match(tblangParserASSIGN)
-		if p.HasError() {
 
-			goto errorExit
+	tst := make([]IStatementContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IStatementContext); ok {
+			tst[i] = t.(IStatementContext)
+			i++
 		}
 	}
-	{
-		p.SetState(72)
-		p.expression(0)
-	}
-	p.SetState(74)
-	p.GetErrorHandler().Sync(p)
-	if p.HasError() {
-		goto errorExit
-	}
-	_la = p.GetTokenStream().LA(1)
 
-	if _la == tblangParserSEMICOLON {
-		{
-			p.SetState(73)
-			p.Match(tblangParserSEMICOLON)
-			if p.HasError() {
+	return tst
+}
 
-				goto errorExit
+func (s *IfStatementContext) Statement(i int) IStatementContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IStatementContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
 			}
+			j++
 		}
-
 	}
 
-errorExit:
-	if p.HasError() {
-		v := p.GetError()
-		localctx.SetException(v)
-		p.GetErrorHandler().ReportError(p, v)
-		p.GetErrorHandler().Recover(p, v)
-		p.SetError(nil)
+	if t == nil {
+		return nil
 	}
-	p.ExitRule()
-	return localctx
-	goto errorExit
-}
-
-type IFunctionCallContext interface {
-	antlr.ParserRuleContext
-
-	GetParser() antlr.Parser
-
-	IDENTIFIER() antlr.TerminalNode
-	LPAREN() antlr.TerminalNode
-	RPAREN() antlr.TerminalNode
-	ArgumentList() IArgumentListContext
-	SEMICOLON() antlr.TerminalNode
-
-	IsFunctionCallContext()
-}
-
-type FunctionCallContext struct {
-	antlr.BaseParserRuleContext
-	parser antlr.Parser
-}
-
-func NewEmptyFunctionCallContext() *FunctionCallContext {
-	var p = new(FunctionCallContext)
-	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_functionCall
-	return p
-}
-
-func InitEmptyFunctionCallContext(p *FunctionCallContext) {
-	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_functionCall
-}
-
-func (*FunctionCallContext) IsFunctionCallContext() {}
-
-func NewFunctionCallContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *FunctionCallContext {
-	var p = new(FunctionCallContext)
-
-	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
-
-	p.parser = parser
-	p.RuleIndex = tblangParserRULE_functionCall
-
-	return p
-}
-
-func (s *FunctionCallContext) GetParser() antlr.Parser { return s.parser }
-
-func (s *FunctionCallContext) IDENTIFIER() antlr.TerminalNode {
-	return s.GetToken(tblangParserIDENTIFIER, 0)
-}
-
-func (s *FunctionCallContext) LPAREN() antlr.TerminalNode {
-	return s.GetToken(tblangParserLPAREN, 0)
-}
 
-func (s *FunctionCallContext) RPAREN() antlr.TerminalNode {
-	return s.GetToken(tblangParserRPAREN, 0)
+	return t.(IStatementContext)
 }
 
-func (s *FunctionCallContext) ArgumentList() IArgumentListContext {
+func (s *IfStatementContext) ElseClause() IElseClauseContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IArgumentListContext); ok {
+		if _, ok := ctx.(IElseClauseContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -1343,92 +1659,96 @@ func (s *FunctionCallContext) ArgumentList() IArgumentListContext {
 		return nil
 	}
 
-	return t.(IArgumentListContext)
-}
-
-func (s *FunctionCallContext) SEMICOLON() antlr.TerminalNode {
-	return s.GetToken(tblangParserSEMICOLON, 0)
+	return t.(IElseClauseContext)
 }
 
-func (s *FunctionCallContext) GetRuleContext() antlr.RuleContext {
+func (s *IfStatementContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *FunctionCallContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *IfStatementContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *FunctionCallContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *IfStatementContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.EnterFunctionCall(s)
+		listenerT.EnterIfStatement(s)
 	}
 }
 
-func (s *FunctionCallContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *IfStatementContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.ExitFunctionCall(s)
+		listenerT.ExitIfStatement(s)
 	}
 }
 
-func (p *tblangParser) FunctionCall() (localctx IFunctionCallContext) {
-	localctx = NewFunctionCallContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, tblangParserRULE_functionCall)
+func (p *tblangParser) IfStatement() (localctx IIfStatementContext) {
+	localctx = NewIfStatementContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 12, tblangParserRULE_ifStatement)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(76)
-		p.Match(tblangParserIDENTIFIER)
+		p.SetState(104)
+		p.Match(tblangParserIF)
 		if p.HasError() {
 
 			goto errorExit
 		}
 	}
 	{
-		p.SetState(77)
-		p.Match(tblangParserLPAREN)
+		p.SetState(105)
+		p.expression(0)
+	}
+	{
+		p.SetState(106)
+		p.Match(tblangParserLBRACE)
 		if p.HasError() {
 
 			goto errorExit
 		}
 	}
-	p.SetState(79)
+	p.SetState(110)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 	_la = p.GetTokenStream().LA(1)
 
-	if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&172272) != 0 {
+	for (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&74198) != 0 {
 		{
-			p.SetState(78)
-			p.ArgumentList()
+			p.SetState(107)
+			p.Statement()
 		}
 
+		p.SetState(112)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(81)
-		p.Match(tblangParserRPAREN)
+		p.SetState(113)
+		p.Match(tblangParserRBRACE)
 		if p.HasError() {
 
 			goto errorExit
 		}
 	}
-	p.SetState(83)
+	p.SetState(115)
 	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
 
-	if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 7, p.GetParserRuleContext()) == 1 {
+	if _la == tblangParserELSE {
 		{
-			p.SetState(82)
-			p.Match(tblangParserSEMICOLON)
-			if p.HasError() {
-
-				goto errorExit
-			}
+			p.SetState(114)
+			p.ElseClause()
 		}
 
-	} else if p.HasError() {
-		goto errorExit
 	}
 
 errorExit:
@@ -1444,65 +1764,95 @@ errorExit:
 	goto errorExit
 }
 
-type IArgumentListContext interface {
+type IElseClauseContext interface {
 	antlr.ParserRuleContext
 
 	GetParser() antlr.Parser
 
-	AllExpression() []IExpressionContext
-	Expression(i int) IExpressionContext
-	AllCOMMA() []antlr.TerminalNode
-	COMMA(i int) antlr.TerminalNode
+	ELSE() antlr.TerminalNode
+	IfStatement() IIfStatementContext
+	LBRACE() antlr.TerminalNode
+	RBRACE() antlr.TerminalNode
+	AllStatement() []IStatementContext
+	Statement(i int) IStatementContext
 
-	IsArgumentListContext()
+	IsElseClauseContext()
 }
 
-type ArgumentListContext struct {
+type ElseClauseContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyArgumentListContext() *ArgumentListContext {
-	var p = new(ArgumentListContext)
+func NewEmptyElseClauseContext() *ElseClauseContext {
+	var p = new(ElseClauseContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_argumentList
+	p.RuleIndex = tblangParserRULE_elseClause
 	return p
 }
 
-func InitEmptyArgumentListContext(p *ArgumentListContext) {
+func InitEmptyElseClauseContext(p *ElseClauseContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_argumentList
+	p.RuleIndex = tblangParserRULE_elseClause
 }
 
-func (*ArgumentListContext) IsArgumentListContext() {}
+func (*ElseClauseContext) IsElseClauseContext() {}
 
-func NewArgumentListContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ArgumentListContext {
-	var p = new(ArgumentListContext)
+func NewElseClauseContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ElseClauseContext {
+	var p = new(ElseClauseContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = tblangParserRULE_argumentList
+	p.RuleIndex = tblangParserRULE_elseClause
 
 	return p
 }
 
-func (s *ArgumentListContext) GetParser() antlr.Parser { return s.parser }
+func (s *ElseClauseContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ArgumentListContext) AllExpression() []IExpressionContext {
+func (s *ElseClauseContext) ELSE() antlr.TerminalNode {
+	return s.GetToken(tblangParserELSE, 0)
+}
+
+func (s *ElseClauseContext) IfStatement() IIfStatementContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IIfStatementContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIfStatementContext)
+}
+
+func (s *ElseClauseContext) LBRACE() antlr.TerminalNode {
+	return s.GetToken(tblangParserLBRACE, 0)
+}
+
+func (s *ElseClauseContext) RBRACE() antlr.TerminalNode {
+	return s.GetToken(tblangParserRBRACE, 0)
+}
+
+func (s *ElseClauseContext) AllStatement() []IStatementContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
-		if _, ok := ctx.(IExpressionContext); ok {
+		if _, ok := ctx.(IStatementContext); ok {
 			len++
 		}
 	}
 
-	tst := make([]IExpressionContext, len)
+	tst := make([]IStatementContext, len)
 	i := 0
 	for _, ctx := range children {
-		if t, ok := ctx.(IExpressionContext); ok {
-			tst[i] = t.(IExpressionContext)
+		if t, ok := ctx.(IStatementContext); ok {
+			tst[i] = t.(IStatementContext)
 			i++
 		}
 	}
@@ -1510,11 +1860,11 @@ func (s *ArgumentListContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *ArgumentListContext) Expression(i int) IExpressionContext {
+func (s *ElseClauseContext) Statement(i int) IStatementContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
+		if _, ok := ctx.(IStatementContext); ok {
 			if j == i {
 				t = ctx.(antlr.RuleContext)
 				break
@@ -1527,74 +1877,105 @@ func (s *ArgumentListContext) Expression(i int) IExpressionContext {
 		return nil
 	}
 
-	return t.(IExpressionContext)
+	return t.(IStatementContext)
 }
 
-func (s *ArgumentListContext) AllCOMMA() []antlr.TerminalNode {
-	return s.GetTokens(tblangParserCOMMA)
-}
-
-func (s *ArgumentListContext) COMMA(i int) antlr.TerminalNode {
-	return s.GetToken(tblangParserCOMMA, i)
-}
-
-func (s *ArgumentListContext) GetRuleContext() antlr.RuleContext {
+func (s *ElseClauseContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ArgumentListContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ElseClauseContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *ArgumentListContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ElseClauseContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.EnterArgumentList(s)
+		listenerT.EnterElseClause(s)
 	}
 }
 
-func (s *ArgumentListContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ElseClauseContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.ExitArgumentList(s)
+		listenerT.ExitElseClause(s)
 	}
 }
 
-func (p *tblangParser) ArgumentList() (localctx IArgumentListContext) {
-	localctx = NewArgumentListContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, tblangParserRULE_argumentList)
+func (p *tblangParser) ElseClause() (localctx IElseClauseContext) {
+	localctx = NewElseClauseContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 14, tblangParserRULE_elseClause)
 	var _la int
 
-	p.EnterOuterAlt(localctx, 1)
-	{
-		p.SetState(85)
-		p.expression(0)
-	}
-	p.SetState(90)
+	p.SetState(128)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
-	_la = p.GetTokenStream().LA(1)
 
-	for _la == tblangParserCOMMA {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 10, p.GetParserRuleContext()) {
+	case 1:
+		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(86)
-			p.Match(tblangParserCOMMA)
+			p.SetState(117)
+			p.Match(tblangParserELSE)
 			if p.HasError() {
 
 				goto errorExit
 			}
 		}
 		{
-			p.SetState(87)
-			p.expression(0)
+			p.SetState(118)
+			p.IfStatement()
+		}
+
+	case 2:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(119)
+			p.Match(tblangParserELSE)
+			if p.HasError() {
+
+				goto errorExit
+			}
 		}
+		{
+			p.SetState(120)
+			p.Match(tblangParserLBRACE)
+			if p.HasError() {
 
-		p.SetState(92)
+				goto errorExit
+			}
+		}
+		p.SetState(124)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
+
+		for (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&74198) != 0 {
+			{
+				p.SetState(121)
+				p.Statement()
+			}
+
+			p.SetState(126)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
+				goto errorExit
+			}
+			_la = p.GetTokenStream().LA(1)
+		}
+		{
+			p.SetState(127)
+			p.Match(tblangParserRBRACE)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+
+	case antlr.ATNInvalidAltNumber:
+		goto errorExit
 	}
 
 errorExit:
@@ -1610,94 +1991,84 @@ errorExit:
 	goto errorExit
 }
 
-type IExpressionContext interface {
+type IFunctionDeclarationContext interface {
 	antlr.ParserRuleContext
 
 	GetParser() antlr.Parser
 
-	STRING_LITERAL() antlr.TerminalNode
-	NUMBER() antlr.TerminalNode
-	BOOLEAN() antlr.TerminalNode
+	FUNC() antlr.TerminalNode
 	IDENTIFIER() antlr.TerminalNode
-	ObjectLiteral() IObjectLiteralContext
-	ArrayLiteral() IArrayLiteralContext
-	FunctionCall() IFunctionCallContext
 	LPAREN() antlr.TerminalNode
-	Expression() IExpressionContext
 	RPAREN() antlr.TerminalNode
-	DOT() antlr.TerminalNode
+	LBRACE() antlr.TerminalNode
+	RBRACE() antlr.TerminalNode
+	ParameterList() IParameterListContext
+	AllStatement() []IStatementContext
+	Statement(i int) IStatementContext
 
-	IsExpressionContext()
+	IsFunctionDeclarationContext()
 }
 
-type ExpressionContext struct {
+type FunctionDeclarationContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyExpressionContext() *ExpressionContext {
-	var p = new(ExpressionContext)
+func NewEmptyFunctionDeclarationContext() *FunctionDeclarationContext {
+	var p = new(FunctionDeclarationContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_expression
+	p.RuleIndex = tblangParserRULE_functionDeclaration
 	return p
 }
 
-func InitEmptyExpressionContext(p *ExpressionContext) {
+func InitEmptyFunctionDeclarationContext(p *FunctionDeclarationContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_expression
+	p.RuleIndex = tblangParserRULE_functionDeclaration
 }
 
-func (*ExpressionContext) IsExpressionContext() {}
+func (*FunctionDeclarationContext) IsFunctionDeclarationContext() {}
 
-func NewExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ExpressionContext {
-	var p = new(ExpressionContext)
+func NewFunctionDeclarationContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *FunctionDeclarationContext {
+	var p = new(FunctionDeclarationContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = tblangParserRULE_expression
+	p.RuleIndex = tblangParserRULE_functionDeclaration
 
 	return p
 }
 
-func (s *ExpressionContext) GetParser() antlr.Parser { return s.parser }
+func (s *FunctionDeclarationContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ExpressionContext) STRING_LITERAL() antlr.TerminalNode {
-	return s.GetToken(tblangParserSTRING_LITERAL, 0)
+func (s *FunctionDeclarationContext) FUNC() antlr.TerminalNode {
+	return s.GetToken(tblangParserFUNC, 0)
 }
 
-func (s *ExpressionContext) NUMBER() antlr.TerminalNode {
-	return s.GetToken(tblangParserNUMBER, 0)
+func (s *FunctionDeclarationContext) IDENTIFIER() antlr.TerminalNode {
+	return s.GetToken(tblangParserIDENTIFIER, 0)
 }
 
-func (s *ExpressionContext) BOOLEAN() antlr.TerminalNode {
-	return s.GetToken(tblangParserBOOLEAN, 0)
+func (s *FunctionDeclarationContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(tblangParserLPAREN, 0)
 }
 
-func (s *ExpressionContext) IDENTIFIER() antlr.TerminalNode {
-	return s.GetToken(tblangParserIDENTIFIER, 0)
+func (s *FunctionDeclarationContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(tblangParserRPAREN, 0)
 }
 
-func (s *ExpressionContext) ObjectLiteral() IObjectLiteralContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IObjectLiteralContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
+func (s *FunctionDeclarationContext) LBRACE() antlr.TerminalNode {
+	return s.GetToken(tblangParserLBRACE, 0)
+}
 
-	return t.(IObjectLiteralContext)
+func (s *FunctionDeclarationContext) RBRACE() antlr.TerminalNode {
+	return s.GetToken(tblangParserRBRACE, 0)
 }
 
-func (s *ExpressionContext) ArrayLiteral() IArrayLiteralContext {
+func (s *FunctionDeclarationContext) ParameterList() IParameterListContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IArrayLiteralContext); ok {
+		if _, ok := ctx.(IParameterListContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -1707,35 +2078,40 @@ func (s *ExpressionContext) ArrayLiteral() IArrayLiteralContext {
 		return nil
 	}
 
-	return t.(IArrayLiteralContext)
+	return t.(IParameterListContext)
 }
 
-func (s *ExpressionContext) FunctionCall() IFunctionCallContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IFunctionCallContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+func (s *FunctionDeclarationContext) AllStatement() []IStatementContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IStatementContext); ok {
+			len++
 		}
 	}
 
-	if t == nil {
-		return nil
+	tst := make([]IStatementContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IStatementContext); ok {
+			tst[i] = t.(IStatementContext)
+			i++
+		}
 	}
 
-	return t.(IFunctionCallContext)
-}
-
-func (s *ExpressionContext) LPAREN() antlr.TerminalNode {
-	return s.GetToken(tblangParserLPAREN, 0)
+	return tst
 }
 
-func (s *ExpressionContext) Expression() IExpressionContext {
+func (s *FunctionDeclarationContext) Statement(i int) IStatementContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+		if _, ok := ctx.(IStatementContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -1743,194 +2119,2138 @@ func (s *ExpressionContext) Expression() IExpressionContext {
 		return nil
 	}
 
-	return t.(IExpressionContext)
-}
-
-func (s *ExpressionContext) RPAREN() antlr.TerminalNode {
-	return s.GetToken(tblangParserRPAREN, 0)
-}
-
-func (s *ExpressionContext) DOT() antlr.TerminalNode {
-	return s.GetToken(tblangParserDOT, 0)
+	return t.(IStatementContext)
 }
 
-func (s *ExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *FunctionDeclarationContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *FunctionDeclarationContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *ExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *FunctionDeclarationContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.EnterExpression(s)
+		listenerT.EnterFunctionDeclaration(s)
 	}
 }
 
-func (s *ExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *FunctionDeclarationContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.ExitExpression(s)
+		listenerT.ExitFunctionDeclaration(s)
 	}
 }
 
-func (p *tblangParser) Expression() (localctx IExpressionContext) {
-	return p.expression(0)
-}
+func (p *tblangParser) FunctionDeclaration() (localctx IFunctionDeclarationContext) {
+	localctx = NewFunctionDeclarationContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 16, tblangParserRULE_functionDeclaration)
+	var _la int
 
-func (p *tblangParser) expression(_p int) (localctx IExpressionContext) {
-	var _parentctx antlr.ParserRuleContext = p.GetParserRuleContext()
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(130)
+		p.Match(tblangParserFUNC)
+		if p.HasError() {
 
-	_parentState := p.GetState()
-	localctx = NewExpressionContext(p, p.GetParserRuleContext(), _parentState)
-	var _prevctx IExpressionContext = localctx
-	var _ antlr.ParserRuleContext = _prevctx
-	_startState := 16
-	p.EnterRecursionRule(localctx, 16, tblangParserRULE_expression, _p)
-	var _alt int
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(131)
+		p.Match(tblangParserIDENTIFIER)
+		if p.HasError() {
 
-	p.EnterOuterAlt(localctx, 1)
-	p.SetState(105)
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(132)
+		p.Match(tblangParserLPAREN)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	p.SetState(134)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
+	_la = p.GetTokenStream().LA(1)
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 9, p.GetParserRuleContext()) {
-	case 1:
+	if _la == tblangParserIDENTIFIER {
 		{
-			p.SetState(94)
-			p.Match(tblangParserSTRING_LITERAL)
-			if p.HasError() {
-
-				goto errorExit
-			}
+			p.SetState(133)
+			p.ParameterList()
 		}
 
-	case 2:
-		{
-			p.SetState(95)
-			p.Match(tblangParserNUMBER)
-			if p.HasError() {
+	}
+	{
+		p.SetState(136)
+		p.Match(tblangParserRPAREN)
+		if p.HasError() {
 
-				goto errorExit
-			}
+			goto errorExit
 		}
+	}
+	{
+		p.SetState(137)
+		p.Match(tblangParserLBRACE)
+		if p.HasError() {
 
-	case 3:
-		{
-			p.SetState(96)
-			p.Match(tblangParserBOOLEAN)
-			if p.HasError() {
-
-				goto errorExit
-			}
+			goto errorExit
 		}
+	}
+	p.SetState(141)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
 
-	case 4:
+	for (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&74198) != 0 {
 		{
-			p.SetState(97)
-			p.Match(tblangParserIDENTIFIER)
-			if p.HasError() {
-
-				goto errorExit
-			}
+			p.SetState(138)
+			p.Statement()
 		}
 
-	case 5:
-		{
-			p.SetState(98)
-			p.ObjectLiteral()
+		p.SetState(143)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
 		}
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(144)
+		p.Match(tblangParserRBRACE)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit
+}
+
+type IParameterListContext interface {
+	antlr.ParserRuleContext
+
+	GetParser() antlr.Parser
+
+	AllIDENTIFIER() []antlr.TerminalNode
+	IDENTIFIER(i int) antlr.TerminalNode
+	AllCOMMA() []antlr.TerminalNode
+	COMMA(i int) antlr.TerminalNode
+
+	IsParameterListContext()
+}
+
+type ParameterListContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyParameterListContext() *ParameterListContext {
+	var p = new(ParameterListContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_parameterList
+	return p
+}
+
+func InitEmptyParameterListContext(p *ParameterListContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_parameterList
+}
+
+func (*ParameterListContext) IsParameterListContext() {}
+
+func NewParameterListContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ParameterListContext {
+	var p = new(ParameterListContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = tblangParserRULE_parameterList
+
+	return p
+}
+
+func (s *ParameterListContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ParameterListContext) AllIDENTIFIER() []antlr.TerminalNode {
+	return s.GetTokens(tblangParserIDENTIFIER)
+}
+
+func (s *ParameterListContext) IDENTIFIER(i int) antlr.TerminalNode {
+	return s.GetToken(tblangParserIDENTIFIER, i)
+}
+
+func (s *ParameterListContext) AllCOMMA() []antlr.TerminalNode {
+	return s.GetTokens(tblangParserCOMMA)
+}
+
+func (s *ParameterListContext) COMMA(i int) antlr.TerminalNode {
+	return s.GetToken(tblangParserCOMMA, i)
+}
+
+func (s *ParameterListContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ParameterListContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ParameterListContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.EnterParameterList(s)
+	}
+}
+
+func (s *ParameterListContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.ExitParameterList(s)
+	}
+}
+
+func (p *tblangParser) ParameterList() (localctx IParameterListContext) {
+	localctx = NewParameterListContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 18, tblangParserRULE_parameterList)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(146)
+		p.Match(tblangParserIDENTIFIER)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	p.SetState(151)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == tblangParserCOMMA {
+		{
+			p.SetState(147)
+			p.Match(tblangParserCOMMA)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(148)
+			p.Match(tblangParserIDENTIFIER)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+
+		p.SetState(153)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit
+}
+
+type IReturnStatementContext interface {
+	antlr.ParserRuleContext
+
+	GetParser() antlr.Parser
+
+	RETURN() antlr.TerminalNode
+	Expression() IExpressionContext
+	SEMICOLON() antlr.TerminalNode
+
+	IsReturnStatementContext()
+}
+
+type ReturnStatementContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyReturnStatementContext() *ReturnStatementContext {
+	var p = new(ReturnStatementContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_returnStatement
+	return p
+}
+
+func InitEmptyReturnStatementContext(p *ReturnStatementContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_returnStatement
+}
+
+func (*ReturnStatementContext) IsReturnStatementContext() {}
+
+func NewReturnStatementContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ReturnStatementContext {
+	var p = new(ReturnStatementContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = tblangParserRULE_returnStatement
+
+	return p
+}
+
+func (s *ReturnStatementContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ReturnStatementContext) RETURN() antlr.TerminalNode {
+	return s.GetToken(tblangParserRETURN, 0)
+}
+
+func (s *ReturnStatementContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *ReturnStatementContext) SEMICOLON() antlr.TerminalNode {
+	return s.GetToken(tblangParserSEMICOLON, 0)
+}
+
+func (s *ReturnStatementContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ReturnStatementContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ReturnStatementContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.EnterReturnStatement(s)
+	}
+}
+
+func (s *ReturnStatementContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.ExitReturnStatement(s)
+	}
+}
+
+func (p *tblangParser) ReturnStatement() (localctx IReturnStatementContext) {
+	localctx = NewReturnStatementContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 20, tblangParserRULE_returnStatement)
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(154)
+		p.Match(tblangParserRETURN)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(155)
+		p.expression(0)
+	}
+	p.SetState(157)
+	p.GetErrorHandler().Sync(p)
+
+	if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 14, p.GetParserRuleContext()) == 1 {
+		{
+			p.SetState(156)
+			p.Match(tblangParserSEMICOLON)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+
+	} else if p.HasError() {
+		goto errorExit
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit
+}
+
+type IImportStatementContext interface {
+	antlr.ParserRuleContext
+
+	GetParser() antlr.Parser
+
+	IMPORT() antlr.TerminalNode
+	STRING_LITERAL() antlr.TerminalNode
+	AS() antlr.TerminalNode
+	IDENTIFIER() antlr.TerminalNode
+	ObjectLiteral() IObjectLiteralContext
+	SEMICOLON() antlr.TerminalNode
+
+	IsImportStatementContext()
+}
+
+type ImportStatementContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyImportStatementContext() *ImportStatementContext {
+	var p = new(ImportStatementContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_importStatement
+	return p
+}
+
+func InitEmptyImportStatementContext(p *ImportStatementContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_importStatement
+}
+
+func (*ImportStatementContext) IsImportStatementContext() {}
+
+func NewImportStatementContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ImportStatementContext {
+	var p = new(ImportStatementContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = tblangParserRULE_importStatement
+
+	return p
+}
+
+func (s *ImportStatementContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ImportStatementContext) IMPORT() antlr.TerminalNode {
+	return s.GetToken(tblangParserIMPORT, 0)
+}
+
+func (s *ImportStatementContext) STRING_LITERAL() antlr.TerminalNode {
+	return s.GetToken(tblangParserSTRING_LITERAL, 0)
+}
+
+func (s *ImportStatementContext) AS() antlr.TerminalNode {
+	return s.GetToken(tblangParserAS, 0)
+}
+
+func (s *ImportStatementContext) IDENTIFIER() antlr.TerminalNode {
+	return s.GetToken(tblangParserIDENTIFIER, 0)
+}
+
+func (s *ImportStatementContext) ObjectLiteral() IObjectLiteralContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IObjectLiteralContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IObjectLiteralContext)
+}
+
+func (s *ImportStatementContext) SEMICOLON() antlr.TerminalNode {
+	return s.GetToken(tblangParserSEMICOLON, 0)
+}
+
+func (s *ImportStatementContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ImportStatementContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ImportStatementContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.EnterImportStatement(s)
+	}
+}
+
+func (s *ImportStatementContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.ExitImportStatement(s)
+	}
+}
+
+func (p *tblangParser) ImportStatement() (localctx IImportStatementContext) {
+	localctx = NewImportStatementContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 22, tblangParserRULE_importStatement)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(159)
+		p.Match(tblangParserIMPORT)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(160)
+		p.Match(tblangParserSTRING_LITERAL)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(161)
+		p.Match(tblangParserAS)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(162)
+		p.Match(tblangParserIDENTIFIER)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	p.SetState(164)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == tblangParserLBRACE {
+		{
+			p.SetState(163)
+			p.ObjectLiteral()
+		}
+
+	}
+	p.SetState(167)
+	p.GetErrorHandler().Sync(p)
+
+	if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 16, p.GetParserRuleContext()) == 1 {
+		{
+			p.SetState(166)
+			p.Match(tblangParserSEMICOLON)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+
+	} else if p.HasError() {
+		goto errorExit
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit
+}
+
+type IPropertyContext interface {
+	antlr.ParserRuleContext
+
+	GetParser() antlr.Parser
+
+	IDENTIFIER() antlr.TerminalNode
+	ASSIGN() antlr.TerminalNode
+	Expression() IExpressionContext
+	SEMICOLON() antlr.TerminalNode
+	COMMA() antlr.TerminalNode
+
+	IsPropertyContext()
+}
+
+type PropertyContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyPropertyContext() *PropertyContext {
+	var p = new(PropertyContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_property
+	return p
+}
+
+func InitEmptyPropertyContext(p *PropertyContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_property
+}
+
+func (*PropertyContext) IsPropertyContext() {}
+
+func NewPropertyContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PropertyContext {
+	var p = new(PropertyContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = tblangParserRULE_property
+
+	return p
+}
+
+func (s *PropertyContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *PropertyContext) IDENTIFIER() antlr.TerminalNode {
+	return s.GetToken(tblangParserIDENTIFIER, 0)
+}
+
+func (s *PropertyContext) ASSIGN() antlr.TerminalNode {
+	return s.GetToken(tblangParserASSIGN, 0)
+}
+
+func (s *PropertyContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *PropertyContext) SEMICOLON() antlr.TerminalNode {
+	return s.GetToken(tblangParserSEMICOLON, 0)
+}
+
+func (s *PropertyContext) COMMA() antlr.TerminalNode {
+	return s.GetToken(tblangParserCOMMA, 0)
+}
+
+func (s *PropertyContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *PropertyContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *PropertyContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.EnterProperty(s)
+	}
+}
+
+func (s *PropertyContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.ExitProperty(s)
+	}
+}
+
+func (p *tblangParser) Property() (localctx IPropertyContext) {
+	localctx = NewPropertyContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 24, tblangParserRULE_property)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(169)
+		p.Match(tblangParserIDENTIFIER)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(170)
+		p.Match(tblangParserASSIGN)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(171)
+		p.expression(0)
+	}
+	p.SetState(173)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == tblangParserSEMICOLON || _la == tblangParserCOMMA {
+		{
+			p.SetState(172)
+			_la = p.GetTokenStream().LA(1)
+
+			if !(_la == tblangParserSEMICOLON || _la == tblangParserCOMMA) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit
+}
+
+type IFunctionCallContext interface {
+	antlr.ParserRuleContext
+
+	GetParser() antlr.Parser
+
+	IDENTIFIER() antlr.TerminalNode
+	LPAREN() antlr.TerminalNode
+	RPAREN() antlr.TerminalNode
+	ArgumentList() IArgumentListContext
+	SEMICOLON() antlr.TerminalNode
+
+	IsFunctionCallContext()
+}
+
+type FunctionCallContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyFunctionCallContext() *FunctionCallContext {
+	var p = new(FunctionCallContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_functionCall
+	return p
+}
+
+func InitEmptyFunctionCallContext(p *FunctionCallContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_functionCall
+}
+
+func (*FunctionCallContext) IsFunctionCallContext() {}
+
+func NewFunctionCallContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *FunctionCallContext {
+	var p = new(FunctionCallContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = tblangParserRULE_functionCall
+
+	return p
+}
+
+func (s *FunctionCallContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *FunctionCallContext) IDENTIFIER() antlr.TerminalNode {
+	return s.GetToken(tblangParserIDENTIFIER, 0)
+}
+
+func (s *FunctionCallContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(tblangParserLPAREN, 0)
+}
+
+func (s *FunctionCallContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(tblangParserRPAREN, 0)
+}
+
+func (s *FunctionCallContext) ArgumentList() IArgumentListContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IArgumentListContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IArgumentListContext)
+}
+
+func (s *FunctionCallContext) SEMICOLON() antlr.TerminalNode {
+	return s.GetToken(tblangParserSEMICOLON, 0)
+}
+
+func (s *FunctionCallContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *FunctionCallContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *FunctionCallContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.EnterFunctionCall(s)
+	}
+}
+
+func (s *FunctionCallContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.ExitFunctionCall(s)
+	}
+}
+
+func (p *tblangParser) FunctionCall() (localctx IFunctionCallContext) {
+	localctx = NewFunctionCallContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 26, tblangParserRULE_functionCall)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(175)
+		p.Match(tblangParserIDENTIFIER)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(176)
+		p.Match(tblangParserLPAREN)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	p.SetState(178)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&722762480640) != 0 {
+		{
+			p.SetState(177)
+			p.ArgumentList()
+		}
+
+	}
+	{
+		p.SetState(180)
+		p.Match(tblangParserRPAREN)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	p.SetState(182)
+	p.GetErrorHandler().Sync(p)
+
+	if p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 19, p.GetParserRuleContext()) == 1 {
+		{
+			p.SetState(181)
+			p.Match(tblangParserSEMICOLON)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+
+	} else if p.HasError() {
+		goto errorExit
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit
+}
+
+type IArgumentListContext interface {
+	antlr.ParserRuleContext
+
+	GetParser() antlr.Parser
+
+	AllExpression() []IExpressionContext
+	Expression(i int) IExpressionContext
+	AllCOMMA() []antlr.TerminalNode
+	COMMA(i int) antlr.TerminalNode
+
+	IsArgumentListContext()
+}
+
+type ArgumentListContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyArgumentListContext() *ArgumentListContext {
+	var p = new(ArgumentListContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_argumentList
+	return p
+}
+
+func InitEmptyArgumentListContext(p *ArgumentListContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_argumentList
+}
+
+func (*ArgumentListContext) IsArgumentListContext() {}
+
+func NewArgumentListContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ArgumentListContext {
+	var p = new(ArgumentListContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = tblangParserRULE_argumentList
+
+	return p
+}
+
+func (s *ArgumentListContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ArgumentListContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ArgumentListContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *ArgumentListContext) AllCOMMA() []antlr.TerminalNode {
+	return s.GetTokens(tblangParserCOMMA)
+}
+
+func (s *ArgumentListContext) COMMA(i int) antlr.TerminalNode {
+	return s.GetToken(tblangParserCOMMA, i)
+}
+
+func (s *ArgumentListContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ArgumentListContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ArgumentListContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.EnterArgumentList(s)
+	}
+}
+
+func (s *ArgumentListContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.ExitArgumentList(s)
+	}
+}
+
+func (p *tblangParser) ArgumentList() (localctx IArgumentListContext) {
+	localctx = NewArgumentListContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 28, tblangParserRULE_argumentList)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(184)
+		p.expression(0)
+	}
+	p.SetState(189)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == tblangParserCOMMA {
+		{
+			p.SetState(185)
+			p.Match(tblangParserCOMMA)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(186)
+			p.expression(0)
+		}
+
+		p.SetState(191)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit
+}
+
+type IExpressionContext interface {
+	antlr.ParserRuleContext
+
+	GetParser() antlr.Parser
+
+	STRING_LITERAL() antlr.TerminalNode
+	NUMBER() antlr.TerminalNode
+	BOOLEAN() antlr.TerminalNode
+	IDENTIFIER() antlr.TerminalNode
+	ObjectLiteral() IObjectLiteralContext
+	ArrayLiteral() IArrayLiteralContext
+	ListComprehension() IListComprehensionContext
+	MapComprehension() IMapComprehensionContext
+	FunctionCall() IFunctionCallContext
+	LPAREN() antlr.TerminalNode
+	AllExpression() []IExpressionContext
+	Expression(i int) IExpressionContext
+	RPAREN() antlr.TerminalNode
+	MINUS() antlr.TerminalNode
+	NOT() antlr.TerminalNode
+	STAR() antlr.TerminalNode
+	SLASH() antlr.TerminalNode
+	PERCENT() antlr.TerminalNode
+	PLUS() antlr.TerminalNode
+	LT() antlr.TerminalNode
+	LE() antlr.TerminalNode
+	GT() antlr.TerminalNode
+	GE() antlr.TerminalNode
+	EQ() antlr.TerminalNode
+	NEQ() antlr.TerminalNode
+	AND() antlr.TerminalNode
+	OR() antlr.TerminalNode
+	QUESTION() antlr.TerminalNode
+	COLON() antlr.TerminalNode
+	DOT() antlr.TerminalNode
+	LBRACKET() antlr.TerminalNode
+	RBRACKET() antlr.TerminalNode
+
+	IsExpressionContext()
+}
+
+type ExpressionContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyExpressionContext() *ExpressionContext {
+	var p = new(ExpressionContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_expression
+	return p
+}
+
+func InitEmptyExpressionContext(p *ExpressionContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_expression
+}
+
+func (*ExpressionContext) IsExpressionContext() {}
+
+func NewExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ExpressionContext {
+	var p = new(ExpressionContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = tblangParserRULE_expression
+
+	return p
+}
+
+func (s *ExpressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ExpressionContext) STRING_LITERAL() antlr.TerminalNode {
+	return s.GetToken(tblangParserSTRING_LITERAL, 0)
+}
+
+func (s *ExpressionContext) NUMBER() antlr.TerminalNode {
+	return s.GetToken(tblangParserNUMBER, 0)
+}
+
+func (s *ExpressionContext) BOOLEAN() antlr.TerminalNode {
+	return s.GetToken(tblangParserBOOLEAN, 0)
+}
+
+func (s *ExpressionContext) IDENTIFIER() antlr.TerminalNode {
+	return s.GetToken(tblangParserIDENTIFIER, 0)
+}
+
+func (s *ExpressionContext) ObjectLiteral() IObjectLiteralContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IObjectLiteralContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IObjectLiteralContext)
+}
+
+func (s *ExpressionContext) ArrayLiteral() IArrayLiteralContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IArrayLiteralContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IArrayLiteralContext)
+}
+
+func (s *ExpressionContext) ListComprehension() IListComprehensionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IListComprehensionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IListComprehensionContext)
+}
+
+func (s *ExpressionContext) MapComprehension() IMapComprehensionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IMapComprehensionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMapComprehensionContext)
+}
+
+func (s *ExpressionContext) FunctionCall() IFunctionCallContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IFunctionCallContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IFunctionCallContext)
+}
+
+func (s *ExpressionContext) LPAREN() antlr.TerminalNode {
+	return s.GetToken(tblangParserLPAREN, 0)
+}
+
+func (s *ExpressionContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ExpressionContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *ExpressionContext) RPAREN() antlr.TerminalNode {
+	return s.GetToken(tblangParserRPAREN, 0)
+}
+
+func (s *ExpressionContext) MINUS() antlr.TerminalNode {
+	return s.GetToken(tblangParserMINUS, 0)
+}
+
+func (s *ExpressionContext) NOT() antlr.TerminalNode {
+	return s.GetToken(tblangParserNOT, 0)
+}
+
+func (s *ExpressionContext) STAR() antlr.TerminalNode {
+	return s.GetToken(tblangParserSTAR, 0)
+}
+
+func (s *ExpressionContext) SLASH() antlr.TerminalNode {
+	return s.GetToken(tblangParserSLASH, 0)
+}
+
+func (s *ExpressionContext) PERCENT() antlr.TerminalNode {
+	return s.GetToken(tblangParserPERCENT, 0)
+}
+
+func (s *ExpressionContext) PLUS() antlr.TerminalNode {
+	return s.GetToken(tblangParserPLUS, 0)
+}
+
+func (s *ExpressionContext) LT() antlr.TerminalNode {
+	return s.GetToken(tblangParserLT, 0)
+}
+
+func (s *ExpressionContext) LE() antlr.TerminalNode {
+	return s.GetToken(tblangParserLE, 0)
+}
+
+func (s *ExpressionContext) GT() antlr.TerminalNode {
+	return s.GetToken(tblangParserGT, 0)
+}
+
+func (s *ExpressionContext) GE() antlr.TerminalNode {
+	return s.GetToken(tblangParserGE, 0)
+}
+
+func (s *ExpressionContext) EQ() antlr.TerminalNode {
+	return s.GetToken(tblangParserEQ, 0)
+}
+
+func (s *ExpressionContext) NEQ() antlr.TerminalNode {
+	return s.GetToken(tblangParserNEQ, 0)
+}
+
+func (s *ExpressionContext) AND() antlr.TerminalNode {
+	return s.GetToken(tblangParserAND, 0)
+}
+
+func (s *ExpressionContext) OR() antlr.TerminalNode {
+	return s.GetToken(tblangParserOR, 0)
+}
+
+func (s *ExpressionContext) QUESTION() antlr.TerminalNode {
+	return s.GetToken(tblangParserQUESTION, 0)
+}
+
+func (s *ExpressionContext) COLON() antlr.TerminalNode {
+	return s.GetToken(tblangParserCOLON, 0)
+}
+
+func (s *ExpressionContext) DOT() antlr.TerminalNode {
+	return s.GetToken(tblangParserDOT, 0)
+}
+
+func (s *ExpressionContext) LBRACKET() antlr.TerminalNode {
+	return s.GetToken(tblangParserLBRACKET, 0)
+}
+
+func (s *ExpressionContext) RBRACKET() antlr.TerminalNode {
+	return s.GetToken(tblangParserRBRACKET, 0)
+}
+
+func (s *ExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.EnterExpression(s)
+	}
+}
+
+func (s *ExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.ExitExpression(s)
+	}
+}
+
+func (p *tblangParser) Expression() (localctx IExpressionContext) {
+	return p.expression(0)
+}
+
+func (p *tblangParser) expression(_p int) (localctx IExpressionContext) {
+	var _parentctx antlr.ParserRuleContext = p.GetParserRuleContext()
+
+	_parentState := p.GetState()
+	localctx = NewExpressionContext(p, p.GetParserRuleContext(), _parentState)
+	var _prevctx IExpressionContext = localctx
+	var _ antlr.ParserRuleContext = _prevctx
+	_startState := 30
+	p.EnterRecursionRule(localctx, 30, tblangParserRULE_expression, _p)
+	var _la int
+	var _alt int
+
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(208)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 21, p.GetParserRuleContext()) {
+	case 1:
+		{
+			p.SetState(193)
+			p.Match(tblangParserSTRING_LITERAL)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+
+	case 2:
+		{
+			p.SetState(194)
+			p.Match(tblangParserNUMBER)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+
+	case 3:
+		{
+			p.SetState(195)
+			p.Match(tblangParserBOOLEAN)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+
+	case 4:
+		{
+			p.SetState(196)
+			p.Match(tblangParserIDENTIFIER)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+
+	case 5:
+		{
+			p.SetState(197)
+			p.ObjectLiteral()
+		}
+
+	case 6:
+		{
+			p.SetState(198)
+			p.ArrayLiteral()
+		}
+
+	case 7:
+		{
+			p.SetState(199)
+			p.ListComprehension()
+		}
+
+	case 8:
+		{
+			p.SetState(200)
+			p.MapComprehension()
+		}
+
+	case 9:
+		{
+			p.SetState(201)
+			p.FunctionCall()
+		}
+
+	case 10:
+		{
+			p.SetState(202)
+			p.Match(tblangParserLPAREN)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(203)
+			p.expression(0)
+		}
+		{
+			p.SetState(204)
+			p.Match(tblangParserRPAREN)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+
+	case 11:
+		{
+			p.SetState(206)
+			_la = p.GetTokenStream().LA(1)
+
+			if !(_la == tblangParserNOT || _la == tblangParserMINUS) {
+				p.GetErrorHandler().RecoverInline(p)
+			} else {
+				p.GetErrorHandler().ReportMatch(p)
+				p.Consume()
+			}
+		}
+		{
+			p.SetState(207)
+			p.expression(8)
+		}
+
+	case antlr.ATNInvalidAltNumber:
+		goto errorExit
+	}
+	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
+	p.SetState(244)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 23, p.GetParserRuleContext())
+	if p.HasError() {
+		goto errorExit
+	}
+	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
+		if _alt == 1 {
+			if p.GetParseListeners() != nil {
+				p.TriggerExitRuleEvent()
+			}
+			_prevctx = localctx
+			p.SetState(242)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
+				goto errorExit
+			}
+
+			switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 22, p.GetParserRuleContext()) {
+			case 1:
+				localctx = NewExpressionContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, tblangParserRULE_expression)
+				p.SetState(210)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(211)
+					_la = p.GetTokenStream().LA(1)
+
+					if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&15032385536) != 0) {
+						p.GetErrorHandler().RecoverInline(p)
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(212)
+					p.expression(8)
+				}
+
+			case 2:
+				localctx = NewExpressionContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, tblangParserRULE_expression)
+				p.SetState(213)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(214)
+					_la = p.GetTokenStream().LA(1)
+
+					if !(_la == tblangParserPLUS || _la == tblangParserMINUS) {
+						p.GetErrorHandler().RecoverInline(p)
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(215)
+					p.expression(7)
+				}
+
+			case 3:
+				localctx = NewExpressionContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, tblangParserRULE_expression)
+				p.SetState(216)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(217)
+					_la = p.GetTokenStream().LA(1)
+
+					if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&31457280) != 0) {
+						p.GetErrorHandler().RecoverInline(p)
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(218)
+					p.expression(6)
+				}
+
+			case 4:
+				localctx = NewExpressionContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, tblangParserRULE_expression)
+				p.SetState(219)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 4)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 4)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(220)
+					_la = p.GetTokenStream().LA(1)
+
+					if !(_la == tblangParserEQ || _la == tblangParserNEQ) {
+						p.GetErrorHandler().RecoverInline(p)
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(221)
+					p.expression(5)
+				}
+
+			case 5:
+				localctx = NewExpressionContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, tblangParserRULE_expression)
+				p.SetState(222)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(223)
+					p.Match(tblangParserAND)
+					if p.HasError() {
+
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(224)
+					p.expression(4)
+				}
+
+			case 6:
+				localctx = NewExpressionContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, tblangParserRULE_expression)
+				p.SetState(225)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(226)
+					p.Match(tblangParserOR)
+					if p.HasError() {
+
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(227)
+					p.expression(3)
+				}
+
+			case 7:
+				localctx = NewExpressionContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, tblangParserRULE_expression)
+				p.SetState(228)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 1)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 1)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(229)
+					p.Match(tblangParserQUESTION)
+					if p.HasError() {
+
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(230)
+					p.expression(0)
+				}
+				{
+					p.SetState(231)
+					p.Match(tblangParserCOLON)
+					if p.HasError() {
+
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(232)
+					p.expression(1)
+				}
+
+			case 8:
+				localctx = NewExpressionContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, tblangParserRULE_expression)
+				p.SetState(234)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 11)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 11)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(235)
+					p.Match(tblangParserDOT)
+					if p.HasError() {
+
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(236)
+					p.Match(tblangParserIDENTIFIER)
+					if p.HasError() {
+
+						goto errorExit
+					}
+				}
+
+			case 9:
+				localctx = NewExpressionContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, tblangParserRULE_expression)
+				p.SetState(237)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 10)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 10)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(238)
+					p.Match(tblangParserLBRACKET)
+					if p.HasError() {
+
+						goto errorExit
+					}
+				}
+				{
+					p.SetState(239)
+					p.expression(0)
+				}
+				{
+					p.SetState(240)
+					p.Match(tblangParserRBRACKET)
+					if p.HasError() {
+
+						goto errorExit
+					}
+				}
+
+			case antlr.ATNInvalidAltNumber:
+				goto errorExit
+			}
+
+		}
+		p.SetState(246)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 23, p.GetParserRuleContext())
+		if p.HasError() {
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.UnrollRecursionContexts(_parentctx)
+	return localctx
+	goto errorExit
+}
+
+type IObjectLiteralContext interface {
+	antlr.ParserRuleContext
+
+	GetParser() antlr.Parser
+
+	LBRACE() antlr.TerminalNode
+	RBRACE() antlr.TerminalNode
+	AllObjectProperty() []IObjectPropertyContext
+	ObjectProperty(i int) IObjectPropertyContext
+
+	IsObjectLiteralContext()
+}
+
+type ObjectLiteralContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyObjectLiteralContext() *ObjectLiteralContext {
+	var p = new(ObjectLiteralContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_objectLiteral
+	return p
+}
+
+func InitEmptyObjectLiteralContext(p *ObjectLiteralContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_objectLiteral
+}
+
+func (*ObjectLiteralContext) IsObjectLiteralContext() {}
+
+func NewObjectLiteralContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ObjectLiteralContext {
+	var p = new(ObjectLiteralContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = tblangParserRULE_objectLiteral
+
+	return p
+}
+
+func (s *ObjectLiteralContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ObjectLiteralContext) LBRACE() antlr.TerminalNode {
+	return s.GetToken(tblangParserLBRACE, 0)
+}
+
+func (s *ObjectLiteralContext) RBRACE() antlr.TerminalNode {
+	return s.GetToken(tblangParserRBRACE, 0)
+}
+
+func (s *ObjectLiteralContext) AllObjectProperty() []IObjectPropertyContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IObjectPropertyContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IObjectPropertyContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IObjectPropertyContext); ok {
+			tst[i] = t.(IObjectPropertyContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ObjectLiteralContext) ObjectProperty(i int) IObjectPropertyContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IObjectPropertyContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IObjectPropertyContext)
+}
+
+func (s *ObjectLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ObjectLiteralContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ObjectLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.EnterObjectLiteral(s)
+	}
+}
+
+func (s *ObjectLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.ExitObjectLiteral(s)
+	}
+}
+
+func (p *tblangParser) ObjectLiteral() (localctx IObjectLiteralContext) {
+	localctx = NewObjectLiteralContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 32, tblangParserRULE_objectLiteral)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(247)
+		p.Match(tblangParserLBRACE)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	p.SetState(251)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == tblangParserIDENTIFIER {
+		{
+			p.SetState(248)
+			p.ObjectProperty()
+		}
+
+		p.SetState(253)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(254)
+		p.Match(tblangParserRBRACE)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit
+}
+
+type IObjectPropertyContext interface {
+	antlr.ParserRuleContext
+
+	GetParser() antlr.Parser
+
+	IDENTIFIER() antlr.TerminalNode
+	Expression() IExpressionContext
+	COLON() antlr.TerminalNode
+	ASSIGN() antlr.TerminalNode
+	COMMA() antlr.TerminalNode
+
+	IsObjectPropertyContext()
+}
+
+type ObjectPropertyContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyObjectPropertyContext() *ObjectPropertyContext {
+	var p = new(ObjectPropertyContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_objectProperty
+	return p
+}
+
+func InitEmptyObjectPropertyContext(p *ObjectPropertyContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = tblangParserRULE_objectProperty
+}
+
+func (*ObjectPropertyContext) IsObjectPropertyContext() {}
+
+func NewObjectPropertyContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ObjectPropertyContext {
+	var p = new(ObjectPropertyContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = tblangParserRULE_objectProperty
+
+	return p
+}
+
+func (s *ObjectPropertyContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ObjectPropertyContext) IDENTIFIER() antlr.TerminalNode {
+	return s.GetToken(tblangParserIDENTIFIER, 0)
+}
+
+func (s *ObjectPropertyContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *ObjectPropertyContext) COLON() antlr.TerminalNode {
+	return s.GetToken(tblangParserCOLON, 0)
+}
+
+func (s *ObjectPropertyContext) ASSIGN() antlr.TerminalNode {
+	return s.GetToken(tblangParserASSIGN, 0)
+}
+
+func (s *ObjectPropertyContext) COMMA() antlr.TerminalNode {
+	return s.GetToken(tblangParserCOMMA, 0)
+}
+
+func (s *ObjectPropertyContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ObjectPropertyContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *ObjectPropertyContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.EnterObjectProperty(s)
+	}
+}
 
-	case 6:
-		{
-			p.SetState(99)
-			p.ArrayLiteral()
-		}
+func (s *ObjectPropertyContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(tblangListener); ok {
+		listenerT.ExitObjectProperty(s)
+	}
+}
 
-	case 7:
-		{
-			p.SetState(100)
-			p.FunctionCall()
-		}
+func (p *tblangParser) ObjectProperty() (localctx IObjectPropertyContext) {
+	localctx = NewObjectPropertyContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 34, tblangParserRULE_objectProperty)
+	var _la int
 
-	case 8:
-		{
-			p.SetState(101)
-			p.Match(tblangParserLPAREN)
-			if p.HasError() {
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(256)
+		p.Match(tblangParserIDENTIFIER)
+		if p.HasError() {
 
-				goto errorExit
-			}
-		}
-		{
-			p.SetState(102)
-			p.expression(0)
+			goto errorExit
 		}
-		{
-			p.SetState(103)
-			p.Match(tblangParserRPAREN)
-			if p.HasError() {
+	}
+	{
+		p.SetState(257)
+		_la = p.GetTokenStream().LA(1)
 
-				goto errorExit
-			}
+		if !(_la == tblangParserASSIGN || _la == tblangParserCOLON) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
 		}
-
-	case antlr.ATNInvalidAltNumber:
-		goto errorExit
 	}
-	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(112)
-	p.GetErrorHandler().Sync(p)
-	if p.HasError() {
-		goto errorExit
+	{
+		p.SetState(258)
+		p.expression(0)
 	}
-	_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 10, p.GetParserRuleContext())
+	p.SetState(260)
+	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
-	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
-		if _alt == 1 {
-			if p.GetParseListeners() != nil {
-				p.TriggerExitRuleEvent()
-			}
-			_prevctx = localctx
-			localctx = NewExpressionContext(p, _parentctx, _parentState)
-			p.PushNewRecursionContext(localctx, _startState, tblangParserRULE_expression)
-			p.SetState(107)
-
-			if !(p.Precpred(p.GetParserRuleContext(), 2)) {
-				p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
-				goto errorExit
-			}
-			{
-				p.SetState(108)
-				p.Match(tblangParserDOT)
-				if p.HasError() {
+	_la = p.GetTokenStream().LA(1)
 
-					goto errorExit
-				}
-			}
-			{
-				p.SetState(109)
-				p.Match(tblangParserIDENTIFIER)
-				if p.HasError() {
+	if _la == tblangParserCOMMA {
+		{
+			p.SetState(259)
+			p.Match(tblangParserCOMMA)
+			if p.HasError() {
 
-					goto errorExit
-				}
+				goto errorExit
 			}
-
-		}
-		p.SetState(114)
-		p.GetErrorHandler().Sync(p)
-		if p.HasError() {
-			goto errorExit
-		}
-		_alt = p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 10, p.GetParserRuleContext())
-		if p.HasError() {
-			goto errorExit
 		}
+
 	}
 
 errorExit:
@@ -1941,78 +4261,80 @@ errorExit:
 		p.GetErrorHandler().Recover(p, v)
 		p.SetError(nil)
 	}
-	p.UnrollRecursionContexts(_parentctx)
+	p.ExitRule()
 	return localctx
 	goto errorExit
 }
 
-type IObjectLiteralContext interface {
+type IArrayLiteralContext interface {
 	antlr.ParserRuleContext
 
 	GetParser() antlr.Parser
 
-	LBRACE() antlr.TerminalNode
-	RBRACE() antlr.TerminalNode
-	AllObjectProperty() []IObjectPropertyContext
-	ObjectProperty(i int) IObjectPropertyContext
+	LBRACKET() antlr.TerminalNode
+	RBRACKET() antlr.TerminalNode
+	AllExpression() []IExpressionContext
+	Expression(i int) IExpressionContext
+	AllCOMMA() []antlr.TerminalNode
+	COMMA(i int) antlr.TerminalNode
 
-	IsObjectLiteralContext()
+	IsArrayLiteralContext()
 }
 
-type ObjectLiteralContext struct {
+type ArrayLiteralContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyObjectLiteralContext() *ObjectLiteralContext {
-	var p = new(ObjectLiteralContext)
+func NewEmptyArrayLiteralContext() *ArrayLiteralContext {
+	var p = new(ArrayLiteralContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_objectLiteral
+	p.RuleIndex = tblangParserRULE_arrayLiteral
 	return p
 }
 
-func InitEmptyObjectLiteralContext(p *ObjectLiteralContext) {
+func InitEmptyArrayLiteralContext(p *ArrayLiteralContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_objectLiteral
+	p.RuleIndex = tblangParserRULE_arrayLiteral
 }
 
-func (*ObjectLiteralContext) IsObjectLiteralContext() {}
+func (*ArrayLiteralContext) IsArrayLiteralContext() {}
 
-func NewObjectLiteralContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ObjectLiteralContext {
-	var p = new(ObjectLiteralContext)
+func NewArrayLiteralContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ArrayLiteralContext {
+	var p = new(ArrayLiteralContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = tblangParserRULE_objectLiteral
+	p.RuleIndex = tblangParserRULE_arrayLiteral
 
 	return p
 }
 
-func (s *ObjectLiteralContext) GetParser() antlr.Parser { return s.parser }
+func (s *ArrayLiteralContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ObjectLiteralContext) LBRACE() antlr.TerminalNode {
-	return s.GetToken(tblangParserLBRACE, 0)
+func (s *ArrayLiteralContext) LBRACKET() antlr.TerminalNode {
+	return s.GetToken(tblangParserLBRACKET, 0)
 }
 
-func (s *ObjectLiteralContext) RBRACE() antlr.TerminalNode {
-	return s.GetToken(tblangParserRBRACE, 0)
+func (s *ArrayLiteralContext) RBRACKET() antlr.TerminalNode {
+	return s.GetToken(tblangParserRBRACKET, 0)
 }
 
-func (s *ObjectLiteralContext) AllObjectProperty() []IObjectPropertyContext {
+func (s *ArrayLiteralContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
-		if _, ok := ctx.(IObjectPropertyContext); ok {
+		if _, ok := ctx.(IExpressionContext); ok {
 			len++
 		}
 	}
 
-	tst := make([]IObjectPropertyContext, len)
+	tst := make([]IExpressionContext, len)
 	i := 0
 	for _, ctx := range children {
-		if t, ok := ctx.(IObjectPropertyContext); ok {
-			tst[i] = t.(IObjectPropertyContext)
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
 			i++
 		}
 	}
@@ -2020,11 +4342,11 @@ func (s *ObjectLiteralContext) AllObjectProperty() []IObjectPropertyContext {
 	return tst
 }
 
-func (s *ObjectLiteralContext) ObjectProperty(i int) IObjectPropertyContext {
+func (s *ArrayLiteralContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IObjectPropertyContext); ok {
+		if _, ok := ctx.(IExpressionContext); ok {
 			if j == i {
 				t = ctx.(antlr.RuleContext)
 				break
@@ -2037,66 +4359,96 @@ func (s *ObjectLiteralContext) ObjectProperty(i int) IObjectPropertyContext {
 		return nil
 	}
 
-	return t.(IObjectPropertyContext)
+	return t.(IExpressionContext)
 }
 
-func (s *ObjectLiteralContext) GetRuleContext() antlr.RuleContext {
+func (s *ArrayLiteralContext) AllCOMMA() []antlr.TerminalNode {
+	return s.GetTokens(tblangParserCOMMA)
+}
+
+func (s *ArrayLiteralContext) COMMA(i int) antlr.TerminalNode {
+	return s.GetToken(tblangParserCOMMA, i)
+}
+
+func (s *ArrayLiteralContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ObjectLiteralContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ArrayLiteralContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *ObjectLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ArrayLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.EnterObjectLiteral(s)
+		listenerT.EnterArrayLiteral(s)
 	}
 }
 
-func (s *ObjectLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ArrayLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.ExitObjectLiteral(s)
+		listenerT.ExitArrayLiteral(s)
 	}
 }
 
-func (p *tblangParser) ObjectLiteral() (localctx IObjectLiteralContext) {
-	localctx = NewObjectLiteralContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, tblangParserRULE_objectLiteral)
+func (p *tblangParser) ArrayLiteral() (localctx IArrayLiteralContext) {
+	localctx = NewArrayLiteralContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 36, tblangParserRULE_arrayLiteral)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(115)
-		p.Match(tblangParserLBRACE)
+		p.SetState(262)
+		p.Match(tblangParserLBRACKET)
 		if p.HasError() {
 
 			goto errorExit
 		}
 	}
-	p.SetState(119)
+	p.SetState(271)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 	_la = p.GetTokenStream().LA(1)
 
-	for _la == tblangParserIDENTIFIER {
+	if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&722762480640) != 0 {
 		{
-			p.SetState(116)
-			p.ObjectProperty()
+			p.SetState(263)
+			p.expression(0)
 		}
-
-		p.SetState(121)
+		p.SetState(268)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
+
+		for _la == tblangParserCOMMA {
+			{
+				p.SetState(264)
+				p.Match(tblangParserCOMMA)
+				if p.HasError() {
+
+					goto errorExit
+				}
+			}
+			{
+				p.SetState(265)
+				p.expression(0)
+			}
+
+			p.SetState(270)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
+				goto errorExit
+			}
+			_la = p.GetTokenStream().LA(1)
+		}
+
 	}
 	{
-		p.SetState(122)
-		p.Match(tblangParserRBRACE)
+		p.SetState(273)
+		p.Match(tblangParserRBRACKET)
 		if p.HasError() {
 
 			goto errorExit
@@ -2116,62 +4468,109 @@ errorExit:
 	goto errorExit
 }
 
-type IObjectPropertyContext interface {
+type IListComprehensionContext interface {
 	antlr.ParserRuleContext
 
 	GetParser() antlr.Parser
 
-	IDENTIFIER() antlr.TerminalNode
-	Expression() IExpressionContext
+	LBRACKET() antlr.TerminalNode
+	FOR() antlr.TerminalNode
+	AllIDENTIFIER() []antlr.TerminalNode
+	IDENTIFIER(i int) antlr.TerminalNode
+	IN() antlr.TerminalNode
+	AllExpression() []IExpressionContext
+	Expression(i int) IExpressionContext
 	COLON() antlr.TerminalNode
-	ASSIGN() antlr.TerminalNode
+	RBRACKET() antlr.TerminalNode
 	COMMA() antlr.TerminalNode
+	IF() antlr.TerminalNode
 
-	IsObjectPropertyContext()
+	IsListComprehensionContext()
 }
 
-type ObjectPropertyContext struct {
+type ListComprehensionContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyObjectPropertyContext() *ObjectPropertyContext {
-	var p = new(ObjectPropertyContext)
+func NewEmptyListComprehensionContext() *ListComprehensionContext {
+	var p = new(ListComprehensionContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_objectProperty
+	p.RuleIndex = tblangParserRULE_listComprehension
 	return p
 }
 
-func InitEmptyObjectPropertyContext(p *ObjectPropertyContext) {
+func InitEmptyListComprehensionContext(p *ListComprehensionContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_objectProperty
+	p.RuleIndex = tblangParserRULE_listComprehension
 }
 
-func (*ObjectPropertyContext) IsObjectPropertyContext() {}
+func (*ListComprehensionContext) IsListComprehensionContext() {}
 
-func NewObjectPropertyContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ObjectPropertyContext {
-	var p = new(ObjectPropertyContext)
+func NewListComprehensionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ListComprehensionContext {
+	var p = new(ListComprehensionContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = tblangParserRULE_objectProperty
+	p.RuleIndex = tblangParserRULE_listComprehension
 
 	return p
 }
 
-func (s *ObjectPropertyContext) GetParser() antlr.Parser { return s.parser }
+func (s *ListComprehensionContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ObjectPropertyContext) IDENTIFIER() antlr.TerminalNode {
-	return s.GetToken(tblangParserIDENTIFIER, 0)
+func (s *ListComprehensionContext) LBRACKET() antlr.TerminalNode {
+	return s.GetToken(tblangParserLBRACKET, 0)
+}
+
+func (s *ListComprehensionContext) FOR() antlr.TerminalNode {
+	return s.GetToken(tblangParserFOR, 0)
+}
+
+func (s *ListComprehensionContext) AllIDENTIFIER() []antlr.TerminalNode {
+	return s.GetTokens(tblangParserIDENTIFIER)
+}
+
+func (s *ListComprehensionContext) IDENTIFIER(i int) antlr.TerminalNode {
+	return s.GetToken(tblangParserIDENTIFIER, i)
+}
+
+func (s *ListComprehensionContext) IN() antlr.TerminalNode {
+	return s.GetToken(tblangParserIN, 0)
+}
+
+func (s *ListComprehensionContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
 }
 
-func (s *ObjectPropertyContext) Expression() IExpressionContext {
+func (s *ListComprehensionContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
+	j := 0
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
 		}
 	}
 
@@ -2182,68 +4581,73 @@ func (s *ObjectPropertyContext) Expression() IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-func (s *ObjectPropertyContext) COLON() antlr.TerminalNode {
+func (s *ListComprehensionContext) COLON() antlr.TerminalNode {
 	return s.GetToken(tblangParserCOLON, 0)
 }
 
-func (s *ObjectPropertyContext) ASSIGN() antlr.TerminalNode {
-	return s.GetToken(tblangParserASSIGN, 0)
+func (s *ListComprehensionContext) RBRACKET() antlr.TerminalNode {
+	return s.GetToken(tblangParserRBRACKET, 0)
 }
 
-func (s *ObjectPropertyContext) COMMA() antlr.TerminalNode {
+func (s *ListComprehensionContext) COMMA() antlr.TerminalNode {
 	return s.GetToken(tblangParserCOMMA, 0)
 }
 
-func (s *ObjectPropertyContext) GetRuleContext() antlr.RuleContext {
+func (s *ListComprehensionContext) IF() antlr.TerminalNode {
+	return s.GetToken(tblangParserIF, 0)
+}
+
+func (s *ListComprehensionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ObjectPropertyContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ListComprehensionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *ObjectPropertyContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *ListComprehensionContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.EnterObjectProperty(s)
+		listenerT.EnterListComprehension(s)
 	}
 }
 
-func (s *ObjectPropertyContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *ListComprehensionContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.ExitObjectProperty(s)
+		listenerT.ExitListComprehension(s)
 	}
 }
 
-func (p *tblangParser) ObjectProperty() (localctx IObjectPropertyContext) {
-	localctx = NewObjectPropertyContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, tblangParserRULE_objectProperty)
+func (p *tblangParser) ListComprehension() (localctx IListComprehensionContext) {
+	localctx = NewListComprehensionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 38, tblangParserRULE_listComprehension)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(124)
-		p.Match(tblangParserIDENTIFIER)
+		p.SetState(275)
+		p.Match(tblangParserLBRACKET)
 		if p.HasError() {
 
 			goto errorExit
 		}
 	}
 	{
-		p.SetState(125)
-		_la = p.GetTokenStream().LA(1)
+		p.SetState(276)
+		p.Match(tblangParserFOR)
+		if p.HasError() {
 
-		if !(_la == tblangParserASSIGN || _la == tblangParserCOLON) {
-			p.GetErrorHandler().RecoverInline(p)
-		} else {
-			p.GetErrorHandler().ReportMatch(p)
-			p.Consume()
+			goto errorExit
 		}
 	}
 	{
-		p.SetState(126)
-		p.expression(0)
+		p.SetState(277)
+		p.Match(tblangParserIDENTIFIER)
+		if p.HasError() {
+
+			goto errorExit
+		}
 	}
-	p.SetState(128)
+	p.SetState(280)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2252,14 +4656,76 @@ func (p *tblangParser) ObjectProperty() (localctx IObjectPropertyContext) {
 
 	if _la == tblangParserCOMMA {
 		{
-			p.SetState(127)
+			p.SetState(278)
 			p.Match(tblangParserCOMMA)
 			if p.HasError() {
 
 				goto errorExit
 			}
 		}
+		{
+			p.SetState(279)
+			p.Match(tblangParserIDENTIFIER)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+
+	}
+	{
+		p.SetState(282)
+		p.Match(tblangParserIN)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(283)
+		p.expression(0)
+	}
+	{
+		p.SetState(284)
+		p.Match(tblangParserCOLON)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(285)
+		p.expression(0)
+	}
+	p.SetState(288)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == tblangParserIF {
+		{
+			p.SetState(286)
+			p.Match(tblangParserIF)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(287)
+			p.expression(0)
+		}
+
+	}
+	{
+		p.SetState(290)
+		p.Match(tblangParserRBRACKET)
+		if p.HasError() {
 
+			goto errorExit
+		}
 	}
 
 errorExit:
@@ -2275,62 +4741,80 @@ errorExit:
 	goto errorExit
 }
 
-type IArrayLiteralContext interface {
+type IMapComprehensionContext interface {
 	antlr.ParserRuleContext
 
 	GetParser() antlr.Parser
 
-	LBRACKET() antlr.TerminalNode
-	RBRACKET() antlr.TerminalNode
+	LBRACE() antlr.TerminalNode
+	FOR() antlr.TerminalNode
+	AllIDENTIFIER() []antlr.TerminalNode
+	IDENTIFIER(i int) antlr.TerminalNode
+	IN() antlr.TerminalNode
 	AllExpression() []IExpressionContext
 	Expression(i int) IExpressionContext
-	AllCOMMA() []antlr.TerminalNode
-	COMMA(i int) antlr.TerminalNode
+	COLON() antlr.TerminalNode
+	ARROW() antlr.TerminalNode
+	RBRACE() antlr.TerminalNode
+	COMMA() antlr.TerminalNode
+	IF() antlr.TerminalNode
 
-	IsArrayLiteralContext()
+	IsMapComprehensionContext()
 }
 
-type ArrayLiteralContext struct {
+type MapComprehensionContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyArrayLiteralContext() *ArrayLiteralContext {
-	var p = new(ArrayLiteralContext)
+func NewEmptyMapComprehensionContext() *MapComprehensionContext {
+	var p = new(MapComprehensionContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_arrayLiteral
+	p.RuleIndex = tblangParserRULE_mapComprehension
 	return p
 }
 
-func InitEmptyArrayLiteralContext(p *ArrayLiteralContext) {
+func InitEmptyMapComprehensionContext(p *MapComprehensionContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = tblangParserRULE_arrayLiteral
+	p.RuleIndex = tblangParserRULE_mapComprehension
 }
 
-func (*ArrayLiteralContext) IsArrayLiteralContext() {}
+func (*MapComprehensionContext) IsMapComprehensionContext() {}
 
-func NewArrayLiteralContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ArrayLiteralContext {
-	var p = new(ArrayLiteralContext)
+func NewMapComprehensionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MapComprehensionContext {
+	var p = new(MapComprehensionContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = tblangParserRULE_arrayLiteral
+	p.RuleIndex = tblangParserRULE_mapComprehension
 
 	return p
 }
 
-func (s *ArrayLiteralContext) GetParser() antlr.Parser { return s.parser }
+func (s *MapComprehensionContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ArrayLiteralContext) LBRACKET() antlr.TerminalNode {
-	return s.GetToken(tblangParserLBRACKET, 0)
+func (s *MapComprehensionContext) LBRACE() antlr.TerminalNode {
+	return s.GetToken(tblangParserLBRACE, 0)
 }
 
-func (s *ArrayLiteralContext) RBRACKET() antlr.TerminalNode {
-	return s.GetToken(tblangParserRBRACKET, 0)
+func (s *MapComprehensionContext) FOR() antlr.TerminalNode {
+	return s.GetToken(tblangParserFOR, 0)
 }
 
-func (s *ArrayLiteralContext) AllExpression() []IExpressionContext {
+func (s *MapComprehensionContext) AllIDENTIFIER() []antlr.TerminalNode {
+	return s.GetTokens(tblangParserIDENTIFIER)
+}
+
+func (s *MapComprehensionContext) IDENTIFIER(i int) antlr.TerminalNode {
+	return s.GetToken(tblangParserIDENTIFIER, i)
+}
+
+func (s *MapComprehensionContext) IN() antlr.TerminalNode {
+	return s.GetToken(tblangParserIN, 0)
+}
+
+func (s *MapComprehensionContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -2351,7 +4835,7 @@ func (s *ArrayLiteralContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *ArrayLiteralContext) Expression(i int) IExpressionContext {
+func (s *MapComprehensionContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -2371,93 +4855,163 @@ func (s *ArrayLiteralContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-func (s *ArrayLiteralContext) AllCOMMA() []antlr.TerminalNode {
-	return s.GetTokens(tblangParserCOMMA)
+func (s *MapComprehensionContext) COLON() antlr.TerminalNode {
+	return s.GetToken(tblangParserCOLON, 0)
 }
 
-func (s *ArrayLiteralContext) COMMA(i int) antlr.TerminalNode {
-	return s.GetToken(tblangParserCOMMA, i)
+func (s *MapComprehensionContext) ARROW() antlr.TerminalNode {
+	return s.GetToken(tblangParserARROW, 0)
 }
 
-func (s *ArrayLiteralContext) GetRuleContext() antlr.RuleContext {
+func (s *MapComprehensionContext) RBRACE() antlr.TerminalNode {
+	return s.GetToken(tblangParserRBRACE, 0)
+}
+
+func (s *MapComprehensionContext) COMMA() antlr.TerminalNode {
+	return s.GetToken(tblangParserCOMMA, 0)
+}
+
+func (s *MapComprehensionContext) IF() antlr.TerminalNode {
+	return s.GetToken(tblangParserIF, 0)
+}
+
+func (s *MapComprehensionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ArrayLiteralContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MapComprehensionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *ArrayLiteralContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MapComprehensionContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.EnterArrayLiteral(s)
+		listenerT.EnterMapComprehension(s)
 	}
 }
 
-func (s *ArrayLiteralContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MapComprehensionContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(tblangListener); ok {
-		listenerT.ExitArrayLiteral(s)
+		listenerT.ExitMapComprehension(s)
 	}
 }
 
-func (p *tblangParser) ArrayLiteral() (localctx IArrayLiteralContext) {
-	localctx = NewArrayLiteralContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, tblangParserRULE_arrayLiteral)
+func (p *tblangParser) MapComprehension() (localctx IMapComprehensionContext) {
+	localctx = NewMapComprehensionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 40, tblangParserRULE_mapComprehension)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(130)
-		p.Match(tblangParserLBRACKET)
+		p.SetState(292)
+		p.Match(tblangParserLBRACE)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(293)
+		p.Match(tblangParserFOR)
+		if p.HasError() {
+
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(294)
+		p.Match(tblangParserIDENTIFIER)
 		if p.HasError() {
 
 			goto errorExit
 		}
 	}
-	p.SetState(139)
+	p.SetState(297)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 	_la = p.GetTokenStream().LA(1)
 
-	if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&172272) != 0 {
+	if _la == tblangParserCOMMA {
 		{
-			p.SetState(131)
-			p.expression(0)
+			p.SetState(295)
+			p.Match(tblangParserCOMMA)
+			if p.HasError() {
+
+				goto errorExit
+			}
 		}
-		p.SetState(136)
-		p.GetErrorHandler().Sync(p)
+		{
+			p.SetState(296)
+			p.Match(tblangParserIDENTIFIER)
+			if p.HasError() {
+
+				goto errorExit
+			}
+		}
+
+	}
+	{
+		p.SetState(299)
+		p.Match(tblangParserIN)
 		if p.HasError() {
+
 			goto errorExit
 		}
-		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(300)
+		p.expression(0)
+	}
+	{
+		p.SetState(301)
+		p.Match(tblangParserCOLON)
+		if p.HasError() {
 
-		for _la == tblangParserCOMMA {
-			{
-				p.SetState(132)
-				p.Match(tblangParserCOMMA)
-				if p.HasError() {
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(302)
+		p.expression(0)
+	}
+	{
+		p.SetState(303)
+		p.Match(tblangParserARROW)
+		if p.HasError() {
 
-					goto errorExit
-				}
-			}
-			{
-				p.SetState(133)
-				p.expression(0)
-			}
+			goto errorExit
+		}
+	}
+	{
+		p.SetState(304)
+		p.expression(0)
+	}
+	p.SetState(307)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
 
-			p.SetState(138)
-			p.GetErrorHandler().Sync(p)
+	if _la == tblangParserIF {
+		{
+			p.SetState(305)
+			p.Match(tblangParserIF)
 			if p.HasError() {
+
 				goto errorExit
 			}
-			_la = p.GetTokenStream().LA(1)
+		}
+		{
+			p.SetState(306)
+			p.expression(0)
 		}
 
 	}
 	{
-		p.SetState(141)
-		p.Match(tblangParserRBRACKET)
+		p.SetState(309)
+		p.Match(tblangParserRBRACE)
 		if p.HasError() {
 
 			goto errorExit
@@ -2479,7 +5033,7 @@ errorExit:
 
 func (p *tblangParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
-	case 8:
+	case 15:
 		var t *ExpressionContext = nil
 		if localctx != nil {
 			t = localctx.(*ExpressionContext)
@@ -2494,8 +5048,32 @@ func (p *tblangParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex
 func (p *tblangParser) Expression_Sempred(localctx antlr.RuleContext, predIndex int) bool {
 	switch predIndex {
 	case 0:
+		return p.Precpred(p.GetParserRuleContext(), 7)
+
+	case 1:
+		return p.Precpred(p.GetParserRuleContext(), 6)
+
+	case 2:
+		return p.Precpred(p.GetParserRuleContext(), 5)
+
+	case 3:
+		return p.Precpred(p.GetParserRuleContext(), 4)
+
+	case 4:
+		return p.Precpred(p.GetParserRuleContext(), 3)
+
+	case 5:
 		return p.Precpred(p.GetParserRuleContext(), 2)
 
+	case 6:
+		return p.Precpred(p.GetParserRuleContext(), 1)
+
+	case 7:
+		return p.Precpred(p.GetParserRuleContext(), 11)
+
+	case 8:
+		return p.Precpred(p.GetParserRuleContext(), 10)
+
 	default:
 		panic("No predicate with index: " + fmt.Sprint(predIndex))
 	}
@@ -51,20 +51,14 @@ func (c *GRPCClient) Configure(ctx context.Context, req *ConfigureRequest) (*Con
 }
 
 func (c *GRPCClient) PlanResourceChange(ctx context.Context, req *PlanResourceChangeRequest) (*PlanResourceChangeResponse, error) {
-	protoReq := &proto.PlanResourceChangeRequest{
-		TypeName: req.TypeName,
-	}
+	protoReq := PlanResourceChangeRequestToProto(req)
 
 	protoResp, err := c.client.PlanResourceChange(ctx, protoReq)
 	if err != nil {
 		return nil, err
 	}
 
-	return &PlanResourceChangeResponse{
-		PlannedState:    req.ProposedNewState,
-		RequiresReplace: protoResp.RequiresReplace,
-		PlannedPrivate:  protoResp.PlannedPrivate,
-	}, nil
+	return ProtoToPlanResourceChangeResponse(protoResp), nil
 }
 
 func (c *GRPCClient) ApplyResourceChange(ctx context.Context, req *ApplyResourceChangeRequest) (*ApplyResourceChangeResponse, error) {
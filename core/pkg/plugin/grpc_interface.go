@@ -87,6 +87,7 @@ func ProtoToAttribute(p *proto.Attribute) *Attribute {
 		Optional:    p.Optional,
 		Computed:    p.Computed,
 		Sensitive:   p.Sensitive,
+		ForceNew:    p.ForceNew,
 	}
 }
 
@@ -184,3 +185,51 @@ func ProtoToApplyResourceChangeResponse(p *proto.ApplyResourceChangeResponse) *A
 
 	return resp
 }
+
+func PlanResourceChangeRequestToProto(req *PlanResourceChangeRequest) *proto.PlanResourceChangeRequest {
+	protoReq := &proto.PlanResourceChangeRequest{
+		TypeName:     req.TypeName,
+		PriorPrivate: req.PriorPrivate,
+	}
+
+	if req.PriorState != nil {
+		if jsonData, err := json.Marshal(req.PriorState); err == nil {
+			protoReq.PriorState = &proto.DynamicValue{Json: jsonData}
+		}
+	}
+
+	if req.ProposedNewState != nil {
+		if jsonData, err := json.Marshal(req.ProposedNewState); err == nil {
+			protoReq.ProposedNewState = &proto.DynamicValue{Json: jsonData}
+		}
+	}
+
+	if req.Config != nil {
+		if jsonData, err := json.Marshal(req.Config); err == nil {
+			protoReq.Config = &proto.DynamicValue{Json: jsonData}
+		}
+	}
+
+	return protoReq
+}
+
+func ProtoToPlanResourceChangeResponse(p *proto.PlanResourceChangeResponse) *PlanResourceChangeResponse {
+	resp := &PlanResourceChangeResponse{
+		RequiresReplace: p.RequiresReplace,
+		PlannedPrivate:  p.PlannedPrivate,
+		Diagnostics:     make([]*Diagnostic, len(p.Diagnostics)),
+	}
+
+	if p.PlannedState != nil && len(p.PlannedState.Json) > 0 {
+		var state interface{}
+		if err := json.Unmarshal(p.PlannedState.Json, &state); err == nil {
+			resp.PlannedState = state
+		}
+	}
+
+	for i, diag := range p.Diagnostics {
+		resp.Diagnostics[i] = ProtoToDiagnostic(diag)
+	}
+
+	return resp
+}
@@ -128,7 +128,39 @@ func (s *GRPCServer) ApplyResourceChange(ctx context.Context, req *proto.ApplyRe
 }
 
 func (s *GRPCServer) PlanResourceChange(ctx context.Context, req *proto.PlanResourceChangeRequest) (*proto.PlanResourceChangeResponse, error) {
-	return &proto.PlanResourceChangeResponse{}, nil
+
+	interfaceReq := &PlanResourceChangeRequest{
+		TypeName:     req.TypeName,
+		PriorPrivate: req.PriorPrivate,
+	}
+
+	if req.PriorState != nil && len(req.PriorState.Json) > 0 {
+		var state interface{}
+		if err := json.Unmarshal(req.PriorState.Json, &state); err == nil {
+			interfaceReq.PriorState = state
+		}
+	}
+
+	if req.ProposedNewState != nil && len(req.ProposedNewState.Json) > 0 {
+		var state interface{}
+		if err := json.Unmarshal(req.ProposedNewState.Json, &state); err == nil {
+			interfaceReq.ProposedNewState = state
+		}
+	}
+
+	if req.Config != nil && len(req.Config.Json) > 0 {
+		var config interface{}
+		if err := json.Unmarshal(req.Config.Json, &config); err == nil {
+			interfaceReq.Config = config
+		}
+	}
+
+	resp, err := s.provider.PlanResourceChange(ctx, interfaceReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return PlanResourceChangeResponseToProto(resp), nil
 }
 
 func (s *GRPCServer) ReadResource(ctx context.Context, req *proto.ReadResourceRequest) (*proto.ReadResourceResponse, error) {
@@ -206,6 +238,7 @@ func AttributeToProto(attr *Attribute) *proto.Attribute {
 		Optional:    attr.Optional,
 		Computed:    attr.Computed,
 		Sensitive:   attr.Sensitive,
+		ForceNew:    attr.ForceNew,
 	}
 }
 
@@ -261,3 +294,23 @@ func ApplyResourceChangeResponseToProto(resp *ApplyResourceChangeResponse) *prot
 
 	return protoResp
 }
+
+func PlanResourceChangeResponseToProto(resp *PlanResourceChangeResponse) *proto.PlanResourceChangeResponse {
+	protoResp := &proto.PlanResourceChangeResponse{
+		RequiresReplace: resp.RequiresReplace,
+		PlannedPrivate:  resp.PlannedPrivate,
+		Diagnostics:     make([]*proto.Diagnostic, len(resp.Diagnostics)),
+	}
+
+	if resp.PlannedState != nil {
+		if jsonData, err := json.Marshal(resp.PlannedState); err == nil {
+			protoResp.PlannedState = &proto.DynamicValue{Json: jsonData}
+		}
+	}
+
+	for i, diag := range resp.Diagnostics {
+		protoResp.Diagnostics[i] = DiagnosticToProto(diag)
+	}
+
+	return protoResp
+}
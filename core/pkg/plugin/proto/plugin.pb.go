@@ -126,6 +126,7 @@ type Attribute struct {
 	Optional      bool                   `protobuf:"varint,4,opt,name=optional,proto3" json:"optional,omitempty"`
 	Computed      bool                   `protobuf:"varint,5,opt,name=computed,proto3" json:"computed,omitempty"`
 	Sensitive     bool                   `protobuf:"varint,6,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	ForceNew      bool                   `protobuf:"varint,7,opt,name=force_new,json=forceNew,proto3" json:"force_new,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -201,6 +202,13 @@ func (x *Attribute) GetSensitive() bool {
 	return false
 }
 
+func (x *Attribute) GetForceNew() bool {
+	if x != nil {
+		return x.ForceNew
+	}
+	return false
+}
+
 type BlockType struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NestingMode   string                 `protobuf:"bytes,1,opt,name=nesting_mode,json=nestingMode,proto3" json:"nesting_mode,omitempty"`
@@ -1234,14 +1242,15 @@ const file_pkg_plugin_proto_plugin_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\v2\x11.plugin.AttributeR\x05value:\x028\x01\x1aP\n" +
 	"\x0fBlockTypesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12'\n" +
-	"\x05value\x18\x02 \x01(\v2\x11.plugin.BlockTypeR\x05value:\x028\x01\"\xb3\x01\n" +
+	"\x05value\x18\x02 \x01(\v2\x11.plugin.BlockTypeR\x05value:\x028\x01\"\xd0\x01\n" +
 	"\tAttribute\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
 	"\brequired\x18\x03 \x01(\bR\brequired\x12\x1a\n" +
 	"\boptional\x18\x04 \x01(\bR\boptional\x12\x1a\n" +
 	"\bcomputed\x18\x05 \x01(\bR\bcomputed\x12\x1c\n" +
-	"\tsensitive\x18\x06 \x01(\bR\tsensitive\"\x93\x01\n" +
+	"\tsensitive\x18\x06 \x01(\bR\tsensitive\x12\x1b\n" +
+	"\tforce_new\x18\a \x01(\bR\bforceNew\"\x93\x01\n" +
 	"\tBlockType\x12!\n" +
 	"\fnesting_mode\x18\x01 \x01(\tR\vnestingMode\x12)\n" +
 	"\x05block\x18\x02 \x01(\v2\x13.plugin.SchemaBlockR\x05block\x12\x1b\n" +
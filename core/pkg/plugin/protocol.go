@@ -38,6 +38,10 @@ type Attribute struct {
 	Optional    bool   `json:"optional"`
 	Computed    bool   `json:"computed"`
 	Sensitive   bool   `json:"sensitive"`
+
+	// ForceNew marks attributes that cannot be changed in place; changing
+	// one replaces the resource.
+	ForceNew bool `json:"force_new"`
 }
 
 type BlockType struct {
@@ -26,6 +26,10 @@ func (p *AWSProvider) ApplyResourceChange(ctx context.Context, req *plugin.Apply
 		return p.handleDestroy(ctx, req)
 	}
 
+	if req.PriorState != nil && req.PlannedState != nil {
+		return p.handleUpdate(ctx, req)
+	}
+
 	return p.handleCreateOrUpdate(ctx, req)
 }
 
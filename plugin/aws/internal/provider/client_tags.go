@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// UpdateTags replaces the tags of an EC2 resource: tags in oldTags that are
+// not in newTags are deleted, and every tag in newTags is set.
+func (c *AWSClient) UpdateTags(ctx context.Context, resourceID string, oldTags, newTags map[string]string) error {
+	var removed []types.Tag
+	for key := range oldTags {
+		if _, kept := newTags[key]; !kept && key != "ManagedBy" {
+			removed = append(removed, types.Tag{Key: aws.String(key)})
+		}
+	}
+
+	if len(removed) > 0 {
+		_, err := c.EC2Client.DeleteTags(ctx, &ec2.DeleteTagsInput{
+			Resources: []string{resourceID},
+			Tags:      removed,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete tags from %s: %w", resourceID, err)
+		}
+	}
+
+	_, err := c.EC2Client.CreateTags(ctx, &ec2.CreateTagsInput{
+		Resources: []string{resourceID},
+		Tags:      c.buildTags("", newTags),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to tag %s: %w", resourceID, err)
+	}
+
+	return nil
+}
@@ -29,7 +29,7 @@ func (c *AWSClient) CreateVPC(ctx context.Context, cidrBlock string, tags map[st
 	}
 
 	vpcID := *result.Vpc.VpcId
-	if err := c.enableVPCDNS(ctx, vpcID); err != nil {
+	if err := c.ModifyVPCDNS(ctx, vpcID, true, true); err != nil {
 
 		fmt.Printf("Warning: failed to enable DNS for VPC %s: %v\n", vpcID, err)
 	}
@@ -40,22 +40,39 @@ func (c *AWSClient) CreateVPC(ctx context.Context, cidrBlock string, tags map[st
 	}, nil
 }
 
-func (c *AWSClient) enableVPCDNS(ctx context.Context, vpcID string) error {
-
-	_, err := c.EC2Client.ModifyVpcAttribute(ctx, &ec2.ModifyVpcAttributeInput{
-		VpcId:              aws.String(vpcID),
-		EnableDnsHostnames: &types.AttributeBooleanValue{Value: aws.Bool(true)},
-	})
-	if err != nil {
-		return fmt.Errorf("failed to enable DNS hostnames: %w", err)
+// ModifyVPCDNS sets the DNS hostnames and DNS support attributes of a VPC.
+// DNS support is enabled before, and disabled after, DNS hostnames, since
+// hostnames require it.
+func (c *AWSClient) ModifyVPCDNS(ctx context.Context, vpcID string, hostnames, support bool) error {
+	setHostnames := func() error {
+		_, err := c.EC2Client.ModifyVpcAttribute(ctx, &ec2.ModifyVpcAttributeInput{
+			VpcId:              aws.String(vpcID),
+			EnableDnsHostnames: &types.AttributeBooleanValue{Value: aws.Bool(hostnames)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set DNS hostnames: %w", err)
+		}
+		return nil
+	}
+	setSupport := func() error {
+		_, err := c.EC2Client.ModifyVpcAttribute(ctx, &ec2.ModifyVpcAttributeInput{
+			VpcId:            aws.String(vpcID),
+			EnableDnsSupport: &types.AttributeBooleanValue{Value: aws.Bool(support)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set DNS support: %w", err)
+		}
+		return nil
 	}
 
-	_, err = c.EC2Client.ModifyVpcAttribute(ctx, &ec2.ModifyVpcAttributeInput{
-		VpcId:           aws.String(vpcID),
-		EnableDnsSupport: &types.AttributeBooleanValue{Value: aws.Bool(true)},
-	})
-	if err != nil {
-		return fmt.Errorf("failed to enable DNS support: %w", err)
+	steps := []func() error{setHostnames, setSupport}
+	if support {
+		steps = []func() error{setSupport, setHostnames}
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -15,3 +15,10 @@ func extractTags(config map[string]interface{}) map[string]string {
 
 	return tags
 }
+
+func boolAttribute(config map[string]interface{}, key string, defaultValue bool) bool {
+	if value, ok := config[key].(bool); ok {
+		return value
+	}
+	return defaultValue
+}
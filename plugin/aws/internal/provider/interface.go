@@ -2,14 +2,49 @@ package provider
 
 import (
 	"context"
+	"reflect"
+	"sort"
 
 	"github.com/tblang/core/pkg/plugin"
 )
 
+// PlanResourceChange reports the ForceNew attributes whose value differs
+// between the prior and proposed state. A resource updated in place keeps
+// the attributes computed for it; for a new or replaced resource they are
+// left out of the planned state, as they are only known after apply.
 func (p *AWSProvider) PlanResourceChange(ctx context.Context, req *plugin.PlanResourceChangeRequest) (*plugin.PlanResourceChangeResponse, error) {
+	attributes := schemaAttributes(req.TypeName)
+	proposed, ok := req.ProposedNewState.(map[string]interface{})
+	if attributes == nil || !ok {
+		return &plugin.PlanResourceChangeResponse{
+			PlannedState: req.ProposedNewState,
+		}, nil
+	}
+
+	priorState, exists := req.PriorState.(map[string]interface{})
+	if !exists {
+		return &plugin.PlanResourceChangeResponse{
+			PlannedState: proposed,
+		}, nil
+	}
+
+	var requiresReplace []string
+	for name, attr := range attributes {
+		if attr.ForceNew && !attr.Computed && !reflect.DeepEqual(priorState[name], proposed[name]) {
+			requiresReplace = append(requiresReplace, name)
+		}
+	}
+	sort.Strings(requiresReplace)
+
+	if len(requiresReplace) > 0 {
+		return &plugin.PlanResourceChangeResponse{
+			PlannedState:    proposed,
+			RequiresReplace: requiresReplace,
+		}, nil
+	}
 
 	return &plugin.PlanResourceChangeResponse{
-		PlannedState: req.ProposedNewState,
+		PlannedState: updatedState(req.TypeName, priorState, proposed),
 	}, nil
 }
 
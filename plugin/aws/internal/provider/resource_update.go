@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tblang/core/pkg/plugin"
+)
+
+// resourceIDAttributes names the computed attribute holding the AWS ID of
+// each resource type.
+var resourceIDAttributes = map[string]string{
+	"vpc":              "vpc_id",
+	"subnet":           "subnet_id",
+	"security_group":   "group_id",
+	"ec2":              "instance_id",
+	"internet_gateway": "gateway_id",
+	"route_table":      "route_table_id",
+	"eip":              "allocation_id",
+	"nat_gateway":      "nat_gateway_id",
+}
+
+// handleUpdate changes an existing resource in place to its planned state,
+// which keeps the prior value of attributes under ignore_changes. Only the
+// attributes not marked ForceNew in the schema reach here; changing any
+// other attribute replaces the resource instead.
+func (p *AWSProvider) handleUpdate(ctx context.Context, req *plugin.ApplyResourceChangeRequest) (*plugin.ApplyResourceChangeResponse, error) {
+	switch req.TypeName {
+	case "data_ami", "data_vpc", "data_subnet", "data_availability_zones", "data_caller_identity":
+		return p.handleCreateOrUpdate(ctx, req)
+	}
+
+	idAttribute, exists := resourceIDAttributes[req.TypeName]
+	if !exists {
+		return &plugin.ApplyResourceChangeResponse{
+			Diagnostics: []*plugin.Diagnostic{
+				{
+					Severity: "error",
+					Summary:  "Unsupported resource type",
+					Detail:   fmt.Sprintf("Resource type %s is not supported", req.TypeName),
+				},
+			},
+		}, nil
+	}
+
+	priorState, priorOK := req.PriorState.(map[string]interface{})
+	planned, plannedOK := req.PlannedState.(map[string]interface{})
+	if !priorOK || !plannedOK {
+		return &plugin.ApplyResourceChangeResponse{
+			Diagnostics: []*plugin.Diagnostic{
+				{
+					Severity: "error",
+					Summary:  "Invalid update request",
+					Detail:   "Prior and planned state must be maps",
+				},
+			},
+		}, nil
+	}
+
+	resourceID, _ := priorState[idAttribute].(string)
+	if resourceID == "" {
+		return &plugin.ApplyResourceChangeResponse{
+			Diagnostics: []*plugin.Diagnostic{
+				{
+					Severity: "error",
+					Summary:  "Missing resource ID",
+					Detail:   fmt.Sprintf("%s is required to update %s", idAttribute, req.TypeName),
+				},
+			},
+		}, nil
+	}
+
+	err := p.client.UpdateTags(ctx, resourceID, extractTags(priorState), extractTags(planned))
+	if err == nil {
+		switch req.TypeName {
+		case "vpc":
+			hostnames := boolAttribute(planned, "enable_dns_hostnames", true)
+			support := boolAttribute(planned, "enable_dns_support", true)
+			if hostnames != boolAttribute(priorState, "enable_dns_hostnames", true) || support != boolAttribute(priorState, "enable_dns_support", true) {
+				err = p.client.ModifyVPCDNS(ctx, resourceID, hostnames, support)
+			}
+		case "subnet":
+			mapPublicIP := boolAttribute(planned, "map_public_ip", false)
+			if mapPublicIP != boolAttribute(priorState, "map_public_ip", false) {
+				err = p.client.ConfigureSubnetPublicIP(ctx, resourceID, mapPublicIP)
+			}
+		}
+	}
+	if err != nil {
+		return &plugin.ApplyResourceChangeResponse{
+			Diagnostics: []*plugin.Diagnostic{
+				{
+					Severity: "error",
+					Summary:  fmt.Sprintf("Failed to update %s", req.TypeName),
+					Detail:   err.Error(),
+				},
+			},
+		}, nil
+	}
+
+	return &plugin.ApplyResourceChangeResponse{
+		NewState: updatedState(req.TypeName, priorState, planned),
+	}, nil
+}
+
+// updatedState returns config together with the attributes of priorState
+// that the provider computed, such as IDs.
+func updatedState(typeName string, priorState, config map[string]interface{}) map[string]interface{} {
+	attributes := schemaAttributes(typeName)
+
+	newState := make(map[string]interface{})
+	for k, v := range config {
+		newState[k] = v
+	}
+	for k, v := range priorState {
+		if _, configured := config[k]; configured {
+			continue
+		}
+		if attr, exists := attributes[k]; !exists || attr.Computed {
+			newState[k] = v
+		}
+	}
+
+	return newState
+}
+
+// schemaAttributes returns the attributes of the resource or data source
+// schema for typeName, or nil for unknown types.
+func schemaAttributes(typeName string) map[string]*plugin.Attribute {
+	schema, exists := getResourceSchemas()[typeName]
+	if !exists {
+		schema, exists = getDataSourceSchemas()[typeName]
+	}
+	if !exists {
+		return nil
+	}
+	return schema.Block.Attributes
+}